@@ -20,5 +20,7 @@ var Provider = app.Provider{
 	CanDeployImmediate: false,
 	NewPusher:          ecr.NewPusher,
 	NewDeployer:        batch.NewDeployer,
+	NewDeployWatcher:   app.NewPollingDeployWatcher(batch.NewDeployStatusGetter),
+	NewStatuser:        batch.NewStatuser,
 	NewLogStreamer:     cloudwatch.NewLogStreamer,
 }
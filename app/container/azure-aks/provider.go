@@ -20,4 +20,6 @@ var Provider = app.Provider{
 	NewPusher:          acr.NewPusher,
 	NewDeployer:        aks.NewDeployer,
 	NewDeployWatcher:   aks.NewDeployWatcher,
+	NewStatuser:        aks.NewStatuser,
+	NewLogStreamer:     aks.NewLogStreamer,
 }
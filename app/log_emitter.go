@@ -4,6 +4,7 @@ import (
 	"github.com/fatih/color"
 	"github.com/nullstone-io/deployment-sdk/display"
 	"io"
+	"strings"
 	"time"
 )
 
@@ -31,6 +32,13 @@ type LogMessage struct {
 
 	Timestamp time.Time `json:"timestamp"`
 	Message   string    `json:"message"`
+
+	// Level is the severity of a structured log message (e.g. `info`, `error`)
+	// Empty if the log source did not report a level
+	Level string `json:"level,omitempty"`
+
+	// Fields contains the remaining attributes of a structured log message
+	Fields map[string]any `json:"fields,omitempty"`
 }
 
 func NewWriterLogEmitter(w io.Writer) LogEmitter {
@@ -39,6 +47,9 @@ func NewWriterLogEmitter(w io.Writer) LogEmitter {
 		if !message.Timestamp.IsZero() {
 			normal.Fprintf(w, " %s", display.FormatTime(message.Timestamp))
 		}
+		if message.Level != "" {
+			bold.Fprintf(w, " %s", strings.ToUpper(message.Level))
+		}
 		normal.Fprintf(w, " %s", message.Message)
 		normal.Fprintln(w)
 	}
@@ -0,0 +1,229 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/nullstone-io/deployment-sdk/maps"
+)
+
+type LogSeverity string
+
+// Log severities in increasing order, matching the levels of syslog and Cloud Logging
+const (
+	LogSeverityDebug     LogSeverity = "debug"
+	LogSeverityInfo      LogSeverity = "info"
+	LogSeverityNotice    LogSeverity = "notice"
+	LogSeverityWarning   LogSeverity = "warning"
+	LogSeverityError     LogSeverity = "error"
+	LogSeverityCritical  LogSeverity = "critical"
+	LogSeverityAlert     LogSeverity = "alert"
+	LogSeverityEmergency LogSeverity = "emergency"
+)
+
+var (
+	logSeverityOrder = []LogSeverity{
+		LogSeverityDebug, LogSeverityInfo, LogSeverityNotice, LogSeverityWarning,
+		LogSeverityError, LogSeverityCritical, LogSeverityAlert, LogSeverityEmergency,
+	}
+	// logSeverityAliases maps the level names used by common loggers to a LogSeverity
+	logSeverityAliases = map[string]LogSeverity{
+		"trace":         LogSeverityDebug,
+		"information":   LogSeverityInfo,
+		"informational": LogSeverityInfo,
+		"warn":          LogSeverityWarning,
+		"err":           LogSeverityError,
+		"crit":          LogSeverityCritical,
+		"fatal":         LogSeverityCritical,
+		"panic":         LogSeverityCritical,
+		"emerg":         LogSeverityEmergency,
+	}
+)
+
+// ParseLogSeverity normalizes a log level (e.g. "WARN", "Information", "fatal") into a LogSeverity
+// This returns an empty LogSeverity if the level is not recognized
+func ParseLogSeverity(level string) LogSeverity {
+	level = strings.ToLower(strings.TrimSpace(level))
+	if alias, ok := logSeverityAliases[level]; ok {
+		return alias
+	}
+	for _, severity := range logSeverityOrder {
+		if string(severity) == level {
+			return severity
+		}
+	}
+	return ""
+}
+
+// Rank orders severities from 1 (debug) to 8 (emergency); an unrecognized severity has a rank of 0
+func (s LogSeverity) Rank() int {
+	for i, severity := range logSeverityOrder {
+		if severity == s {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// LogQuery is a provider-neutral filter for log messages
+// Each log streamer translates what it can into its native query language (e.g. Cloud Logging filter, KQL)
+// and filters the remainder client-side with a LogMatcher.
+// All conditions must match for a message to be emitted.
+type LogQuery struct {
+	// MinSeverity only includes messages at or above this severity
+	// Messages without a recognized severity are excluded
+	// If the log source does not report a severity, it is read from the level of structured JSON messages
+	MinSeverity LogSeverity
+
+	// Contains only includes messages that contain this text (case-sensitive)
+	Contains string
+
+	// Regex only includes messages that match this regular expression (RE2 syntax)
+	Regex string
+
+	// Fields only includes structured JSON messages whose top-level fields equal these values
+	// Non-string values are compared using their JSON representation (e.g. "500", "true")
+	// The level and message keys (e.g. "level", "msg") are not fields; use MinSeverity, Contains, or Regex instead
+	Fields map[string]string
+
+	// ResourceLabels only includes messages from resources with these labels
+	// For Cloud Logging, these are monitored resource labels (resource.labels.*)
+	// For Kubernetes, these are pod labels
+	// This cannot be evaluated client-side; providers that do not support it ignore it
+	ResourceLabels map[string]string
+}
+
+func (q *LogQuery) IsEmpty() bool {
+	return q == nil ||
+		(q.MinSeverity == "" && q.Contains == "" && q.Regex == "" && len(q.Fields) == 0 && len(q.ResourceLabels) == 0)
+}
+
+// Compile validates the query and prepares a LogMatcher to evaluate it client-side
+func (q *LogQuery) Compile() (*LogMatcher, error) {
+	m := &LogMatcher{}
+	if q == nil {
+		return m, nil
+	}
+	m.query = *q
+	if q.MinSeverity != "" {
+		severity := ParseLogSeverity(string(q.MinSeverity))
+		if severity == "" {
+			return nil, fmt.Errorf("invalid log query: unknown severity %q", q.MinSeverity)
+		}
+		m.query.MinSeverity = severity
+	}
+	for key := range q.Fields {
+		if slices.Contains(jsonLevelKeys, key) {
+			return nil, fmt.Errorf("invalid log query: field %q is the log level, filter with a minimum severity instead", key)
+		}
+		if slices.Contains(jsonMessageKeys, key) {
+			return nil, fmt.Errorf("invalid log query: field %q is the log message, filter with contains or regex instead", key)
+		}
+	}
+	if q.Regex != "" {
+		var err error
+		if m.regex, err = regexp.Compile(q.Regex); err != nil {
+			return nil, fmt.Errorf("invalid log query: invalid regex: %w", err)
+		}
+	}
+	return m, nil
+}
+
+// FilterEmitter wraps emitter so that only messages matching the query are emitted
+// If the query is empty, emitter is returned as-is
+func (q *LogQuery) FilterEmitter(emitter LogEmitter) (LogEmitter, error) {
+	if q.IsEmpty() {
+		return emitter, nil
+	}
+	m, err := q.Compile()
+	if err != nil {
+		return nil, err
+	}
+	return func(message LogMessage) {
+		if m.Match(message) {
+			emitter(message)
+		}
+	}, nil
+}
+
+// String renders the query for display (e.g. `severity >= error AND contains "timeout"`)
+func (q *LogQuery) String() string {
+	if q.IsEmpty() {
+		return ""
+	}
+	parts := make([]string, 0)
+	if q.MinSeverity != "" {
+		parts = append(parts, fmt.Sprintf("severity >= %s", q.MinSeverity))
+	}
+	if q.Contains != "" {
+		parts = append(parts, fmt.Sprintf("contains %q", q.Contains))
+	}
+	if q.Regex != "" {
+		parts = append(parts, fmt.Sprintf("matches /%s/", q.Regex))
+	}
+	for _, key := range maps.SortedKeys(q.Fields) {
+		parts = append(parts, fmt.Sprintf("%s = %q", key, q.Fields[key]))
+	}
+	for _, key := range maps.SortedKeys(q.ResourceLabels) {
+		parts = append(parts, fmt.Sprintf("resource.%s = %q", key, q.ResourceLabels[key]))
+	}
+	return strings.Join(parts, " AND ")
+}
+
+// LogMatcher evaluates a LogQuery against log messages client-side
+// ResourceLabels are not evaluated because log messages do not carry resource labels
+type LogMatcher struct {
+	query LogQuery
+	regex *regexp.Regexp
+}
+
+func (m *LogMatcher) Match(msg LogMessage) bool {
+	if m.query.Contains != "" && !strings.Contains(msg.Message, m.query.Contains) {
+		return false
+	}
+	if m.regex != nil && !m.regex.MatchString(msg.Message) {
+		return false
+	}
+	if m.query.MinSeverity == "" && len(m.query.Fields) == 0 {
+		return true
+	}
+
+	// Fill in the level and fields from a structured JSON message when the log source did not report them
+	if msg.Level == "" || msg.Fields == nil {
+		parsed := ParseStructuredLogMessage(msg)
+		if msg.Level == "" {
+			msg.Level = parsed.Level
+		}
+		if msg.Fields == nil {
+			msg.Fields = parsed.Fields
+		}
+	}
+	if m.query.MinSeverity != "" {
+		rank := ParseLogSeverity(msg.Level).Rank()
+		if rank == 0 || rank < m.query.MinSeverity.Rank() {
+			return false
+		}
+	}
+	for key, want := range m.query.Fields {
+		got, ok := msg.Fields[key]
+		if !ok || fieldString(got) != want {
+			return false
+		}
+	}
+	return true
+}
+
+// fieldString renders a structured field the way it appears in the JSON log line (e.g. 1000000, not 1e+06)
+func fieldString(val any) string {
+	if v, ok := val.(string); ok {
+		return v
+	}
+	raw, err := json.Marshal(val)
+	if err != nil {
+		return fmt.Sprint(val)
+	}
+	return string(raw)
+}
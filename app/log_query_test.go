@@ -0,0 +1,145 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLogSeverity(t *testing.T) {
+	tests := map[string]LogSeverity{
+		"ERROR":       LogSeverityError,
+		" warn ":      LogSeverityWarning,
+		"Information": LogSeverityInfo,
+		"trace":       LogSeverityDebug,
+		"fatal":       LogSeverityCritical,
+		"emergency":   LogSeverityEmergency,
+		"default":     "",
+		"":            "",
+	}
+	for level, want := range tests {
+		assert.Equal(t, want, ParseLogSeverity(level), level)
+	}
+	assert.True(t, LogSeverityWarning.Rank() < LogSeverityError.Rank())
+	assert.Equal(t, 0, LogSeverity("verbose").Rank())
+}
+
+func TestLogMatcher_Match(t *testing.T) {
+	tests := []struct {
+		name  string
+		query LogQuery
+		msg   LogMessage
+		want  bool
+	}{
+		{
+			name:  "empty query",
+			query: LogQuery{},
+			msg:   LogMessage{Message: "anything"},
+			want:  true,
+		},
+		{
+			name:  "contains is case-sensitive",
+			query: LogQuery{Contains: "Timeout"},
+			msg:   LogMessage{Message: "request timeout"},
+			want:  false,
+		},
+		{
+			name:  "regex",
+			query: LogQuery{Regex: `status=5\d\d`},
+			msg:   LogMessage{Message: "GET /api status=503"},
+			want:  true,
+		},
+		{
+			name:  "severity reported by source",
+			query: LogQuery{MinSeverity: "warn"},
+			msg:   LogMessage{Message: "disk almost full", Level: "WARNING"},
+			want:  true,
+		},
+		{
+			name:  "severity below minimum",
+			query: LogQuery{MinSeverity: LogSeverityError},
+			msg:   LogMessage{Message: "started", Level: "info"},
+			want:  false,
+		},
+		{
+			name:  "severity from structured message",
+			query: LogQuery{MinSeverity: LogSeverityError},
+			msg:   LogMessage{Message: `{"level":"fatal","msg":"boom"}`},
+			want:  true,
+		},
+		{
+			name:  "unknown severity is excluded",
+			query: LogQuery{MinSeverity: LogSeverityDebug},
+			msg:   LogMessage{Message: "plain text line"},
+			want:  false,
+		},
+		{
+			name:  "fields from structured message",
+			query: LogQuery{Fields: map[string]string{"status": "500", "route": "/api"}},
+			msg:   LogMessage{Message: `{"msg":"failed","status":500,"route":"/api"}`},
+			want:  true,
+		},
+		{
+			name:  "field mismatch",
+			query: LogQuery{Fields: map[string]string{"status": "500"}},
+			msg:   LogMessage{Level: "error", Fields: map[string]any{"status": float64(404)}},
+			want:  false,
+		},
+		{
+			name:  "large numbers and objects match their JSON form",
+			query: LogQuery{Fields: map[string]string{"bytes": "1000000", "user": `{"id":7}`, "trace": "null"}},
+			msg:   LogMessage{Message: `{"bytes":1000000,"user":{"id":7},"trace":null}`},
+			want:  true,
+		},
+		{
+			name:  "resource labels are not evaluated client-side",
+			query: LogQuery{ResourceLabels: map[string]string{"zone": "us-east1-b"}},
+			msg:   LogMessage{Message: "hello"},
+			want:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m, err := test.query.Compile()
+			require.NoError(t, err)
+			assert.Equal(t, test.want, m.Match(test.msg))
+		})
+	}
+}
+
+func TestLogQuery_Compile_Invalid(t *testing.T) {
+	_, err := (&LogQuery{MinSeverity: "loud"}).Compile()
+	assert.EqualError(t, err, `invalid log query: unknown severity "loud"`)
+
+	_, err = (&LogQuery{Regex: "("}).Compile()
+	assert.ErrorContains(t, err, "invalid log query: invalid regex")
+
+	// The level and message keys are removed from the parsed fields, so they could never match
+	_, err = (&LogQuery{Fields: map[string]string{"level": "error"}}).Compile()
+	assert.EqualError(t, err, `invalid log query: field "level" is the log level, filter with a minimum severity instead`)
+
+	_, err = (&LogQuery{Fields: map[string]string{"message": "timeout"}}).Compile()
+	assert.EqualError(t, err, `invalid log query: field "message" is the log message, filter with contains or regex instead`)
+}
+
+func TestLogQuery_FilterEmitter(t *testing.T) {
+	got := make([]string, 0)
+	emitter := func(message LogMessage) { got = append(got, message.Message) }
+
+	var nilQuery *LogQuery
+	unfiltered, err := nilQuery.FilterEmitter(emitter)
+	require.NoError(t, err)
+	unfiltered(LogMessage{Message: "kept"})
+
+	query := &LogQuery{MinSeverity: LogSeverityError, Contains: "db"}
+	filtered, err := query.FilterEmitter(emitter)
+	require.NoError(t, err)
+	filtered(LogMessage{Message: "db connection lost", Level: "error"})
+	filtered(LogMessage{Message: "db connected", Level: "info"})
+	filtered(LogMessage{Message: "cache miss", Level: "error"})
+
+	assert.Equal(t, []string{"kept", "db connection lost"}, got)
+	assert.Equal(t, `severity >= error AND contains "db"`, query.String())
+}
@@ -23,6 +23,12 @@ type LogStreamOptions struct {
 	// For AWS Cloudwatch: https://docs.aws.amazon.com/AmazonCloudWatch/latest/logs/FilterAndPatternSyntax.html
 	Pattern *string
 
+	// Query is a provider-neutral filter (severity, text, fields, resource labels)
+	// Each log streamer translates it to its native query language where possible
+	// and filters the remaining conditions client-side
+	// For AWS Cloudwatch, an explicit Pattern takes precedence over translating Query into a filter pattern
+	Query *LogQuery
+
 	// A filter to apply when querying the log source
 	// For Kubernetes, this is a label selector to further filter down the logs
 	Selectors []string
@@ -67,6 +73,20 @@ type LogStreamOptions struct {
 	// If a negative value is specified, watching will disable, the log streamer will terminate as soon as logs are emitted
 	WatchInterval time.Duration
 
+	// Containers limits a Kubernetes log query to the named containers (including init containers)
+	// If empty, logs are streamed from every container in each pod
+	Containers []string
+
+	// Previous includes logs from the last terminated instance of each container that has restarted
+	// This makes the final output of crash-looping containers visible (equivalent to `kubectl logs --previous`)
+	// This is currently supported for Kubernetes only
+	Previous bool
+
+	// ParseJSON parses structured JSON log lines into LogMessage.Level and LogMessage.Fields
+	// Lines that are not JSON objects are emitted unchanged
+	// This is currently supported for Kubernetes only
+	ParseJSON bool
+
 	Emitter LogEmitter
 
 	// CancelFlushTimeout provides a way to configure how long to wait when flushing logs after a cancellation
@@ -21,6 +21,6 @@ var Provider = app.Provider{
 	NewPusher:          gcs.NewZipPusher,
 	NewDeployer:        cloudfunctions.NewDeployer,
 	NewDeployWatcher:   cloudfunctions.NewDeployWatcher,
-	NewStatuser:        nil,
+	NewStatuser:        cloudfunctions.NewStatuser,
 	NewLogStreamer:     cloudlogging.NewLogStreamer,
 }
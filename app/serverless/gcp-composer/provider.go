@@ -20,6 +20,6 @@ var Provider = app.Provider{
 	NewPusher:          composer.NewPusher,
 	NewDeployer:        composer.NewDeployer,
 	NewDeployWatcher:   composer.NewDeployWatcher,
-	NewStatuser:        nil,
+	NewStatuser:        composer.NewStatuser,
 	NewLogStreamer:     cloudlogging.NewLogStreamer,
 }
@@ -21,6 +21,6 @@ var Provider = app.Provider{
 	NewPusher:          s3.NewDirPusher,
 	NewDeployer:        s3.NewDeployer,
 	NewDeployWatcher:   app.NewPollingDeployWatcher(cdn.NewDeployStatusGetter),
-	NewStatuser:        nil,
+	NewStatuser:        s3.NewStatuser,
 	NewLogStreamer:     cloudwatch.NewLogStreamer,
 }
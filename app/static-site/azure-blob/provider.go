@@ -19,4 +19,5 @@ var Provider = app.Provider{
 	NewPusher:          blob.NewDirPusher,
 	NewDeployer:        blob.NewDeployer,
 	NewDeployWatcher:   app.NewPollingDeployWatcher(blob.NewDeployStatusGetter),
+	NewStatuser:        blob.NewStatuser,
 }
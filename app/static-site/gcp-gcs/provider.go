@@ -20,6 +20,6 @@ var Provider = app.Provider{
 	NewPusher:          gcs.NewDirPusher,
 	NewDeployer:        gcs.NewDeployer,
 	NewDeployWatcher:   app.NewPollingDeployWatcher(cloudcdn.NewDeployStatusGetter),
-	NewStatuser:        nil,
-	NewLogStreamer:     nil, //TODO: Implement cloudlogging.NewLogStreamer,
+	NewStatuser:        gcs.NewStatuser,
+	NewLogStreamer:     cloudcdn.NewLogStreamer,
 }
@@ -0,0 +1,47 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+var (
+	// jsonLevelKeys are the keys that common structured loggers use for the log level, in order of preference
+	jsonLevelKeys = []string{"level", "severity", "lvl", "loglevel"}
+	// jsonMessageKeys are the keys that common structured loggers use for the log message, in order of preference
+	jsonMessageKeys = []string{"msg", "message"}
+)
+
+// ParseStructuredLogMessage parses a JSON object log line into the message's Level, Message, and Fields
+// The level and message keys are removed from Fields; the remaining attributes are kept as-is
+// If the line is not a JSON object, the message is returned unchanged
+func ParseStructuredLogMessage(msg LogMessage) LogMessage {
+	line := strings.TrimSpace(msg.Message)
+	if !strings.HasPrefix(line, "{") {
+		return msg
+	}
+	fields := map[string]any{}
+	if err := json.Unmarshal([]byte(line), &fields); err != nil {
+		return msg
+	}
+
+	for _, key := range jsonLevelKeys {
+		if val, ok := fields[key]; ok {
+			msg.Level = strings.ToLower(fmt.Sprint(val))
+			delete(fields, key)
+			break
+		}
+	}
+	for _, key := range jsonMessageKeys {
+		if val, ok := fields[key].(string); ok {
+			msg.Message = val
+			delete(fields, key)
+			break
+		}
+	}
+	if len(fields) > 0 {
+		msg.Fields = fields
+	}
+	return msg
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStructuredLogMessage(t *testing.T) {
+	tests := []struct {
+		name   string
+		line   string
+		want   string
+		level  string
+		fields map[string]any
+	}{
+		{
+			name:   "zap style",
+			line:   `{"level":"error","msg":"connection refused","host":"db","attempt":3}`,
+			want:   "connection refused",
+			level:  "error",
+			fields: map[string]any{"host": "db", "attempt": float64(3)},
+		},
+		{
+			name:  "severity and message",
+			line:  `{"severity":"WARNING","message":"slow query"}`,
+			want:  "slow query",
+			level: "warning",
+		},
+		{
+			name:   "no message key",
+			line:   `{"level":"info","event":"started"}`,
+			want:   `{"level":"info","event":"started"}`,
+			level:  "info",
+			fields: map[string]any{"event": "started"},
+		},
+		{
+			name: "plain text",
+			line: "listening on :8080",
+			want: "listening on :8080",
+		},
+		{
+			name: "invalid json",
+			line: "{not json",
+			want: "{not json",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := ParseStructuredLogMessage(LogMessage{Stream: "api-1/app", Message: test.line})
+			assert.Equal(t, "api-1/app", got.Stream)
+			assert.Equal(t, test.want, got.Message)
+			assert.Equal(t, test.level, got.Level)
+			assert.Equal(t, test.fields, got.Fields)
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package batch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/batch"
+	batchtypes "github.com/aws/aws-sdk-go-v2/service/batch/types"
+	nsaws "github.com/nullstone-io/deployment-sdk/aws"
+	"github.com/nullstone-io/deployment-sdk/aws/creds"
+	"github.com/nullstone-io/deployment-sdk/outputs"
+	"github.com/nullstone-io/deployment-sdk/workspace"
+	"gopkg.in/nullstone-io/go-api-client.v0/types"
+)
+
+const (
+	ActionSubmitJob    = "submit-job"
+	ActionCancelJob    = "cancel-job"
+	ActionTerminateJob = "terminate-job"
+	ActionRerunJob     = "rerun-job"
+
+	defaultActionReason = "requested from Nullstone"
+)
+
+type SubmitJobInput struct {
+	// JobName defaults to the job definition name suffixed with a timestamp
+	JobName     string            `json:"jobName,omitempty"`
+	Command     []string          `json:"command,omitempty"`
+	Environment map[string]string `json:"environment,omitempty"`
+}
+
+type SubmitJobResult struct {
+	JobId   string `json:"jobId"`
+	JobName string `json:"jobName"`
+}
+
+type CancelJobInput struct {
+	JobId  string `json:"jobId"`
+	Reason string `json:"reason,omitempty"`
+}
+
+type CancelJobResult struct {
+	JobId string `json:"jobId"`
+}
+
+type TerminateJobInput struct {
+	JobId  string `json:"jobId"`
+	Reason string `json:"reason,omitempty"`
+}
+
+type TerminateJobResult struct {
+	JobId string `json:"jobId"`
+}
+
+type RerunJobInput struct {
+	// JobId identifies a previously-submitted job to rerun
+	JobId string `json:"jobId"`
+}
+
+type RerunJobResult struct {
+	// JobId is the ID of the newly-submitted job
+	JobId string `json:"jobId"`
+	// SourceJobId echoes the input job that was rerun
+	SourceJobId string `json:"sourceJobId"`
+}
+
+func NewActioner(ctx context.Context, source outputs.RetrieverSource, blockDetails workspace.Details) (workspace.Actioner, error) {
+	outs, err := outputs.Retrieve[Outputs](ctx, source, blockDetails.Workspace, blockDetails.WorkspaceConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	ws := blockDetails.Workspace
+	credsFactory := creds.NewProviderFactory(source, ws.StackId, ws.BlockId, ws.EnvId)
+	outs.Deployer.RemoteProvider = credsFactory(types.AutomationPurposePerformAction, "deployer")
+
+	return Actioner{
+		Infra:   outs,
+		AppName: blockDetails.Block.Name,
+	}, nil
+}
+
+type Actioner struct {
+	Infra   Outputs
+	AppName string
+}
+
+func (a Actioner) PerformAction(ctx context.Context, options workspace.ActionOptions) (*workspace.ActionResult, error) {
+	switch options.Action {
+	case ActionSubmitJob:
+		return a.submitJob(ctx, options.Input)
+	case ActionCancelJob:
+		return a.cancelJob(ctx, options.Input)
+	case ActionTerminateJob:
+		return a.terminateJob(ctx, options.Input)
+	case ActionRerunJob:
+		return a.rerunJob(ctx, options.Input)
+	default:
+		return nil, workspace.ActionNotSupportedError{
+			InnerErr: fmt.Errorf("unknown batch action %q", options.Action),
+		}
+	}
+}
+
+func (a Actioner) newClient() *batch.Client {
+	return batch.NewFromConfig(nsaws.NewConfig(a.Infra.Deployer, a.Infra.Region))
+}
+
+// submitJob submits a new job to the app's job queue using the latest active job definition.
+// The call returns as soon as the job is submitted; it does not wait for the job to run.
+func (a Actioner) submitJob(ctx context.Context, input json.RawMessage) (*workspace.ActionResult, error) {
+	if a.Infra.JobQueueArn == "" {
+		return nil, fmt.Errorf("%s requires a job queue", ActionSubmitJob)
+	}
+	var in SubmitJobInput
+	if len(input) > 0 {
+		if err := json.Unmarshal(input, &in); err != nil {
+			return nil, fmt.Errorf("invalid input for %s: %w", ActionSubmitJob, err)
+		}
+	}
+	jobName := in.JobName
+	if jobName == "" {
+		jobName = a.newJobName()
+	}
+
+	jobId, err := SubmitJob(ctx, a.Infra, SubmitJobOptions{
+		JobName:     jobName,
+		Command:     in.Command,
+		Environment: in.Environment,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(SubmitJobResult{JobId: jobId, JobName: jobName})
+	if err != nil {
+		return nil, err
+	}
+	return &workspace.ActionResult{
+		Status:  "started",
+		Message: fmt.Sprintf("submitted job %q (%s)", jobName, jobId),
+		Data:    data,
+	}, nil
+}
+
+// cancelJob cancels a job that has not started running yet (SUBMITTED, PENDING, or RUNNABLE).
+// Jobs that are already STARTING or RUNNING are not affected; use terminate-job for those.
+func (a Actioner) cancelJob(ctx context.Context, input json.RawMessage) (*workspace.ActionResult, error) {
+	var in CancelJobInput
+	if len(input) > 0 {
+		if err := json.Unmarshal(input, &in); err != nil {
+			return nil, fmt.Errorf("invalid input for %s: %w", ActionCancelJob, err)
+		}
+	}
+	if in.JobId == "" {
+		return nil, fmt.Errorf("%s requires jobId", ActionCancelJob)
+	}
+	reason := in.Reason
+	if reason == "" {
+		reason = defaultActionReason
+	}
+
+	client := a.newClient()
+	if _, err := client.CancelJob(ctx, &batch.CancelJobInput{
+		JobId:  aws.String(in.JobId),
+		Reason: aws.String(reason),
+	}); err != nil {
+		return nil, fmt.Errorf("error cancelling job %q: %w", in.JobId, err)
+	}
+
+	data, err := json.Marshal(CancelJobResult{JobId: in.JobId})
+	if err != nil {
+		return nil, err
+	}
+	return &workspace.ActionResult{
+		Status:  "completed",
+		Message: fmt.Sprintf("cancelled job %q", in.JobId),
+		Data:    data,
+	}, nil
+}
+
+// terminateJob stops a job in any state, including jobs that are STARTING or RUNNING.
+func (a Actioner) terminateJob(ctx context.Context, input json.RawMessage) (*workspace.ActionResult, error) {
+	var in TerminateJobInput
+	if len(input) > 0 {
+		if err := json.Unmarshal(input, &in); err != nil {
+			return nil, fmt.Errorf("invalid input for %s: %w", ActionTerminateJob, err)
+		}
+	}
+	if in.JobId == "" {
+		return nil, fmt.Errorf("%s requires jobId", ActionTerminateJob)
+	}
+	reason := in.Reason
+	if reason == "" {
+		reason = defaultActionReason
+	}
+
+	client := a.newClient()
+	if _, err := client.TerminateJob(ctx, &batch.TerminateJobInput{
+		JobId:  aws.String(in.JobId),
+		Reason: aws.String(reason),
+	}); err != nil {
+		return nil, fmt.Errorf("error terminating job %q: %w", in.JobId, err)
+	}
+
+	data, err := json.Marshal(TerminateJobResult{JobId: in.JobId})
+	if err != nil {
+		return nil, err
+	}
+	return &workspace.ActionResult{
+		Status:  "completed",
+		Message: fmt.Sprintf("terminated job %q", in.JobId),
+		Data:    data,
+	}, nil
+}
+
+// rerunJob submits a new job with the same command and parameters as a previous job.
+// Each deploy deregisters prior job definition revisions, so the new job always runs
+// against the latest active job definition rather than the source job's revision.
+func (a Actioner) rerunJob(ctx context.Context, input json.RawMessage) (*workspace.ActionResult, error) {
+	if a.Infra.JobQueueArn == "" {
+		return nil, fmt.Errorf("%s requires a job queue", ActionRerunJob)
+	}
+	var in RerunJobInput
+	if len(input) > 0 {
+		if err := json.Unmarshal(input, &in); err != nil {
+			return nil, fmt.Errorf("invalid input for %s: %w", ActionRerunJob, err)
+		}
+	}
+	if in.JobId == "" {
+		return nil, fmt.Errorf("%s requires jobId", ActionRerunJob)
+	}
+
+	source, err := DescribeJob(ctx, a.Infra, in.JobId)
+	if err != nil {
+		return nil, err
+	} else if source == nil {
+		return nil, fmt.Errorf("job %q was not found", in.JobId)
+	}
+
+	submit := &batch.SubmitJobInput{
+		JobName:       aws.String(a.newJobName()),
+		JobDefinition: aws.String(a.Infra.JobDefinitionName),
+		JobQueue:      aws.String(a.Infra.JobQueueArn),
+		Parameters:    source.Parameters,
+	}
+	if source.Container != nil && len(source.Container.Command) > 0 {
+		submit.ContainerOverrides = &batchtypes.ContainerOverrides{Command: source.Container.Command}
+	}
+
+	client := a.newClient()
+	out, err := client.SubmitJob(ctx, submit)
+	if err != nil {
+		return nil, fmt.Errorf("error submitting job: %w", err)
+	}
+
+	data, err := json.Marshal(RerunJobResult{
+		JobId:       aws.ToString(out.JobId),
+		SourceJobId: in.JobId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &workspace.ActionResult{
+		Status:  "started",
+		Message: fmt.Sprintf("submitted job %q from %q", aws.ToString(out.JobId), in.JobId),
+		Data:    data,
+	}, nil
+}
+
+func (a Actioner) newJobName() string {
+	return fmt.Sprintf("%s-%d", a.Infra.JobDefinitionName, time.Now().Unix())
+}
@@ -0,0 +1,31 @@
+package batch
+
+import (
+	"strconv"
+	"strings"
+)
+
+// parseJobDefinitionArn extracts the name and revision from a job-definition ARN of the form
+// arn:aws:batch:<region>:<account-id>:job-definition/<name>:<revision>.
+// Returns ("", 0) if the ARN is empty or malformed.
+func parseJobDefinitionArn(jobDefinitionArn string) (string, int32) {
+	if jobDefinitionArn == "" {
+		return "", 0
+	}
+	parts := strings.Split(jobDefinitionArn, ":")
+	if len(parts) < 2 {
+		return "", 0
+	}
+	nameSeg := parts[len(parts)-2]
+	revSeg := parts[len(parts)-1]
+	slash := strings.LastIndex(nameSeg, "/")
+	if slash < 0 {
+		return "", 0
+	}
+	name := nameSeg[slash+1:]
+	rev, err := strconv.ParseInt(revSeg, 10, 32)
+	if err != nil {
+		return name, 0
+	}
+	return name, int32(rev)
+}
@@ -0,0 +1,28 @@
+package batch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseJobDefinitionArn(t *testing.T) {
+	tests := []struct {
+		name         string
+		arn          string
+		wantName     string
+		wantRevision int32
+	}{
+		{"standard arn", "arn:aws:batch:us-east-1:123456789012:job-definition/my-job:42", "my-job", 42},
+		{"name with hyphens", "arn:aws:batch:us-east-1:123456789012:job-definition/prod-nightly-report:7", "prod-nightly-report", 7},
+		{"empty arn", "", "", 0},
+		{"missing revision", "arn:aws:batch:us-east-1:123456789012:job-definition/my-job", "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotName, gotRevision := parseJobDefinitionArn(tt.arn)
+			assert.Equal(t, tt.wantName, gotName)
+			assert.Equal(t, tt.wantRevision, gotRevision)
+		})
+	}
+}
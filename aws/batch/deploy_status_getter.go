@@ -0,0 +1,110 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	batchtypes "github.com/aws/aws-sdk-go-v2/service/batch/types"
+	"github.com/nullstone-io/deployment-sdk/app"
+	"github.com/nullstone-io/deployment-sdk/logging"
+	"github.com/nullstone-io/deployment-sdk/outputs"
+)
+
+func NewDeployStatusGetter(ctx context.Context, osWriters logging.OsWriters, source outputs.RetrieverSource, appDetails app.Details) (app.DeployStatusGetter, error) {
+	outs, err := outputs.Retrieve[Outputs](ctx, source, appDetails.Workspace, appDetails.WorkspaceConfig)
+	if err != nil {
+		return nil, err
+	}
+	outs.InitializeCreds(source, appDetails.Workspace)
+
+	return &DeployStatusGetter{
+		OsWriters: osWriters,
+		Details:   appDetails,
+		Infra:     outs,
+	}, nil
+}
+
+// DeployStatusGetter verifies that the job definition registered by a deploy is ACTIVE
+// If the app module provides a smoke test command, a smoke job is submitted against the new
+// job definition and the deployment completes only once that job succeeds.
+// The deployment reference is the ARN of the new job definition.
+type DeployStatusGetter struct {
+	OsWriters logging.OsWriters
+	Details   app.Details
+	Infra     Outputs
+
+	smokeJobId     string
+	lastSmokeState batchtypes.JobStatus
+}
+
+func (d *DeployStatusGetter) Close() {}
+
+func (d *DeployStatusGetter) GetDeployStatus(ctx context.Context, reference string) (app.RolloutStatus, error) {
+	stdout := d.OsWriters.Stdout()
+
+	if d.smokeJobId == "" {
+		jobDef, err := DescribeJobDefinition(ctx, d.Infra, reference)
+		if err != nil {
+			return app.RolloutStatusUnknown, err
+		}
+		if jobDef == nil {
+			fmt.Fprintf(stdout, "Job definition %s does not exist\n", reference)
+			return app.RolloutStatusFailed, nil
+		}
+		if status := aws.ToString(jobDef.Status); status != "ACTIVE" {
+			fmt.Fprintf(stdout, "Job definition revision %d is %s\n", aws.ToInt32(jobDef.Revision), status)
+			return app.RolloutStatusFailed, nil
+		}
+		fmt.Fprintf(stdout, "Job definition revision %d is ACTIVE\n", aws.ToInt32(jobDef.Revision))
+
+		if len(d.Infra.SmokeTestCommand) == 0 || d.Infra.JobQueueArn == "" {
+			return app.RolloutStatusComplete, nil
+		}
+
+		jobId, err := SubmitJob(ctx, d.Infra, SubmitJobOptions{
+			JobName:          fmt.Sprintf("%s-smoke-test", d.Infra.JobDefinitionName),
+			JobDefinitionArn: reference,
+			Command:          d.Infra.SmokeTestCommand,
+		})
+		if err != nil {
+			return app.RolloutStatusFailed, fmt.Errorf("error submitting smoke test job: %w", err)
+		}
+		d.smokeJobId = jobId
+		fmt.Fprintf(stdout, "Submitted smoke test job %s\n", jobId)
+	}
+
+	job, err := DescribeJob(ctx, d.Infra, d.smokeJobId)
+	if err != nil {
+		return app.RolloutStatusUnknown, err
+	}
+	if job == nil {
+		fmt.Fprintf(stdout, "Smoke test job %s does not exist\n", d.smokeJobId)
+		return app.RolloutStatusFailed, nil
+	}
+	if job.Status != d.lastSmokeState {
+		d.lastSmokeState = job.Status
+		fmt.Fprintf(stdout, "Smoke test job %s is %s\n", d.smokeJobId, job.Status)
+	}
+
+	switch job.Status {
+	case batchtypes.JobStatusSucceeded:
+		return app.RolloutStatusComplete, nil
+	case batchtypes.JobStatusFailed:
+		reason := aws.ToString(job.StatusReason)
+		if job.Container != nil {
+			if job.Container.ExitCode != nil {
+				reason = fmt.Sprintf("%s (exit code %d)", reason, *job.Container.ExitCode)
+			}
+			if containerReason := aws.ToString(job.Container.Reason); containerReason != "" {
+				reason = fmt.Sprintf("%s: %s", reason, containerReason)
+			}
+		}
+		fmt.Fprintf(stdout, "Smoke test job failed: %s\n", reason)
+		return app.RolloutStatusFailed, nil
+	case batchtypes.JobStatusRunning:
+		return app.RolloutStatusInProgress, nil
+	default:
+		return app.RolloutStatusPending, nil
+	}
+}
@@ -45,6 +45,8 @@ func (d Deployer) Print() {
 //	Change image tag in job definition
 //	Register new job definition
 //	Deregister old job definition
+//
+// The new job definition ARN is returned as the deployment reference.
 func (d Deployer) Deploy(ctx context.Context, meta app.DeployMetadata) (string, error) {
 	stdout, _ := d.OsWriters.Stdout(), d.OsWriters.Stderr()
 	d.Print()
@@ -96,7 +98,6 @@ func (d Deployer) Deploy(ctx context.Context, meta app.DeployMetadata) (string,
 	fmt.Fprintln(stdout, "Current active job definition has been successfully updated")
 	fmt.Fprintln(stdout, "")
 
-	fmt.Fprintf(stdout, "No service name in app module. Skipping update service.\n")
 	fmt.Fprintf(stdout, "Deployed app %q\n", d.Details.App.Name)
-	return "", nil
+	return *newJobDefArn, nil
 }
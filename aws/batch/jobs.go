@@ -0,0 +1,137 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/batch"
+	batchtypes "github.com/aws/aws-sdk-go-v2/service/batch/types"
+	nsaws "github.com/nullstone-io/deployment-sdk/aws"
+)
+
+// ListRecentJobs retrieves the most recent jobs in the job queue that use any revision of the job definition
+// Jobs are returned newest first
+func ListRecentJobs(ctx context.Context, infra Outputs, limit int) ([]batchtypes.JobSummary, error) {
+	if infra.JobQueueArn == "" {
+		return nil, nil
+	}
+
+	client := batch.NewFromConfig(nsaws.NewConfig(infra.Deployer, infra.Region))
+	input := &batch.ListJobsInput{
+		JobQueue: aws.String(infra.JobQueueArn),
+		Filters: []batchtypes.KeyValuesPair{
+			{Name: aws.String("JOB_DEFINITION"), Values: []string{infra.JobDefinitionName}},
+		},
+	}
+	jobs := make([]batchtypes.JobSummary, 0)
+	paginator := batch.NewListJobsPaginator(client, input)
+	for paginator.HasMorePages() && len(jobs) < limit {
+		out, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error listing jobs: %w", err)
+		}
+		jobs = append(jobs, out.JobSummaryList...)
+	}
+	if len(jobs) > limit {
+		jobs = jobs[:limit]
+	}
+	return jobs, nil
+}
+
+type SubmitJobOptions struct {
+	JobName string
+	// JobDefinitionArn defaults to the latest active revision of the app's job definition
+	JobDefinitionArn string
+	// Command overrides the command in the job definition
+	Command []string
+	// Environment is merged into the job definition's environment variables
+	Environment map[string]string
+}
+
+// SubmitJob submits a job to the app's job queue and returns the new job ID
+func SubmitJob(ctx context.Context, infra Outputs, options SubmitJobOptions) (string, error) {
+	if infra.JobQueueArn == "" {
+		return "", fmt.Errorf("app does not have a job queue")
+	}
+	jobDefinition := options.JobDefinitionArn
+	if jobDefinition == "" {
+		jobDefinition = infra.JobDefinitionName
+	}
+
+	input := &batch.SubmitJobInput{
+		JobName:       aws.String(options.JobName),
+		JobDefinition: aws.String(jobDefinition),
+		JobQueue:      aws.String(infra.JobQueueArn),
+	}
+	if len(options.Command) > 0 || len(options.Environment) > 0 {
+		overrides := &batchtypes.ContainerOverrides{Command: options.Command}
+		names := make([]string, 0, len(options.Environment))
+		for name := range options.Environment {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			overrides.Environment = append(overrides.Environment, batchtypes.KeyValuePair{
+				Name:  aws.String(name),
+				Value: aws.String(options.Environment[name]),
+			})
+		}
+		input.ContainerOverrides = overrides
+	}
+
+	client := batch.NewFromConfig(nsaws.NewConfig(infra.Deployer, infra.Region))
+	out, err := client.SubmitJob(ctx, input)
+	if err != nil {
+		return "", fmt.Errorf("error submitting job: %w", err)
+	}
+	return aws.ToString(out.JobId), nil
+}
+
+// DescribeJob retrieves the details of a single job
+// If the job does not exist, this returns nil
+func DescribeJob(ctx context.Context, infra Outputs, jobId string) (*batchtypes.JobDetail, error) {
+	client := batch.NewFromConfig(nsaws.NewConfig(infra.Deployer, infra.Region))
+	out, err := client.DescribeJobs(ctx, &batch.DescribeJobsInput{Jobs: []string{jobId}})
+	if err != nil {
+		return nil, fmt.Errorf("error describing job %q: %w", jobId, err)
+	}
+	if len(out.Jobs) == 0 {
+		return nil, nil
+	}
+	return &out.Jobs[0], nil
+}
+
+// GetJobQueue retrieves the job queue for the app
+// If the app has no job queue or the job queue does not exist, this returns nil
+func GetJobQueue(ctx context.Context, infra Outputs) (*batchtypes.JobQueueDetail, error) {
+	if infra.JobQueueArn == "" {
+		return nil, nil
+	}
+	client := batch.NewFromConfig(nsaws.NewConfig(infra.Deployer, infra.Region))
+	out, err := client.DescribeJobQueues(ctx, &batch.DescribeJobQueuesInput{JobQueues: []string{infra.JobQueueArn}})
+	if err != nil {
+		return nil, fmt.Errorf("error describing job queue: %w", err)
+	}
+	if len(out.JobQueues) == 0 {
+		return nil, nil
+	}
+	return &out.JobQueues[0], nil
+}
+
+// DescribeJobDefinition retrieves a single job definition revision by ARN
+// If the job definition does not exist, this returns nil
+func DescribeJobDefinition(ctx context.Context, infra Outputs, jobDefinitionArn string) (*batchtypes.JobDefinition, error) {
+	client := batch.NewFromConfig(nsaws.NewConfig(infra.Deployer, infra.Region))
+	out, err := client.DescribeJobDefinitions(ctx, &batch.DescribeJobDefinitionsInput{
+		JobDefinitions: []string{jobDefinitionArn},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error describing job definition: %w", err)
+	}
+	if len(out.JobDefinitions) == 0 {
+		return nil, nil
+	}
+	return &out.JobDefinitions[0], nil
+}
@@ -14,6 +14,10 @@ type Outputs struct {
 	JobDefinitionName string            `ns:"job_definition_name"`
 	ImageRepoUrl      docker.ImageUrl   `ns:"image_repo_url,optional"`
 	Deployer          nsaws.IamIdentity `ns:"deployer,optional"`
+	JobQueueArn       string            `ns:"job_queue_arn,optional"`
+	// SmokeTestCommand is run as a job against the new job definition after a deploy
+	// If empty (or there is no job queue), the deploy does not run a smoke job
+	SmokeTestCommand []string `ns:"smoke_test_command,optional"`
 }
 
 func (o *Outputs) InitializeCreds(source outputs.RetrieverSource, ws *types.Workspace) {
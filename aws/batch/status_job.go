@@ -0,0 +1,79 @@
+package batch
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	batchtypes "github.com/aws/aws-sdk-go-v2/service/batch/types"
+)
+
+// JobPhase mirrors EcsJobExecutionPhase (aws/ecs) and AppStatusJobExecutionPhase (k8s)
+// so downstream consumers can switch on the same string values across providers.
+type JobPhase string
+
+const (
+	JobPhaseQueued    JobPhase = "Queued"
+	JobPhaseRunning   JobPhase = "Running"
+	JobPhaseSucceeded JobPhase = "Succeeded"
+	JobPhaseFailed    JobPhase = "Failed"
+)
+
+// StatusJob is one run of a job that uses the app's job definition
+type StatusJob struct {
+	JobId                 string     `json:"jobId"`
+	JobArn                string     `json:"jobArn"`
+	JobName               string     `json:"jobName"`
+	JobDefinitionRevision int32      `json:"jobDefinitionRevision"`
+	Status                string     `json:"status"`
+	StatusReason          string     `json:"statusReason,omitempty"`
+	Phase                 JobPhase   `json:"phase"`
+	CreatedAt             *time.Time `json:"createdAt,omitempty"`
+	StartedAt             *time.Time `json:"startedAt,omitempty"`
+	StoppedAt             *time.Time `json:"stoppedAt,omitempty"`
+	ExitCode              *int32     `json:"exitCode,omitempty"`
+	ContainerStatusReason string     `json:"containerStatusReason,omitempty"`
+}
+
+func StatusJobFromSummary(summary batchtypes.JobSummary) StatusJob {
+	_, revision := parseJobDefinitionArn(aws.ToString(summary.JobDefinition))
+	job := StatusJob{
+		JobId:                 aws.ToString(summary.JobId),
+		JobArn:                aws.ToString(summary.JobArn),
+		JobName:               aws.ToString(summary.JobName),
+		JobDefinitionRevision: revision,
+		Status:                string(summary.Status),
+		StatusReason:          aws.ToString(summary.StatusReason),
+		Phase:                 deriveJobPhase(summary.Status),
+		CreatedAt:             fromEpochMillis(summary.CreatedAt),
+		StartedAt:             fromEpochMillis(summary.StartedAt),
+		StoppedAt:             fromEpochMillis(summary.StoppedAt),
+	}
+	if summary.Container != nil {
+		job.ExitCode = summary.Container.ExitCode
+		job.ContainerStatusReason = aws.ToString(summary.Container.Reason)
+	}
+	return job
+}
+
+// deriveJobPhase classifies a Batch job status into our 4-state phase enum
+// SUBMITTED, PENDING, RUNNABLE and STARTING are all waiting on the scheduler or compute environment
+func deriveJobPhase(status batchtypes.JobStatus) JobPhase {
+	switch status {
+	case batchtypes.JobStatusRunning:
+		return JobPhaseRunning
+	case batchtypes.JobStatusSucceeded:
+		return JobPhaseSucceeded
+	case batchtypes.JobStatusFailed:
+		return JobPhaseFailed
+	default:
+		return JobPhaseQueued
+	}
+}
+
+func fromEpochMillis(ms *int64) *time.Time {
+	if ms == nil || *ms == 0 {
+		return nil
+	}
+	t := time.UnixMilli(*ms).UTC()
+	return &t
+}
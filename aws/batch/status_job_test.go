@@ -0,0 +1,67 @@
+package batch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	batchtypes "github.com/aws/aws-sdk-go-v2/service/batch/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusJobFromSummary(t *testing.T) {
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		summary batchtypes.JobSummary
+		want    StatusJob
+	}{
+		{
+			name: "runnable job is queued",
+			summary: batchtypes.JobSummary{
+				JobId:         aws.String("job-1"),
+				JobName:       aws.String("nightly-1"),
+				JobDefinition: aws.String("arn:aws:batch:us-east-1:123456789012:job-definition/nightly:3"),
+				Status:        batchtypes.JobStatusRunnable,
+				CreatedAt:     aws.Int64(createdAt.UnixMilli()),
+			},
+			want: StatusJob{
+				JobId:                 "job-1",
+				JobName:               "nightly-1",
+				JobDefinitionRevision: 3,
+				Status:                "RUNNABLE",
+				Phase:                 JobPhaseQueued,
+				CreatedAt:             &createdAt,
+			},
+		},
+		{
+			name: "failed job reports exit code",
+			summary: batchtypes.JobSummary{
+				JobId:         aws.String("job-2"),
+				JobDefinition: aws.String("arn:aws:batch:us-east-1:123456789012:job-definition/nightly:4"),
+				Status:        batchtypes.JobStatusFailed,
+				StatusReason:  aws.String("Essential container in task exited"),
+				Container:     &batchtypes.ContainerSummary{ExitCode: aws.Int32(137), Reason: aws.String("OutOfMemoryError")},
+			},
+			want: StatusJob{
+				JobId:                 "job-2",
+				JobDefinitionRevision: 4,
+				Status:                "FAILED",
+				StatusReason:          "Essential container in task exited",
+				Phase:                 JobPhaseFailed,
+				ExitCode:              aws.Int32(137),
+				ContainerStatusReason: "OutOfMemoryError",
+			},
+		},
+		{
+			name:    "succeeded job",
+			summary: batchtypes.JobSummary{JobId: aws.String("job-3"), Status: batchtypes.JobStatusSucceeded},
+			want:    StatusJob{JobId: "job-3", Status: "SUCCEEDED", Phase: JobPhaseSucceeded},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, StatusJobFromSummary(test.summary))
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package batch
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	batchtypes "github.com/aws/aws-sdk-go-v2/service/batch/types"
+	"github.com/nullstone-io/deployment-sdk/app"
+	"github.com/nullstone-io/deployment-sdk/docker"
+	"github.com/nullstone-io/deployment-sdk/logging"
+	"github.com/nullstone-io/deployment-sdk/outputs"
+)
+
+const (
+	// statusJobsLimit caps how many recent jobs are reported in Status
+	statusJobsLimit = 25
+)
+
+var (
+	_ app.StatusOverviewResult = StatusOverview{}
+)
+
+type StatusOverview struct {
+	Deployments []StatusOverviewDeployment `json:"deployments"`
+}
+
+func (s StatusOverview) GetDeploymentVersions() []string {
+	refs := make([]string, 0)
+	for _, d := range s.Deployments {
+		refs = append(refs, d.AppVersion)
+	}
+	return refs
+}
+
+// StatusOverviewDeployment is an active revision of the app's job definition
+type StatusOverviewDeployment struct {
+	JobDefinitionArn      string `json:"jobDefinitionArn"`
+	JobDefinitionRevision int32  `json:"jobDefinitionRevision"`
+	AppVersion            string `json:"appVersion"`
+	Status                string `json:"status"`
+}
+
+type Status struct {
+	Region        string          `json:"region"`
+	JobDefinition string          `json:"jobDefinition"`
+	JobQueue      *StatusJobQueue `json:"jobQueue,omitempty"`
+	Jobs          []StatusJob     `json:"jobs"`
+}
+
+type StatusJobQueue struct {
+	Name         string `json:"name"`
+	Arn          string `json:"arn"`
+	State        string `json:"state"`
+	Status       string `json:"status"`
+	StatusReason string `json:"statusReason,omitempty"`
+}
+
+func NewStatuser(ctx context.Context, osWriters logging.OsWriters, source outputs.RetrieverSource, appDetails app.Details) (app.Statuser, error) {
+	outs, err := outputs.Retrieve[Outputs](ctx, source, appDetails.Workspace, appDetails.WorkspaceConfig)
+	if err != nil {
+		return nil, err
+	}
+	outs.InitializeCreds(source, appDetails.Workspace)
+
+	return Statuser{
+		OsWriters: osWriters,
+		Details:   appDetails,
+		Infra:     outs,
+	}, nil
+}
+
+type Statuser struct {
+	OsWriters logging.OsWriters
+	Details   app.Details
+	Infra     Outputs
+}
+
+func (s Statuser) StatusOverview(ctx context.Context) (app.StatusOverviewResult, error) {
+	so := StatusOverview{Deployments: make([]StatusOverviewDeployment, 0)}
+	jobDef, _, err := GetJobDefinition(ctx, s.Infra)
+	if err != nil {
+		return so, err
+	}
+	so.Deployments = append(so.Deployments, StatusOverviewDeployment{
+		JobDefinitionArn:      aws.ToString(jobDef.JobDefinitionArn),
+		JobDefinitionRevision: aws.ToInt32(jobDef.Revision),
+		AppVersion:            jobDefinitionAppVersion(*jobDef),
+		Status:                aws.ToString(jobDef.Status),
+	})
+	return so, nil
+}
+
+func (s Statuser) Status(ctx context.Context) (any, error) {
+	st := Status{
+		Region:        s.Infra.Region,
+		JobDefinition: s.Infra.JobDefinitionName,
+		Jobs:          make([]StatusJob, 0),
+	}
+
+	queue, err := GetJobQueue(ctx, s.Infra)
+	if err != nil {
+		return st, err
+	}
+	if queue != nil {
+		st.JobQueue = &StatusJobQueue{
+			Name:         aws.ToString(queue.JobQueueName),
+			Arn:          aws.ToString(queue.JobQueueArn),
+			State:        string(queue.State),
+			Status:       string(queue.Status),
+			StatusReason: aws.ToString(queue.StatusReason),
+		}
+	}
+
+	jobs, err := ListRecentJobs(ctx, s.Infra, statusJobsLimit)
+	if err != nil {
+		return st, err
+	}
+	for _, job := range jobs {
+		st.Jobs = append(st.Jobs, StatusJobFromSummary(job))
+	}
+	return st, nil
+}
+
+// jobDefinitionAppVersion reports the app version of a job definition using its container image tag
+func jobDefinitionAppVersion(jobDef batchtypes.JobDefinition) string {
+	if jobDef.ContainerProperties == nil || jobDef.ContainerProperties.Image == nil {
+		return ""
+	}
+	return docker.ParseImageUrl(*jobDef.ContainerProperties.Image).Tag
+}
@@ -0,0 +1,70 @@
+package cdn
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudfront/types"
+	"github.com/nullstone-io/deployment-sdk/aws"
+	"github.com/nullstone-io/deployment-sdk/staticsite"
+)
+
+const invalidationStatusInProgress = "InProgress"
+
+// GetCdnStatuses reports the version served by each CloudFront distribution and its in-progress invalidations
+func GetCdnStatuses(ctx context.Context, infra Outputs) ([]staticsite.CdnStatus, error) {
+	cdns, err := GetCdns(ctx, infra)
+	if err != nil {
+		return nil, err
+	}
+
+	cfClient := nsaws.NewCloudfrontClient(infra.Deployer, infra.Region)
+	result := make([]staticsite.CdnStatus, 0)
+	for _, cdnRes := range cdns {
+		var invalidations []cftypes.InvalidationSummary
+		if aws.ToInt32(cdnRes.Distribution.InProgressInvalidationBatches) > 0 {
+			// Invalidations are listed newest first; in-progress invalidations are always on the first page
+			out, err := cfClient.ListInvalidations(ctx, &cloudfront.ListInvalidationsInput{DistributionId: cdnRes.Distribution.Id})
+			if err != nil {
+				return nil, fmt.Errorf("error listing invalidations for distribution %q: %w", aws.ToString(cdnRes.Distribution.Id), err)
+			}
+			if out.InvalidationList != nil {
+				invalidations = out.InvalidationList.Items
+			}
+		}
+		result = append(result, mapDistributionStatus(cdnRes.Distribution, invalidations, infra.ArtifactsKeyTemplate))
+	}
+	return result, nil
+}
+
+func mapDistributionStatus(cdn *cftypes.Distribution, invalidations []cftypes.InvalidationSummary, keyTemplate string) staticsite.CdnStatus {
+	status := staticsite.CdnStatus{
+		Id:                   aws.ToString(cdn.Id),
+		Domains:              make([]string, 0),
+		State:                aws.ToString(cdn.Status),
+		PendingInvalidations: make([]staticsite.Invalidation, 0),
+	}
+	if cdn.DistributionConfig != nil && cdn.DistributionConfig.Aliases != nil {
+		status.Domains = append(status.Domains, cdn.DistributionConfig.Aliases.Items...)
+	}
+	if domainName := aws.ToString(cdn.DomainName); domainName != "" {
+		status.Domains = append(status.Domains, domainName)
+	}
+	// UpdateCdnVersion points the default origin at the version's artifacts directory
+	if index, defaultOrigin := findDefaultOrigin(cdn); index >= 0 {
+		status.AppVersion = staticsite.AppVersionFromPath(keyTemplate, aws.ToString(defaultOrigin.OriginPath))
+	}
+	for _, inv := range invalidations {
+		if aws.ToString(inv.Status) != invalidationStatusInProgress {
+			continue
+		}
+		status.PendingInvalidations = append(status.PendingInvalidations, staticsite.Invalidation{
+			Id:        aws.ToString(inv.Id),
+			Status:    aws.ToString(inv.Status),
+			CreatedAt: inv.CreateTime,
+		})
+	}
+	return status
+}
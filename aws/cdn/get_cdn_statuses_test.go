@@ -0,0 +1,45 @@
+package cdn
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudfront/types"
+	"github.com/nullstone-io/deployment-sdk/staticsite"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapDistributionStatus(t *testing.T) {
+	createdAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	distribution := &cftypes.Distribution{
+		Id:         aws.String("E2ABC"),
+		Status:     aws.String("InProgress"),
+		DomainName: aws.String("d111111abcdef8.cloudfront.net"),
+		DistributionConfig: &cftypes.DistributionConfig{
+			Aliases: &cftypes.Aliases{Items: []string{"example.com"}},
+			DefaultCacheBehavior: &cftypes.DefaultCacheBehavior{
+				TargetOriginId: aws.String("s3"),
+			},
+			Origins: &cftypes.Origins{Items: []cftypes.Origin{
+				{Id: aws.String("api"), OriginPath: aws.String("/api")},
+				{Id: aws.String("s3"), OriginPath: aws.String("/site/v1.2.3")},
+			}},
+		},
+	}
+	invalidations := []cftypes.InvalidationSummary{
+		{Id: aws.String("I2"), Status: aws.String("InProgress"), CreateTime: &createdAt},
+		{Id: aws.String("I1"), Status: aws.String("Completed"), CreateTime: &createdAt},
+	}
+
+	got := mapDistributionStatus(distribution, invalidations, "site/{{app-version}}")
+	assert.Equal(t, staticsite.CdnStatus{
+		Id:         "E2ABC",
+		Domains:    []string{"example.com", "d111111abcdef8.cloudfront.net"},
+		State:      "InProgress",
+		AppVersion: "v1.2.3",
+		PendingInvalidations: []staticsite.Invalidation{
+			{Id: "I2", Status: "InProgress", CreatedAt: &createdAt},
+		},
+	}, got)
+}
@@ -0,0 +1,65 @@
+package cloudwatch
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/nullstone-io/deployment-sdk/app"
+	"github.com/nullstone-io/deployment-sdk/maps"
+)
+
+// jsonPatternKey matches field names that can be referenced as `$.key` in a JSON filter pattern
+var jsonPatternKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// FilterPattern translates the parts of a LogQuery that CloudWatch can evaluate server-side into a filter pattern
+// https://docs.aws.amazon.com/AmazonCloudWatch/latest/logs/FilterAndPatternSyntax.html
+//
+// A filter pattern is either a JSON pattern or a term pattern, so this emits a JSON pattern for Fields
+// or, if there are no Fields, a term pattern for Contains.
+// The result only narrows what is fetched; the query is still evaluated client-side.
+// Returns nil if nothing can be translated.
+func FilterPattern(query *app.LogQuery) *string {
+	if query.IsEmpty() {
+		return nil
+	}
+
+	conditions := make([]string, 0)
+	for _, key := range maps.SortedKeys(query.Fields) {
+		value := query.Fields[key]
+		if !jsonPatternKey.MatchString(key) || !isPatternSafe(value) || !isJsonString(value) {
+			// Leave this field for the client-side filter
+			continue
+		}
+		conditions = append(conditions, fmt.Sprintf(`($.%s = "%s")`, key, value))
+	}
+	if len(conditions) > 0 {
+		pattern := fmt.Sprintf("{ %s }", strings.Join(conditions, " && "))
+		return &pattern
+	}
+
+	if query.Contains != "" && isPatternSafe(query.Contains) {
+		pattern := fmt.Sprintf(`"%s"`, query.Contains)
+		return &pattern
+	}
+	return nil
+}
+
+// isPatternSafe reports whether value can be placed inside a double-quoted filter pattern string without escaping
+func isPatternSafe(value string) bool {
+	return value != "" && !strings.ContainsAny(value, `"\`)
+}
+
+// isJsonString reports whether a field value is compared as a JSON string
+// CloudWatch compares numbers and booleans by type, so "500" would not match {"status": 500}
+func isJsonString(value string) bool {
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return false
+	}
+	switch value {
+	case "true", "false", "null":
+		return false
+	}
+	return true
+}
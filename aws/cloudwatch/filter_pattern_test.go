@@ -0,0 +1,53 @@
+package cloudwatch
+
+import (
+	"testing"
+
+	"github.com/nullstone-io/deployment-sdk/app"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterPattern(t *testing.T) {
+	tests := []struct {
+		name  string
+		query *app.LogQuery
+		want  string
+	}{
+		{
+			name:  "no query",
+			query: nil,
+		},
+		{
+			name:  "contains",
+			query: &app.LogQuery{Contains: "connection refused", MinSeverity: app.LogSeverityError},
+			want:  `"connection refused"`,
+		},
+		{
+			name:  "fields take precedence over contains",
+			query: &app.LogQuery{Contains: "failed", Fields: map[string]string{"route": "/api", "env": "prod"}},
+			want:  `{ ($.env = "prod") && ($.route = "/api") }`,
+		},
+		{
+			name:  "non-string and unsafe fields are left client-side",
+			query: &app.LogQuery{Contains: "failed", Fields: map[string]string{"status": "500", "http.method": "GET", "user": `a"b`}},
+			want:  `"failed"`,
+		},
+		{
+			name:  "nothing translatable",
+			query: &app.LogQuery{Regex: "^ERROR", Contains: `say "hi"`},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := FilterPattern(test.query)
+			if test.want == "" {
+				assert.Nil(t, got)
+				return
+			}
+			if assert.NotNil(t, got) {
+				assert.Equal(t, test.want, *got)
+			}
+		})
+	}
+}
@@ -53,6 +53,24 @@ func (l LogStreamer) Stream(ctx context.Context, options app.LogStreamOptions) e
 	logger.Println(options.QueryTimeMessage())
 	logger.Println(options.WatchMessage())
 
+	emitter, err := options.Query.FilterEmitter(options.Emitter)
+	if err != nil {
+		return err
+	}
+	options.Emitter = emitter
+	if !options.Query.IsEmpty() {
+		logger.Printf("Filtering logs: %s\n", options.Query)
+		if options.Pattern == nil {
+			options.Pattern = FilterPattern(options.Query)
+		}
+		if len(options.Query.ResourceLabels) > 0 {
+			logger.Println("Resource label filters are not supported by CloudWatch and will be ignored")
+		}
+	}
+	if options.Pattern != nil {
+		logger.Printf("Using the filter pattern: %s\n", *options.Pattern)
+	}
+
 	logGroupNames, err := ExpandLogGroups(context.Background(), l.Infra)
 	if err != nil {
 		return err
@@ -3,7 +3,10 @@ package ecs
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -11,6 +14,8 @@ import (
 	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
 	nsaws "github.com/nullstone-io/deployment-sdk/aws"
 	"github.com/nullstone-io/deployment-sdk/aws/creds"
+	"github.com/nullstone-io/deployment-sdk/aws/ssm"
+	"github.com/nullstone-io/deployment-sdk/logging"
 	"github.com/nullstone-io/deployment-sdk/outputs"
 	"github.com/nullstone-io/deployment-sdk/workspace"
 	"gopkg.in/nullstone-io/go-api-client.v0/types"
@@ -20,6 +25,19 @@ const (
 	ActionRestartDeployment = "restart-deployment"
 	ActionKillTask          = "kill-task"
 	ActionRerunJob          = "rerun-job"
+	ActionExecCommand       = "exec-command"
+	ActionScale             = "scale"
+	ActionPause             = "pause"
+	ActionResume            = "resume"
+	ActionRunJob            = "run-job"
+
+	// PausedDesiredCountTagKey is a service tag that remembers the desired count from before a pause
+	PausedDesiredCountTagKey = "nullstone.io/paused-desired-count"
+
+	defaultExecCommandTimeout = 5 * time.Minute
+	defaultRunJobTimeout      = 30 * time.Minute
+	// maxExecCommandOutput caps how much command output is returned in the action result
+	maxExecCommandOutput = 64 * 1024
 )
 
 type RestartDeploymentInput struct{}
@@ -52,6 +70,60 @@ type RerunJobResult struct {
 	SourceTaskArn string `json:"sourceTaskArn"`
 }
 
+type ExecCommandInput struct {
+	Command string `json:"command"`
+	// TaskId selects the task to run the command in.
+	// If TaskId and Deployment are empty, any healthy running task is used.
+	TaskId     string `json:"taskId,omitempty"`
+	Deployment string `json:"deployment,omitempty"`
+	Container  string `json:"container,omitempty"`
+	// TimeoutSeconds bounds how long the command may run (default 5m).
+	TimeoutSeconds int `json:"timeoutSeconds,omitempty"`
+}
+
+type ExecCommandResult struct {
+	ExecResult
+	Output string `json:"output"`
+	// Truncated is true when the command produced more output than is returned.
+	Truncated bool `json:"truncated"`
+}
+
+type RunJobInput struct {
+	Command     []string          `json:"command,omitempty"`
+	Environment map[string]string `json:"environment,omitempty"`
+	Container   string            `json:"container,omitempty"`
+	// TimeoutSeconds bounds how long to wait for the task to stop (default 30m).
+	TimeoutSeconds int `json:"timeoutSeconds,omitempty"`
+}
+
+type ScaleInput struct {
+	// DesiredCount is required; a nil value is rejected rather than scaling the service to zero.
+	DesiredCount *int32 `json:"desiredCount"`
+}
+
+type ScaleResult struct {
+	Service       string `json:"service"`
+	PreviousCount int32  `json:"previousCount"`
+	DesiredCount  int32  `json:"desiredCount"`
+}
+
+type PauseInput struct{}
+
+type PauseResult struct {
+	Service       string `json:"service"`
+	PreviousCount int32  `json:"previousCount"`
+}
+
+type ResumeInput struct {
+	// DesiredCount overrides the desired count remembered from the pause.
+	DesiredCount *int32 `json:"desiredCount,omitempty"`
+}
+
+type ResumeResult struct {
+	Service      string `json:"service"`
+	DesiredCount int32  `json:"desiredCount"`
+}
+
 func NewActioner(ctx context.Context, source outputs.RetrieverSource, blockDetails workspace.Details) (workspace.Actioner, error) {
 	outs, err := outputs.Retrieve[Outputs](ctx, source, blockDetails.Workspace, blockDetails.WorkspaceConfig)
 	if err != nil {
@@ -81,6 +153,16 @@ func (a Actioner) PerformAction(ctx context.Context, options workspace.ActionOpt
 		return a.killTask(ctx, options.Input)
 	case ActionRerunJob:
 		return a.rerunJob(ctx, options.Input)
+	case ActionExecCommand:
+		return a.execCommand(ctx, options.Input)
+	case ActionRunJob:
+		return a.runJob(ctx, options.Input)
+	case ActionScale:
+		return a.scale(ctx, options.Input)
+	case ActionPause:
+		return a.pause(ctx, options.Input)
+	case ActionResume:
+		return a.resume(ctx, options.Input)
 	default:
 		return nil, workspace.ActionNotSupportedError{
 			InnerErr: fmt.Errorf("unknown ecs action %q", options.Action),
@@ -262,6 +344,297 @@ func (a Actioner) rerunJob(ctx context.Context, input json.RawMessage) (*workspa
 	}, nil
 }
 
+// execCommand runs a one-shot command in a running task through ECS Exec and returns its output.
+// Interactive sessions are not possible through an action; use Exec directly to stream stdio.
+func (a Actioner) execCommand(ctx context.Context, input json.RawMessage) (*workspace.ActionResult, error) {
+	var in ExecCommandInput
+	if len(input) > 0 {
+		if err := json.Unmarshal(input, &in); err != nil {
+			return nil, fmt.Errorf("invalid input for %s: %w", ActionExecCommand, err)
+		}
+	}
+	if in.Command == "" {
+		return nil, fmt.Errorf("%s requires command", ActionExecCommand)
+	}
+
+	timeout := defaultExecCommandTimeout
+	if in.TimeoutSeconds > 0 {
+		timeout = time.Duration(in.TimeoutSeconds) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	output := &logging.LimitedBuffer{Limit: maxExecCommandOutput}
+	res, err := Exec(ctx, a.Infra, ExecOptions{
+		ExecTarget: ExecTarget{
+			TaskId:     in.TaskId,
+			Deployment: in.Deployment,
+			Container:  in.Container,
+		},
+		Command:   in.Command,
+		SessionIO: ssm.SessionIO{Stdout: output},
+	})
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, fmt.Errorf("command timed out after %s", timeout)
+		}
+		return nil, err
+	}
+
+	data, err := json.Marshal(ExecCommandResult{
+		ExecResult: *res,
+		Output:     output.String(),
+		Truncated:  output.Truncated(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &workspace.ActionResult{
+		Status:  "completed",
+		Message: fmt.Sprintf("executed command in task %q (container %q)", res.TaskId, res.Container),
+		Data:    data,
+	}, nil
+}
+
+// runJob launches a new task from the job workspace's task definition and waits for it to stop.
+// The result reports the job's phase and exit code; use RunJob directly to also follow the task's logs.
+func (a Actioner) runJob(ctx context.Context, input json.RawMessage) (*workspace.ActionResult, error) {
+	if a.Infra.ServiceName != "" {
+		return nil, fmt.Errorf("%s is only supported on task workspaces", ActionRunJob)
+	}
+	var in RunJobInput
+	if len(input) > 0 {
+		if err := json.Unmarshal(input, &in); err != nil {
+			return nil, fmt.Errorf("invalid input for %s: %w", ActionRunJob, err)
+		}
+	}
+	timeout := defaultRunJobTimeout
+	if in.TimeoutSeconds > 0 {
+		timeout = time.Duration(in.TimeoutSeconds) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result, err := RunJob(ctx, a.Infra, RunJobOptions{
+		Command:     in.Command,
+		Environment: in.Environment,
+		Container:   in.Container,
+		StartedBy:   fmt.Sprintf("nullstone:run-job:%s", a.AppName),
+	})
+	if err != nil {
+		if result != nil && errors.Is(err, context.DeadlineExceeded) {
+			return nil, fmt.Errorf("timed out waiting for task %q to stop", result.TaskId)
+		}
+		return nil, err
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	msg := fmt.Sprintf("task %q %s", result.TaskId, strings.ToLower(string(result.Phase)))
+	if result.ExitCode != nil {
+		msg = fmt.Sprintf("%s with exit code %d", msg, *result.ExitCode)
+	}
+	status := "completed"
+	if !result.Succeeded() {
+		status = "failed"
+		if result.StoppedReason != "" {
+			msg = fmt.Sprintf("%s: %s", msg, result.StoppedReason)
+		}
+	}
+	return &workspace.ActionResult{
+		Status:  status,
+		Message: msg,
+		Data:    data,
+	}, nil
+}
+
+// scale sets the desired count of the workspace's ECS service.
+// The desired count is limited by the max_scale output when the module provides one.
+func (a Actioner) scale(ctx context.Context, input json.RawMessage) (*workspace.ActionResult, error) {
+	if a.Infra.ServiceName == "" {
+		return nil, fmt.Errorf("%s requires a service workspace", ActionScale)
+	}
+	var in ScaleInput
+	if len(input) > 0 {
+		if err := json.Unmarshal(input, &in); err != nil {
+			return nil, fmt.Errorf("invalid input for %s: %w", ActionScale, err)
+		}
+	}
+	if in.DesiredCount == nil {
+		return nil, fmt.Errorf("invalid input for %s: desiredCount is required", ActionScale)
+	}
+	desired := *in.DesiredCount
+	if err := a.checkScaleLimit(desired); err != nil {
+		return nil, err
+	}
+
+	svc, err := a.getService(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.updateDesiredCount(ctx, desired); err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(ScaleResult{
+		Service:       a.Infra.ServiceName,
+		PreviousCount: svc.DesiredCount,
+		DesiredCount:  desired,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &workspace.ActionResult{
+		Status:  "completed",
+		Message: fmt.Sprintf("scaled service %q from %d to %d tasks", a.Infra.ServiceName, svc.DesiredCount, desired),
+		Data:    data,
+	}, nil
+}
+
+// pause scales the workspace's ECS service to zero tasks.
+// The prior desired count is stored in a service tag so that resume can restore it.
+// NOTE: If Application Auto Scaling manages this service with a min capacity above zero, it will scale the service back up.
+func (a Actioner) pause(ctx context.Context, _ json.RawMessage) (*workspace.ActionResult, error) {
+	if a.Infra.ServiceName == "" {
+		return nil, fmt.Errorf("%s requires a service workspace", ActionPause)
+	}
+
+	svc, err := a.getService(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if svc.DesiredCount == 0 {
+		return nil, fmt.Errorf("service %q is already scaled to zero", a.Infra.ServiceName)
+	}
+
+	client := a.newClient()
+	if _, err := client.TagResource(ctx, &ecs.TagResourceInput{
+		ResourceArn: svc.ServiceArn,
+		Tags: []ecstypes.Tag{
+			{Key: aws.String(PausedDesiredCountTagKey), Value: aws.String(strconv.Itoa(int(svc.DesiredCount)))},
+		},
+	}); err != nil {
+		return nil, fmt.Errorf("error recording desired count on service %q: %w", a.Infra.ServiceName, err)
+	}
+	if err := a.updateDesiredCount(ctx, 0); err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(PauseResult{
+		Service:       a.Infra.ServiceName,
+		PreviousCount: svc.DesiredCount,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &workspace.ActionResult{
+		Status:  "completed",
+		Message: fmt.Sprintf("paused service %q (previously %d tasks)", a.Infra.ServiceName, svc.DesiredCount),
+		Data:    data,
+	}, nil
+}
+
+// resume restores the desired count that was recorded when the service was paused.
+func (a Actioner) resume(ctx context.Context, input json.RawMessage) (*workspace.ActionResult, error) {
+	if a.Infra.ServiceName == "" {
+		return nil, fmt.Errorf("%s requires a service workspace", ActionResume)
+	}
+	var in ResumeInput
+	if len(input) > 0 {
+		if err := json.Unmarshal(input, &in); err != nil {
+			return nil, fmt.Errorf("invalid input for %s: %w", ActionResume, err)
+		}
+	}
+
+	svc, err := a.getService(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client := a.newClient()
+	tagsOut, err := client.ListTagsForResource(ctx, &ecs.ListTagsForResourceInput{ResourceArn: svc.ServiceArn})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving tags for service %q: %w", a.Infra.ServiceName, err)
+	}
+	var paused *int32
+	for _, tag := range tagsOut.Tags {
+		if aws.ToString(tag.Key) != PausedDesiredCountTagKey {
+			continue
+		}
+		if count, err := strconv.ParseInt(aws.ToString(tag.Value), 10, 32); err == nil {
+			paused = aws.Int32(int32(count))
+		}
+	}
+
+	desired := paused
+	if in.DesiredCount != nil {
+		desired = in.DesiredCount
+	}
+	if desired == nil {
+		return nil, fmt.Errorf("service %q was not paused, specify desiredCount to resume", a.Infra.ServiceName)
+	}
+	if err := a.checkScaleLimit(*desired); err != nil {
+		return nil, err
+	}
+	if err := a.updateDesiredCount(ctx, *desired); err != nil {
+		return nil, err
+	}
+	if paused != nil {
+		if _, err := client.UntagResource(ctx, &ecs.UntagResourceInput{
+			ResourceArn: svc.ServiceArn,
+			TagKeys:     []string{PausedDesiredCountTagKey},
+		}); err != nil {
+			return nil, fmt.Errorf("error clearing paused desired count on service %q: %w", a.Infra.ServiceName, err)
+		}
+	}
+
+	data, err := json.Marshal(ResumeResult{
+		Service:      a.Infra.ServiceName,
+		DesiredCount: *desired,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &workspace.ActionResult{
+		Status:  "completed",
+		Message: fmt.Sprintf("resumed service %q with %d tasks", a.Infra.ServiceName, *desired),
+		Data:    data,
+	}, nil
+}
+
+func (a Actioner) checkScaleLimit(desiredCount int32) error {
+	if desiredCount < 0 {
+		return fmt.Errorf("desired count must not be negative, got %d", desiredCount)
+	}
+	if a.Infra.MaxScale > 0 && desiredCount > a.Infra.MaxScale {
+		return fmt.Errorf("desired count %d exceeds the max scale limit of %d", desiredCount, a.Infra.MaxScale)
+	}
+	return nil
+}
+
+func (a Actioner) getService(ctx context.Context) (*ecstypes.Service, error) {
+	svc, err := GetService(ctx, a.Infra)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving service %q: %w", a.Infra.ServiceName, err)
+	} else if svc == nil {
+		return nil, fmt.Errorf("service %q was not found", a.Infra.ServiceName)
+	}
+	return svc, nil
+}
+
+func (a Actioner) updateDesiredCount(ctx context.Context, desiredCount int32) error {
+	client := a.newClient()
+	if _, err := client.UpdateService(ctx, &ecs.UpdateServiceInput{
+		Cluster:      aws.String(a.Infra.ClusterArn()),
+		Service:      aws.String(a.Infra.ServiceName),
+		DesiredCount: aws.Int32(desiredCount),
+	}); err != nil {
+		return fmt.Errorf("error scaling service %q: %w", a.Infra.ServiceName, err)
+	}
+	return nil
+}
+
 func subnetsFromAttachments(atts []ecstypes.Attachment) []string {
 	seen := map[string]struct{}{}
 	out := make([]string, 0)
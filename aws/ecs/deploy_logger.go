@@ -7,6 +7,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
 	"github.com/nullstone-io/deployment-sdk/app"
+	"github.com/nullstone-io/deployment-sdk/aws/ecs/failures"
 	"github.com/nullstone-io/deployment-sdk/display"
 	"github.com/nullstone-io/deployment-sdk/logging"
 	"github.com/nullstone-io/deployment-sdk/outputs"
@@ -57,6 +58,8 @@ type LogEvent struct {
 	Source  string
 	At      time.Time
 	Message string
+	// Failure is the classified failure when Message reports a known failure mode
+	Failure *failures.Failure
 }
 
 func (e LogEvent) String() string {
@@ -295,6 +298,7 @@ func (d *DeployLogger) logNewEvents() {
 				Source:  d.Infra.ServiceName,
 				At:      aws.ToTime(evt.CreatedAt),
 				Message: aws.ToString(evt.Message),
+				Failure: failures.ClassifyServiceEvent(d.Infra.ServiceName, evt),
 			})
 			if evt.CreatedAt.After(d.lastSeenEventAt) {
 				d.lastSeenEventAt = *evt.CreatedAt
@@ -316,6 +320,13 @@ func (d *DeployLogger) logNewEvents() {
 			evt.Message = strings.Replace(evt.Message, svcEventPrefix, "Service", 1)
 		}
 		d.log(evt)
+		if evt.Failure != nil {
+			d.log(LogEvent{
+				Source:  evt.Source,
+				At:      evt.At,
+				Message: fmt.Sprintf("Diagnosis: %s. %s", evt.Failure.Summary, evt.Failure.Remediation),
+			})
+		}
 	}
 }
 
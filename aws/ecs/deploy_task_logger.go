@@ -149,6 +149,9 @@ func (l *deployTaskLogger) comparePrevious(previous *StatusTask) {
 	}
 	if at := aws.ToTime(l.task.StoppedAt); at != aws.ToTime(previous.StoppedAt) {
 		l.log(at, "Task stopped")
+		if f := l.task.Failure; f != nil {
+			l.log(at, fmt.Sprintf("Diagnosis: %s. %s", f.Summary, f.Remediation))
+		}
 	}
 }
 
@@ -0,0 +1,199 @@
+package ecs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	nsaws "github.com/nullstone-io/deployment-sdk/aws"
+	"github.com/nullstone-io/deployment-sdk/aws/ssm"
+)
+
+var (
+	ErrNoExecTask = errors.New("no running task with execute command enabled was found")
+)
+
+// ExecTarget selects which task to run a command in
+// If TaskId and Deployment are both empty, any healthy running task is selected
+type ExecTarget struct {
+	// TaskId selects a specific task by ID or ARN
+	TaskId string
+	// Deployment selects a running task that belongs to the given ECS deployment ID
+	Deployment string
+	// Container is the name of the container to run the command in
+	// If empty, the app's main container is used (or the only container in the task)
+	Container string
+}
+
+type ExecOptions struct {
+	ExecTarget
+
+	// Command is the command to run inside the container (e.g. "/bin/sh")
+	Command string
+
+	ssm.SessionIO
+}
+
+type ExecResult struct {
+	TaskArn   string `json:"taskArn"`
+	TaskId    string `json:"taskId"`
+	Container string `json:"container"`
+	SessionId string `json:"sessionId"`
+	// ExitCode is the command's exit code when reported by the ECS exec agent; otherwise nil
+	ExitCode *int `json:"exitCode,omitempty"`
+}
+
+// Exec runs a command inside a running ECS task using ECS Exec
+// This establishes the SSM session through ExecuteCommand and proxies stdio over the session manager protocol,
+// which removes the need for the AWS CLI and session-manager-plugin
+// ECS only supports interactive sessions, so the command is always started with a pty
+func Exec(ctx context.Context, infra Outputs, options ExecOptions) (*ExecResult, error) {
+	task, err := FindExecTask(ctx, infra, options.ExecTarget)
+	if err != nil {
+		return nil, err
+	}
+	container, err := resolveExecContainer(*task, options.Container, infra.MainContainerName)
+	if err != nil {
+		return nil, err
+	}
+
+	ecsClient := ecs.NewFromConfig(nsaws.NewConfig(infra.Deployer, infra.Region))
+	out, err := ecsClient.ExecuteCommand(ctx, &ecs.ExecuteCommandInput{
+		Cluster:     aws.String(infra.ClusterArn()),
+		Task:        task.TaskArn,
+		Container:   aws.String(container),
+		Command:     aws.String(options.Command),
+		Interactive: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error executing command in task %q: %w", parseTaskId(task.TaskArn), err)
+	}
+	if out.Session == nil {
+		return nil, fmt.Errorf("ecs did not return a session for the command")
+	}
+
+	result := &ExecResult{
+		TaskArn:   aws.ToString(task.TaskArn),
+		TaskId:    parseTaskId(task.TaskArn),
+		Container: container,
+		SessionId: aws.ToString(out.Session.SessionId),
+	}
+	session, err := ssm.StartSession(ctx, aws.ToString(out.Session.StreamUrl), aws.ToString(out.Session.TokenValue))
+	if err != nil {
+		return result, err
+	}
+	defer session.Close()
+
+	sessionResult, err := session.Run(ctx, options.SessionIO)
+	if sessionResult != nil {
+		result.ExitCode = sessionResult.ExitCode
+	}
+	return result, err
+}
+
+// FindExecTask resolves an ExecTarget to a single running task that supports ECS Exec
+func FindExecTask(ctx context.Context, infra Outputs, target ExecTarget) (*ecstypes.Task, error) {
+	var candidates []ecstypes.Task
+	var err error
+	switch {
+	case target.TaskId != "":
+		candidates, err = DescribeTasks(ctx, infra, []string{target.TaskId})
+		if err != nil {
+			return nil, err
+		}
+		if len(candidates) == 0 {
+			return nil, fmt.Errorf("task %q was not found", target.TaskId)
+		}
+		task := candidates[0]
+		if aws.ToString(task.LastStatus) != "RUNNING" {
+			return nil, fmt.Errorf("task %q is not running (currently: %s)", target.TaskId, aws.ToString(task.LastStatus))
+		}
+		if !task.EnableExecuteCommand {
+			return nil, fmt.Errorf("task %q does not have execute command enabled", target.TaskId)
+		}
+		return &task, nil
+	case target.Deployment != "":
+		candidates, err = GetDeploymentTasks(ctx, infra, target.Deployment)
+	case infra.ServiceName != "":
+		candidates, err = GetServiceTasks(ctx, infra)
+	default:
+		candidates, err = GetTaskFamilyTasks(ctx, infra)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	task := pickExecTask(candidates)
+	if task == nil {
+		return nil, ErrNoExecTask
+	}
+	return task, nil
+}
+
+// pickExecTask chooses the best task to exec into
+// Only running tasks with execute command enabled are considered
+// Healthy tasks are preferred over tasks with an unknown health, unhealthy tasks are skipped
+// Ties are broken by the most recently started task
+func pickExecTask(tasks []ecstypes.Task) *ecstypes.Task {
+	healthRank := map[ecstypes.HealthStatus]int{
+		ecstypes.HealthStatusHealthy: 0,
+		ecstypes.HealthStatusUnknown: 1,
+		"":                           1,
+	}
+	eligible := make([]ecstypes.Task, 0)
+	for _, task := range tasks {
+		if aws.ToString(task.LastStatus) != "RUNNING" || !task.EnableExecuteCommand {
+			continue
+		}
+		if _, ok := healthRank[task.HealthStatus]; !ok {
+			continue
+		}
+		eligible = append(eligible, task)
+	}
+	if len(eligible) == 0 {
+		return nil
+	}
+	sort.SliceStable(eligible, func(i, j int) bool {
+		ri, rj := healthRank[eligible[i].HealthStatus], healthRank[eligible[j].HealthStatus]
+		if ri != rj {
+			return ri < rj
+		}
+		return aws.ToTime(eligible[i].StartedAt).After(aws.ToTime(eligible[j].StartedAt))
+	})
+	return &eligible[0]
+}
+
+// resolveExecContainer picks the container to exec into and verifies its ECS Exec agent is running
+func resolveExecContainer(task ecstypes.Task, requested, mainContainerName string) (string, error) {
+	name := requested
+	if name == "" {
+		name = mainContainerName
+	}
+	if name == "" {
+		if len(task.Containers) != 1 {
+			return "", fmt.Errorf("task %q has %d containers, a container name is required", parseTaskId(task.TaskArn), len(task.Containers))
+		}
+		name = aws.ToString(task.Containers[0].Name)
+	}
+
+	for _, container := range task.Containers {
+		if aws.ToString(container.Name) != name {
+			continue
+		}
+		for _, agent := range container.ManagedAgents {
+			if agent.Name != ecstypes.ManagedAgentNameExecuteCommandAgent {
+				continue
+			}
+			if status := aws.ToString(agent.LastStatus); status != "RUNNING" {
+				return "", fmt.Errorf("execute command agent in container %q is not running (currently: %s)", name, status)
+			}
+			return name, nil
+		}
+		return "", fmt.Errorf("container %q does not have the execute command agent", name)
+	}
+	return "", fmt.Errorf("container %q was not found in task %q", name, parseTaskId(task.TaskArn))
+}
@@ -0,0 +1,108 @@
+package ecs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPickExecTask(t *testing.T) {
+	now := time.Now()
+	task := func(id, status string, exec bool, health ecstypes.HealthStatus, startedAt time.Time) ecstypes.Task {
+		return ecstypes.Task{
+			TaskArn:              aws.String("arn:aws:ecs:us-east-1:123456789012:task/cluster/" + id),
+			LastStatus:           aws.String(status),
+			EnableExecuteCommand: exec,
+			HealthStatus:         health,
+			StartedAt:            aws.Time(startedAt),
+		}
+	}
+
+	tests := []struct {
+		name  string
+		tasks []ecstypes.Task
+		want  string
+	}{
+		{
+			name:  "no tasks",
+			tasks: nil,
+			want:  "",
+		},
+		{
+			name: "skips tasks without exec or not running",
+			tasks: []ecstypes.Task{
+				task("a", "RUNNING", false, ecstypes.HealthStatusHealthy, now),
+				task("b", "PENDING", true, ecstypes.HealthStatusUnknown, now),
+				task("c", "RUNNING", true, ecstypes.HealthStatusUnknown, now),
+			},
+			want: "c",
+		},
+		{
+			name: "prefers healthy over unknown and skips unhealthy",
+			tasks: []ecstypes.Task{
+				task("a", "RUNNING", true, ecstypes.HealthStatusUnhealthy, now),
+				task("b", "RUNNING", true, ecstypes.HealthStatusUnknown, now),
+				task("c", "RUNNING", true, ecstypes.HealthStatusHealthy, now.Add(-time.Hour)),
+			},
+			want: "c",
+		},
+		{
+			name: "prefers most recently started",
+			tasks: []ecstypes.Task{
+				task("a", "RUNNING", true, ecstypes.HealthStatusHealthy, now.Add(-time.Hour)),
+				task("b", "RUNNING", true, ecstypes.HealthStatusHealthy, now),
+			},
+			want: "b",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := pickExecTask(tt.tasks)
+			if tt.want == "" {
+				assert.Nil(t, got)
+				return
+			}
+			if assert.NotNil(t, got) {
+				assert.Equal(t, tt.want, parseTaskId(got.TaskArn))
+			}
+		})
+	}
+}
+
+func TestResolveExecContainer(t *testing.T) {
+	container := func(name, agentStatus string) ecstypes.Container {
+		c := ecstypes.Container{Name: aws.String(name)}
+		if agentStatus != "" {
+			c.ManagedAgents = []ecstypes.ManagedAgent{
+				{Name: ecstypes.ManagedAgentNameExecuteCommandAgent, LastStatus: aws.String(agentStatus)},
+			}
+		}
+		return c
+	}
+
+	single := ecstypes.Task{Containers: []ecstypes.Container{container("app", "RUNNING")}}
+	multi := ecstypes.Task{Containers: []ecstypes.Container{container("app", "RUNNING"), container("sidecar", "PENDING"), container("noagent", "")}}
+
+	got, err := resolveExecContainer(single, "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "app", got)
+
+	got, err = resolveExecContainer(multi, "", "app")
+	assert.NoError(t, err)
+	assert.Equal(t, "app", got)
+
+	_, err = resolveExecContainer(multi, "", "")
+	assert.Error(t, err, "multiple containers require a name")
+
+	_, err = resolveExecContainer(multi, "sidecar", "app")
+	assert.Error(t, err, "agent not running")
+
+	_, err = resolveExecContainer(multi, "noagent", "app")
+	assert.Error(t, err, "agent missing")
+
+	_, err = resolveExecContainer(multi, "missing", "app")
+	assert.Error(t, err, "container missing")
+}
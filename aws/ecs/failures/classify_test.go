@@ -0,0 +1,187 @@
+package failures
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stoppedTask(stopCode ecstypes.TaskStopCode, reason string, containers ...ecstypes.Container) ecstypes.Task {
+	return ecstypes.Task{
+		TaskArn:       aws.String("arn:aws:ecs:us-east-1:123456789012:task/cluster/abc123"),
+		LastStatus:    aws.String("STOPPED"),
+		DesiredStatus: aws.String("STOPPED"),
+		StopCode:      stopCode,
+		StoppedReason: aws.String(reason),
+		Containers:    containers,
+	}
+}
+
+func TestClassifyTask(t *testing.T) {
+	cases := []struct {
+		name          string
+		task          ecstypes.Task
+		wantName      string
+		wantCategory  Category
+		wantContainer string
+	}{
+		{
+			name:         "pull auth",
+			task:         stoppedTask(ecstypes.TaskStopCodeTaskFailedToStart, "CannotPullContainerError: pull image manifest has been retried 5 time(s): failed to resolve ref 123.dkr.ecr.us-east-1.amazonaws.com/app:v1: pulling from host failed with status code [manifests v1]: 403 Forbidden"),
+			wantName:     "CannotPullContainer/Auth",
+			wantCategory: CategoryImage,
+		},
+		{
+			name:         "pull not found",
+			task:         stoppedTask(ecstypes.TaskStopCodeTaskFailedToStart, "CannotPullContainerError: pull image manifest has been retried 5 time(s): failed to resolve ref docker.io/acme/app:v9: docker.io/acme/app:v9: not found"),
+			wantName:     "CannotPullContainer/NotFound",
+			wantCategory: CategoryImage,
+		},
+		{
+			name:         "pull network",
+			task:         stoppedTask(ecstypes.TaskStopCodeTaskFailedToStart, "CannotPullContainerError: ref pull has been retried 1 time(s): failed to copy: httpReadSeeker: failed open: failed to do request: dial tcp 52.216.0.1:443: i/o timeout"),
+			wantName:     "CannotPullContainer/Network",
+			wantCategory: CategoryImage,
+		},
+		{
+			name:         "secrets",
+			task:         stoppedTask(ecstypes.TaskStopCodeTaskFailedToStart, "ResourceInitializationError: unable to pull secrets or registry auth: execution resource retrieval failed: unable to retrieve secret from asm: service call has been retried 1 time(s): failed to fetch secret arn:aws:secretsmanager:us-east-1:123456789012:secret:db-password from secrets manager: AccessDeniedException"),
+			wantName:     "ResourceInitialization/Secrets",
+			wantCategory: CategorySecrets,
+		},
+		{
+			name:         "ssm parameter",
+			task:         stoppedTask(ecstypes.TaskStopCodeTaskFailedToStart, "ResourceInitializationError: unable to pull secrets or registry auth: execution resource retrieval failed: unable to retrieve secrets from ssm: service call has been retried 1 time(s): AccessDeniedException: User: arn:aws:sts::123456789012:assumed-role/task-execution/abc is not authorized to perform: ssm:GetParameters"),
+			wantName:     "ResourceInitialization/Secrets",
+			wantCategory: CategorySecrets,
+		},
+		{
+			name:         "registry auth",
+			task:         stoppedTask(ecstypes.TaskStopCodeTaskFailedToStart, "ResourceInitializationError: unable to pull secrets or registry auth: execution resource retrieval failed: unable to retrieve ecr registry auth: service call has been retried 3 time(s): RequestError: send request failed caused by: Post \"https://api.ecr.us-east-1.amazonaws.com/\": dial tcp 10.0.1.5:443: i/o timeout"),
+			wantName:     "ResourceInitialization/RegistryAuth",
+			wantCategory: CategoryImage,
+		},
+		{
+			name:         "resource init network",
+			task:         stoppedTask(ecstypes.TaskStopCodeTaskFailedToStart, "ResourceInitializationError: unable to pull secrets or registry auth: execution resource retrieval failed: RequestError: send request failed caused by: Post \"https://sts.us-east-1.amazonaws.com/\": dial tcp 10.0.1.5:443: i/o timeout"),
+			wantName:     "ResourceInitialization",
+			wantCategory: CategoryNetwork,
+		},
+		{
+			name:         "log group with registry auth prefix",
+			task:         stoppedTask(ecstypes.TaskStopCodeTaskFailedToStart, "ResourceInitializationError: unable to pull secrets or registry auth: execution resource retrieval failed: failed to create Cloudwatch log group: AccessDeniedException: not authorized to perform: logs:CreateLogGroup"),
+			wantName:     "ResourceInitialization/Logging",
+			wantCategory: CategoryLogging,
+		},
+		{
+			name:         "log group",
+			task:         stoppedTask(ecstypes.TaskStopCodeTaskFailedToStart, "ResourceInitializationError: failed to validate logger args: create stream has been retried 1 times: failed to create Cloudwatch log stream: ResourceNotFoundException: The specified log group does not exist."),
+			wantName:     "ResourceInitialization/Logging",
+			wantCategory: CategoryLogging,
+		},
+		{
+			name:         "efs",
+			task:         stoppedTask(ecstypes.TaskStopCodeTaskFailedToStart, "ResourceInitializationError: failed to invoke EFS utils commands to set up EFS volumes: stderr: Failed to resolve \"fs-1234.efs.us-east-1.amazonaws.com\""),
+			wantName:     "ResourceInitialization/EFSMount",
+			wantCategory: CategoryStorage,
+		},
+		{
+			name:         "exec format error",
+			task:         stoppedTask(ecstypes.TaskStopCodeEssentialContainerExited, "CannotStartContainerError: Error response from daemon: failed to create shim task: exec format error"),
+			wantName:     "ImageArchitectureMismatch",
+			wantCategory: CategoryImage,
+		},
+		{
+			name:         "elb health checks",
+			task:         stoppedTask(ecstypes.TaskStopCodeServiceSchedulerInitiated, "Task failed ELB health checks in (target-group arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/app/abc)"),
+			wantName:     "Health/ELBHealthCheck",
+			wantCategory: CategoryHealth,
+		},
+		{
+			name: "oom",
+			task: stoppedTask(ecstypes.TaskStopCodeEssentialContainerExited, "Essential container in task exited",
+				ecstypes.Container{Name: aws.String("app"), ExitCode: aws.Int32(137), Reason: aws.String("OutOfMemoryError: Container killed due to memory usage")}),
+			wantName:      "OOMKilled",
+			wantCategory:  CategoryRuntime,
+			wantContainer: "app",
+		},
+		{
+			name: "non-zero exit",
+			task: stoppedTask(ecstypes.TaskStopCodeEssentialContainerExited, "Essential container in task exited",
+				ecstypes.Container{Name: aws.String("sidecar"), ExitCode: aws.Int32(0)},
+				ecstypes.Container{Name: aws.String("app"), ExitCode: aws.Int32(1)}),
+			wantName:      "EssentialContainerExited",
+			wantCategory:  CategoryRuntime,
+			wantContainer: "app",
+		},
+		{
+			name:         "spot",
+			task:         stoppedTask(ecstypes.TaskStopCodeSpotInterruption, "Your Spot Task was interrupted."),
+			wantName:     "Capacity/SpotInterruption",
+			wantCategory: CategoryCapacity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := ClassifyTask(tc.task)
+			require.NotNil(t, f)
+			assert.Equal(t, tc.wantName, f.Name)
+			assert.Equal(t, tc.wantCategory, f.Category)
+			assert.Equal(t, "Task", f.Object.Kind)
+			assert.Equal(t, "abc123", f.Object.Name)
+			assert.Equal(t, tc.wantContainer, f.Object.Container)
+			assert.NotEmpty(t, f.Summary)
+			assert.NotEmpty(t, f.Remediation)
+		})
+	}
+}
+
+func TestClassifyTask_Benign(t *testing.T) {
+	cases := map[string]ecstypes.Task{
+		"running": {
+			TaskArn:       aws.String("arn:aws:ecs:us-east-1:123456789012:task/cluster/abc123"),
+			LastStatus:    aws.String("RUNNING"),
+			DesiredStatus: aws.String("RUNNING"),
+		},
+		"scaled in":       stoppedTask(ecstypes.TaskStopCodeServiceSchedulerInitiated, "Scaling activity initiated by (deployment ecs-svc/123)"),
+		"stopped by user": stoppedTask(ecstypes.TaskStopCodeUserInitiated, "Task stopped by user"),
+		"job succeeded": stoppedTask(ecstypes.TaskStopCodeEssentialContainerExited, "Essential container in task exited",
+			ecstypes.Container{Name: aws.String("app"), ExitCode: aws.Int32(0)}),
+	}
+	for name, task := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Nil(t, ClassifyTask(task))
+		})
+	}
+}
+
+func TestClassifyServiceEvents(t *testing.T) {
+	now := time.Now()
+	event := func(ago time.Duration, msg string) ecstypes.ServiceEvent {
+		return ecstypes.ServiceEvent{CreatedAt: aws.Time(now.Add(-ago)), Message: aws.String(msg)}
+	}
+	// ECS reports events newest first
+	events := []ecstypes.ServiceEvent{
+		event(1*time.Minute, "(service app) was unable to place a task because no container instance met all of its requirements. The closest matching (container-instance 123) has insufficient memory available."),
+		event(2*time.Minute, "(service app) was unable to place a task because no container instance met all of its requirements. The closest matching (container-instance 123) has insufficient memory available."),
+		event(3*time.Minute, "(service app) (port 8080) is unhealthy in (target-group arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/app/abc) due to (reason Health checks failed)."),
+		event(4*time.Minute, "(service app) has started 1 tasks: (task abc123)."),
+		event(5*time.Minute, "(service app) has reached a steady state."),
+		event(6*time.Minute, "(service app) was unable to place a task because no container instance met all of its requirements. Reason: No Container Instances were found in your cluster."),
+	}
+
+	got := ClassifyServiceEvents("app", events)
+	names := make([]string, 0)
+	for _, f := range got {
+		names = append(names, f.Name)
+	}
+	assert.Equal(t, []string{"Placement/InsufficientResources", "Health/TargetUnhealthy"}, names)
+	assert.Equal(t, "Service", got[0].Object.Kind)
+	assert.Equal(t, "app", got[0].Object.Name)
+	assert.Equal(t, now.Add(-time.Minute), got[0].ObservedAt)
+}
@@ -0,0 +1,63 @@
+// Package failures classifies ECS deployment and task failures into canonical
+// records with remediation text, mirroring k8s/failures. All classifiers are
+// pure: they take service events and task snapshots from the ECS API and return
+// a structured Failure record. No API calls.
+package failures
+
+import (
+	"time"
+
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+)
+
+// Category is the top-level catalog section a Failure belongs to.
+type Category string
+
+const (
+	CategoryImage     Category = "image"
+	CategoryRuntime   Category = "runtime"
+	CategoryPlacement Category = "placement"
+	CategoryCapacity  Category = "capacity"
+	CategorySecrets   Category = "secrets"
+	CategoryNetwork   Category = "network"
+	CategoryStorage   Category = "storage"
+	CategoryLogging   Category = "logging"
+	CategoryHealth    Category = "health"
+	CategoryRollout   Category = "rollout"
+)
+
+// ObjectRef points at the ECS object the failure was observed on.
+type ObjectRef struct {
+	// Kind is "Service" or "Task"
+	Kind      string `json:"kind"`
+	Name      string `json:"name,omitempty"`
+	Container string `json:"container,omitempty"`
+}
+
+// Signals carries the raw evidence that produced the classification.
+// Consumers use it to render drill-down UIs without re-deriving the source.
+type Signals struct {
+	EventMessage    string                `json:"eventMessage,omitempty"`
+	StoppedReason   string                `json:"stoppedReason,omitempty"`
+	StopCode        ecstypes.TaskStopCode `json:"stopCode,omitempty"`
+	ContainerReason string                `json:"containerReason,omitempty"`
+	ExitCode        *int32                `json:"exitCode,omitempty"`
+}
+
+// Failure is the structured output of every classifier.
+// The shape matches k8s/failures.Failure so consumers can render both the same way.
+type Failure struct {
+	Name        string    `json:"name"`
+	Category    Category  `json:"category"`
+	Summary     string    `json:"summary"`
+	Remediation string    `json:"remediation"`
+	Object      ObjectRef `json:"object"`
+	Signals     Signals   `json:"signals"`
+	Docs        []string  `json:"docs,omitempty"`
+	ObservedAt  time.Time `json:"observedAt,omitempty"`
+}
+
+const (
+	docsStoppedTaskErrors = "https://docs.aws.amazon.com/AmazonECS/latest/developerguide/stopped-task-error-codes.html"
+	docsServiceEvents     = "https://docs.aws.amazon.com/AmazonECS/latest/developerguide/service-event-messages-list.html"
+)
@@ -0,0 +1,139 @@
+package failures
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+)
+
+// ClassifyServiceEvent maps a single ECS service event to a Failure.
+// Returns nil for informational events (steady state, task started/stopped, etc.)
+// See https://docs.aws.amazon.com/AmazonECS/latest/developerguide/service-event-messages-list.html
+func ClassifyServiceEvent(serviceName string, ev ecstypes.ServiceEvent) *Failure {
+	obj := ObjectRef{Kind: "Service", Name: serviceName}
+	msg := aws.ToString(ev.Message)
+	f := classifyServiceMessage(obj, msg)
+	if f == nil {
+		return nil
+	}
+	f.ObservedAt = aws.ToTime(ev.CreatedAt)
+	return f
+}
+
+// ClassifyServiceEvents classifies the service events reported since the service last reached a steady state.
+// ECS returns events newest first; the result keeps that order and reports each failure Name once.
+func ClassifyServiceEvents(serviceName string, events []ecstypes.ServiceEvent) []Failure {
+	result := make([]Failure, 0)
+	seen := map[string]bool{}
+	for _, ev := range events {
+		if IsSteadyStateEvent(ev) {
+			break
+		}
+		f := ClassifyServiceEvent(serviceName, ev)
+		if f == nil || seen[f.Name] {
+			continue
+		}
+		seen[f.Name] = true
+		result = append(result, *f)
+	}
+	return result
+}
+
+// IsSteadyStateEvent reports whether the service event announces that the service reached a steady state.
+// Failures reported before the most recent steady state have already been resolved.
+func IsSteadyStateEvent(ev ecstypes.ServiceEvent) bool {
+	return strings.Contains(aws.ToString(ev.Message), "has reached a steady state")
+}
+
+func classifyServiceMessage(obj ObjectRef, msg string) *Failure {
+	lower := strings.ToLower(msg)
+	signals := Signals{EventMessage: msg}
+	docs := []string{docsServiceEvents}
+
+	switch {
+	case strings.Contains(lower, "unable to place a task"):
+		return placementFailure(obj, lower, signals)
+	case containsAny(lower, "capacity is unavailable at this time", "insufficient capacity"):
+		return &Failure{
+			Name:        "Capacity/Unavailable",
+			Category:    CategoryCapacity,
+			Summary:     "AWS does not currently have capacity to launch the task",
+			Remediation: "Retry later, spread the service across more availability zones, or add an alternate capacity provider (e.g. FARGATE alongside FARGATE_SPOT).",
+			Object:      obj, Signals: signals, Docs: docs,
+		}
+	case strings.Contains(lower, "unable to assume the role"):
+		return &Failure{
+			Name:        "Permissions/AssumeRole",
+			Category:    CategoryPlacement,
+			Summary:     "ECS could not assume the task or execution role",
+			Remediation: "Verify the role exists and its trust policy allows ecs-tasks.amazonaws.com to assume it.",
+			Object:      obj, Signals: signals, Docs: docs,
+		}
+	case containsAny(lower, "insufficientfreeaddressesinsubnet", "no free addresses in subnet"):
+		return &Failure{
+			Name:        "Network/IPExhaustion",
+			Category:    CategoryNetwork,
+			Summary:     "The service's subnets have no free IP addresses for task network interfaces",
+			Remediation: "Add larger or additional subnets to the service's network configuration.",
+			Object:      obj, Signals: signals, Docs: docs,
+		}
+	case strings.Contains(lower, "is unhealthy in") && strings.Contains(lower, "target-group"):
+		return &Failure{
+			Name:        "Health/TargetUnhealthy",
+			Category:    CategoryHealth,
+			Summary:     "Load balancer health checks are failing for the service's tasks",
+			Remediation: "Confirm the app listens on the container port, the health check path returns 2xx/3xx, and the security group allows traffic from the load balancer.",
+			Object:      obj, Signals: signals, Docs: docs,
+		}
+	case containsAny(lower, "deployment failed", "rolling back to deployment", "circuit breaker"):
+		return &Failure{
+			Name:        "Rollout/CircuitBreaker",
+			Category:    CategoryRollout,
+			Summary:     "The deployment circuit breaker stopped the rollout because tasks kept failing to start",
+			Remediation: "Inspect the stopped tasks of the failed deployment to find the underlying failure.",
+			Object:      obj, Signals: signals,
+			Docs: []string{"https://docs.aws.amazon.com/AmazonECS/latest/developerguide/deployment-circuit-breaker.html"},
+		}
+	}
+	return nil
+}
+
+// placementFailure sub-classifies "was unable to place a task" events by the closest matching
+// container instance's shortfall, e.g. "The closest matching container-instance ... has insufficient memory available."
+// Sub-reasons: insufficient resources, port conflict, missing attribute, no instances, ENI limit
+func placementFailure(obj ObjectRef, lower string, signals Signals) *Failure {
+	f := &Failure{
+		Category: CategoryPlacement,
+		Object:   obj,
+		Signals:  signals,
+		Docs:     []string{docsServiceEvents},
+	}
+	switch {
+	case containsAny(lower, "insufficient memory", "insufficient cpu"):
+		f.Name = "Placement/InsufficientResources"
+		f.Summary = "No container instance has enough free CPU or memory for the task"
+		f.Remediation = "Lower the task's CPU/memory reservations, scale out the cluster, or enable managed scaling on the capacity provider."
+	case strings.Contains(lower, "already using a port required by your task"):
+		f.Name = "Placement/PortConflict"
+		f.Summary = "Every candidate container instance already uses the task's host port"
+		f.Remediation = "Use dynamic host ports (hostPort 0) or awsvpc networking, or add container instances."
+	case containsAny(lower, "missing an attribute", "missing attribute"):
+		f.Name = "Placement/MissingAttribute"
+		f.Summary = "No container instance has an attribute the task definition requires"
+		f.Remediation = "Check the task definition's requiresAttributes and placement constraints; the instances' AMI or ECS agent may be out of date."
+	case strings.Contains(lower, "no container instances were found"):
+		f.Name = "Placement/NoContainerInstances"
+		f.Summary = "The cluster has no registered container instances to run the task on"
+		f.Remediation = "Verify the Auto Scaling group has running instances that have registered with the cluster."
+	case containsAny(lower, " eni", "network interface"):
+		f.Name = "Placement/ENILimit"
+		f.Summary = "Container instances have no free elastic network interfaces for awsvpc tasks"
+		f.Remediation = "Enable ENI trunking (awsvpcTrunking), use larger instance types, or add container instances."
+	default:
+		f.Name = "Placement/Unplaceable"
+		f.Summary = "ECS could not find a container instance that satisfies the task's requirements"
+		f.Remediation = "Inspect the event message for the unmet requirement and compare it to the cluster's container instances."
+	}
+	return f
+}
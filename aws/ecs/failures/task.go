@@ -0,0 +1,267 @@
+package failures
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+)
+
+// ClassifyTask inspects a stopped task's stop code, stopped reason, and container exit codes.
+// Returns nil for tasks that are still running or that stopped normally
+// (e.g. replaced by a deployment, scaled in, or stopped by a user).
+// The dispatch order is:
+//
+//  1. StoppedReason error prefixes (CannotPullContainerError, ResourceInitializationError, ...)
+//  2. Health check failures
+//  3. Container-level failures (OutOfMemoryError, non-zero exit of an essential container)
+//  4. StopCode (SpotInterruption, TerminationNotice, TaskFailedToStart)
+func ClassifyTask(task ecstypes.Task) *Failure {
+	if aws.ToString(task.LastStatus) != "STOPPED" && aws.ToString(task.DesiredStatus) != "STOPPED" {
+		return nil
+	}
+	obj := ObjectRef{Kind: "Task", Name: parseTaskId(aws.ToString(task.TaskArn))}
+	reason := aws.ToString(task.StoppedReason)
+	signals := Signals{StoppedReason: reason, StopCode: task.StopCode}
+
+	f := classifyStoppedReason(obj, reason, signals)
+	if f == nil {
+		f = classifyContainers(obj, task, signals)
+	}
+	if f == nil {
+		f = classifyStopCode(obj, task.StopCode, signals)
+	}
+	if f != nil && task.StoppingAt != nil {
+		f.ObservedAt = *task.StoppingAt
+	}
+	return f
+}
+
+func classifyStoppedReason(obj ObjectRef, reason string, signals Signals) *Failure {
+	lower := strings.ToLower(reason)
+	docs := []string{docsStoppedTaskErrors}
+	switch {
+	case strings.HasPrefix(reason, "CannotPullContainerError"):
+		return imagePullFailure(obj, lower, signals)
+	case strings.HasPrefix(reason, "ResourceInitializationError"):
+		return resourceInitFailure(obj, lower, signals)
+	case strings.HasPrefix(reason, "CannotStartContainerError"):
+		if strings.Contains(lower, "exec format error") {
+			return &Failure{
+				Name:        "ImageArchitectureMismatch",
+				Category:    CategoryImage,
+				Summary:     "Image architecture does not match the task's CPU architecture (amd64 vs arm64)",
+				Remediation: "Publish a multi-arch image (docker buildx --platform) or set runtimePlatform.cpuArchitecture to match the image.",
+				Object:      obj, Signals: signals, Docs: docs,
+			}
+		}
+		return &Failure{
+			Name:        "CannotStartContainer",
+			Category:    CategoryRuntime,
+			Summary:     "The container runtime failed to start the container",
+			Remediation: "Verify the entrypoint/command exist in the image and that mounts and linux parameters are valid.",
+			Object:      obj, Signals: signals, Docs: docs,
+		}
+	case strings.HasPrefix(reason, "CannotCreateContainerError"):
+		return &Failure{
+			Name:        "CannotCreateContainer",
+			Category:    CategoryRuntime,
+			Summary:     "The container runtime could not create the container",
+			Remediation: "Inspect the message; common causes are a full disk on the container instance or invalid docker options.",
+			Object:      obj, Signals: signals, Docs: docs,
+		}
+	case strings.HasPrefix(reason, "CannotInspectContainerError"):
+		return &Failure{
+			Name:        "CannotInspectContainer",
+			Category:    CategoryRuntime,
+			Summary:     "The ECS agent could not inspect the container",
+			Remediation: "This usually indicates an unhealthy container instance; drain and replace it.",
+			Object:      obj, Signals: signals, Docs: docs,
+		}
+	case strings.Contains(lower, "failed elb health checks"):
+		return &Failure{
+			Name:        "Health/ELBHealthCheck",
+			Category:    CategoryHealth,
+			Summary:     "The task was replaced because it failed load balancer health checks",
+			Remediation: "Confirm the app listens on the container port, the health check path returns 2xx/3xx, and the health check grace period covers app startup.",
+			Object:      obj, Signals: signals, Docs: docs,
+		}
+	case strings.Contains(lower, "failed container health checks"):
+		return &Failure{
+			Name:        "Health/ContainerHealthCheck",
+			Category:    CategoryHealth,
+			Summary:     "The task was replaced because a container health check failed",
+			Remediation: "Verify the healthCheck command succeeds inside the container and its startPeriod covers app startup.",
+			Object:      obj, Signals: signals, Docs: docs,
+		}
+	}
+	return nil
+}
+
+// imagePullFailure sub-classifies CannotPullContainerError by parsing the message.
+// Sub-reasons: auth, not-found, network, rate-limit. Defaults to a generic pull failure.
+func imagePullFailure(obj ObjectRef, lower string, signals Signals) *Failure {
+	f := &Failure{
+		Category: CategoryImage,
+		Object:   obj,
+		Signals:  signals,
+		Docs:     []string{"https://docs.aws.amazon.com/AmazonECS/latest/developerguide/task_cannot_pull_image.html"},
+	}
+	switch {
+	case containsAny(lower, "accessdenied", "access denied", "not authorized", "unauthorized", "no basic auth credentials", "403"):
+		f.Name = "CannotPullContainer/Auth"
+		f.Summary = "The registry rejected the task execution role's credentials"
+		f.Remediation = "Grant the task execution role ecr:GetAuthorizationToken and ecr:BatchGetImage, or configure repositoryCredentials for private registries."
+	case containsAny(lower, "not found", "manifest unknown", "does not exist"):
+		f.Name = "CannotPullContainer/NotFound"
+		f.Summary = "The image tag or repository does not exist"
+		f.Remediation = "Verify the image reference and that the tag was pushed before deploying."
+	case containsAny(lower, "toomanyrequests", "rate limit"):
+		f.Name = "CannotPullContainer/RateLimit"
+		f.Summary = "The registry rate-limited the pull (commonly Docker Hub anonymous limits)"
+		f.Remediation = "Authenticate to the registry, or mirror the image into ECR."
+	case containsAny(lower, "i/o timeout", "context deadline exceeded", "dial tcp", "no such host"):
+		f.Name = "CannotPullContainer/Network"
+		f.Summary = "The task could not reach the registry"
+		f.Remediation = "Give the task's subnets a route to the registry: a NAT gateway, a public IP, or VPC endpoints for ECR (api, dkr) and S3."
+	default:
+		f.Name = "CannotPullContainer"
+		f.Summary = "The container image could not be pulled"
+		f.Remediation = "Inspect the registry message and verify the image exists and the execution role can pull it."
+	}
+	return f
+}
+
+// resourceInitFailure sub-classifies ResourceInitializationError.
+// Sub-reasons: secrets, registry auth, log configuration, EFS. Defaults to a network failure,
+// which is the most common cause (the task can't reach AWS APIs during startup).
+// AWS prefixes most of these with "unable to pull secrets or registry auth: ...", so only the clause
+// after the prefix identifies which resource failed.
+func resourceInitFailure(obj ObjectRef, lower string, signals Signals) *Failure {
+	f := &Failure{
+		Object:  obj,
+		Signals: signals,
+		Docs:    []string{"https://docs.aws.amazon.com/AmazonECS/latest/developerguide/resource-initialization-error.html"},
+	}
+	switch {
+	case containsAny(lower, "unable to retrieve secret", "secretsmanager", "ssm parameter"):
+		f.Name = "ResourceInitialization/Secrets"
+		f.Category = CategorySecrets
+		f.Summary = "The task could not fetch its secrets from Secrets Manager or SSM Parameter Store"
+		f.Remediation = "Verify each secret ARN exists and that the task execution role can read it (and decrypt it with its KMS key); private subnets need a NAT gateway or VPC endpoints."
+	case containsAny(lower, "retrieve ecr registry auth"):
+		f.Name = "ResourceInitialization/RegistryAuth"
+		f.Category = CategoryImage
+		f.Summary = "The task could not retrieve registry credentials"
+		f.Remediation = "Grant the task execution role ecr:GetAuthorizationToken and verify the task can reach the ECR API endpoint."
+	case containsAny(lower, "logger", "log group", "createlogstream", "log driver"):
+		f.Name = "ResourceInitialization/Logging"
+		f.Category = CategoryLogging
+		f.Summary = "The task's log configuration could not be initialized"
+		f.Remediation = "Verify the CloudWatch log group exists (or set awslogs-create-group) and the execution role can write to it."
+	case containsAny(lower, "efs", "mount"):
+		f.Name = "ResourceInitialization/EFSMount"
+		f.Category = CategoryStorage
+		f.Summary = "The task could not mount its EFS volume"
+		f.Remediation = "Verify the EFS mount targets exist in the task's subnets and their security group allows NFS (2049) from the task."
+	default:
+		f.Name = "ResourceInitialization"
+		f.Category = CategoryNetwork
+		f.Summary = "The task failed to initialize its resources, usually because it could not reach AWS APIs"
+		f.Remediation = "Give the task's subnets a route to AWS APIs: a NAT gateway, a public IP, or the required VPC endpoints."
+	}
+	return f
+}
+
+// classifyContainers finds the first container that explains why the task stopped
+// OutOfMemoryError takes precedence over a non-zero exit code
+func classifyContainers(obj ObjectRef, task ecstypes.Task, signals Signals) *Failure {
+	for _, c := range task.Containers {
+		containerReason := aws.ToString(c.Reason)
+		if strings.HasPrefix(containerReason, "OutOfMemoryError") {
+			f := &Failure{
+				Name:        "OOMKilled",
+				Category:    CategoryRuntime,
+				Summary:     "Container exceeded its memory limit and was killed",
+				Remediation: "Raise the task or container memory, fix the leak, or size the runtime heap (GOMEMLIMIT, -XX:MaxRAMPercentage).",
+				Object:      obj,
+				Signals:     signals,
+				Docs:        []string{docsStoppedTaskErrors},
+			}
+			f.Object.Container = aws.ToString(c.Name)
+			f.Signals.ContainerReason = containerReason
+			f.Signals.ExitCode = c.ExitCode
+			return f
+		}
+	}
+
+	if !strings.Contains(signals.StoppedReason, "Essential container in task exited") {
+		return nil
+	}
+	for _, c := range task.Containers {
+		if c.ExitCode == nil || *c.ExitCode == 0 {
+			continue
+		}
+		f := &Failure{
+			Name:        "EssentialContainerExited",
+			Category:    CategoryRuntime,
+			Summary:     exitCodeSummary(*c.ExitCode),
+			Remediation: "Check the container's logs for the underlying exception or signal source.",
+			Object:      obj,
+			Signals:     signals,
+			Docs:        []string{docsStoppedTaskErrors},
+		}
+		f.Object.Container = aws.ToString(c.Name)
+		f.Signals.ContainerReason = aws.ToString(c.Reason)
+		f.Signals.ExitCode = c.ExitCode
+		return f
+	}
+	return nil
+}
+
+func exitCodeSummary(exitCode int32) string {
+	switch exitCode {
+	case 137:
+		return "Container received SIGKILL (often a precursor to running out of memory)"
+	case 139:
+		return "Container segfaulted (SIGSEGV)"
+	case 143:
+		return "Container received SIGTERM and did not exit gracefully"
+	}
+	return fmt.Sprintf("Essential container exited with code %d", exitCode)
+}
+
+func classifyStopCode(obj ObjectRef, stopCode ecstypes.TaskStopCode, signals Signals) *Failure {
+	switch stopCode {
+	case ecstypes.TaskStopCodeSpotInterruption:
+		return &Failure{
+			Name:        "Capacity/SpotInterruption",
+			Category:    CategoryCapacity,
+			Summary:     "The task's Spot capacity was reclaimed by AWS",
+			Remediation: "Make the app tolerate interruptions, or mix on-demand capacity into the capacity provider strategy.",
+			Object:      obj, Signals: signals, Docs: []string{docsStoppedTaskErrors},
+		}
+	case ecstypes.TaskStopCodeTerminationNotice:
+		return &Failure{
+			Name:        "Capacity/TerminationNotice",
+			Category:    CategoryCapacity,
+			Summary:     "The task's host was retired (Fargate maintenance or instance termination)",
+			Remediation: "No action is usually required; the service scheduler replaces the task.",
+			Object:      obj, Signals: signals, Docs: []string{docsStoppedTaskErrors},
+		}
+	case ecstypes.TaskStopCodeTaskFailedToStart:
+		return &Failure{
+			Name:        "TaskFailedToStart",
+			Category:    CategoryRuntime,
+			Summary:     "The task failed to start",
+			Remediation: "Inspect the stopped reason for the underlying error.",
+			Object:      obj, Signals: signals, Docs: []string{docsStoppedTaskErrors},
+		}
+	}
+	return nil
+}
+
+func parseTaskId(taskArn string) string {
+	return taskArn[strings.LastIndex(taskArn, "/")+1:]
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/nullstone-io/deployment-sdk/aws/ecs/failures"
 )
 
 // EcsJobExecutionPhase mirrors AppStatusJobExecutionPhase on the K8s side so
@@ -35,6 +36,7 @@ type EcsJobExecution struct {
 	StopCode               ecstypes.TaskStopCode `json:"stopCode,omitempty"`
 	EnableExecuteCommand   bool                  `json:"enableExecuteCommand"`
 	Containers             []StatusTaskContainer `json:"containers"`
+	Failure                *failures.Failure     `json:"failure,omitempty"`
 }
 
 // EcsJobExecutionFromStatusTask folds an enriched StatusTask plus its
@@ -54,6 +56,7 @@ func EcsJobExecutionFromStatusTask(task StatusTask, appVersion string) EcsJobExe
 		StopCode:               task.StopCode,
 		EnableExecuteCommand:   task.EnableExecuteCommand,
 		Containers:             task.Containers,
+		Failure:                task.Failure,
 	}
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
@@ -13,6 +14,7 @@ import (
 	"github.com/nullstone-io/deployment-sdk/aws/cloudwatch"
 	"github.com/nullstone-io/deployment-sdk/logging"
 	"github.com/nullstone-io/deployment-sdk/outputs"
+	"golang.org/x/sync/errgroup"
 )
 
 // NewLogStreamer returns an ECS-aware log streamer that translates the high-level
@@ -51,12 +53,68 @@ func (l LogStreamer) Stream(ctx context.Context, options app.LogStreamOptions) e
 	if err != nil {
 		return err
 	}
-	if streamNames != nil {
-		options.LogStreamNames = streamNames
+	if streamNames == nil {
+		return l.Inner.Stream(ctx, options)
 	}
+	if options.WatchInterval >= 0 {
+		return l.watchStreams(ctx, options, streamNames)
+	}
+	if len(streamNames) == 0 {
+		// The filter matched no log streams; streaming the whole log group would show unrelated tasks
+		return nil
+	}
+	options.LogStreamNames = streamNames
 	return l.Inner.Stream(ctx, options)
 }
 
+// watchStreams streams the log streams matching the Task / Deployment / Job filters until ctx is cancelled.
+// The filters are re-resolved on each poll because tasks that start later (e.g. during a deployment)
+// create their log streams after streaming begins.
+func (l LogStreamer) watchStreams(ctx context.Context, options app.LogStreamOptions, streamNames []string) error {
+	interval := options.WatchInterval
+	if interval == 0 {
+		interval = cloudwatch.DefaultWatchInterval
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	streaming := map[string]struct{}{}
+	streamNew := func(names []string) {
+		added := make([]string, 0)
+		for _, name := range names {
+			if _, ok := streaming[name]; !ok {
+				streaming[name] = struct{}{}
+				added = append(added, name)
+			}
+		}
+		if len(added) == 0 {
+			return
+		}
+		opts := options
+		opts.LogStreamNames = added
+		g.Go(func() error { return l.Inner.Stream(ctx, opts) })
+	}
+
+	streamNew(streamNames)
+	g.Go(func() error {
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(interval):
+			}
+			names, err := l.resolveStreamNames(ctx, options)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return err
+			}
+			streamNew(names)
+		}
+	})
+	return g.Wait()
+}
+
 // resolveStreamNames translates the Task / Deployment / Job filters into the
 // concrete list of CloudWatch log streams to scope on. Returns nil when no
 // ECS-specific filter is set, signaling the cloudwatch streamer to stream the
@@ -101,7 +159,7 @@ func (l LogStreamer) findStreamsForTaskIds(ctx context.Context, wantedTaskIds ma
 	}
 
 	cwlClient := cloudwatchlogs.NewFromConfig(nsaws.NewConfig(l.Inner.Infra.LogReader, l.Inner.Infra.Region))
-	var matched []string
+	matched := make([]string, 0)
 	for _, lgName := range logGroupNames {
 		var nextToken *string
 		for {
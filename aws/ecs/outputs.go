@@ -17,6 +17,8 @@ type Outputs struct {
 	ImageRepoUrl      docker.ImageUrl   `ns:"image_repo_url,optional"`
 	MainContainerName string            `ns:"main_container_name,optional"`
 	Deployer          nsaws.IamIdentity `ns:"deployer,optional"`
+	// MaxScale limits how many tasks the scale/resume actions may request (0 = no limit)
+	MaxScale int32 `ns:"max_scale,optional"`
 
 	Cluster          ClusterOutputs          `ns:",connectionContract:cluster/aws/ecs:*,optional"`
 	ClusterNamespace ClusterNamespaceOutputs `ns:",connectionContract:cluster-namespace/aws/ecs:*,optional"`
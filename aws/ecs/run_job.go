@@ -0,0 +1,287 @@
+package ecs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/nullstone-io/deployment-sdk/app"
+	nsaws "github.com/nullstone-io/deployment-sdk/aws"
+	"github.com/nullstone-io/deployment-sdk/aws/ecs/failures"
+)
+
+const (
+	defaultRunJobPollInterval = 5 * time.Second
+	// runJobLogFlushDelay gives CloudWatch a moment to ingest the final log events after a task stops
+	runJobLogFlushDelay = 5 * time.Second
+)
+
+type RunJobOptions struct {
+	// Command overrides the command of the job's container
+	Command []string
+	// Environment is merged into the environment variables of the job's container
+	Environment map[string]string
+	// Container is the container that receives the overrides and whose exit code is reported
+	// If empty, the app's main container is used (or the only essential container in the task definition)
+	Container string
+	// StartedBy is recorded on the task (max 36 characters)
+	StartedBy string
+
+	// LogStreamer follows the task's logs while it runs (typically an ecs.LogStreamer)
+	// If nil, logs are not followed
+	LogStreamer app.LogStreamer
+	// LogEmitter receives log messages when following logs
+	LogEmitter app.LogEmitter
+
+	// PollInterval dictates how often the task is polled for its status (default 5s)
+	PollInterval time.Duration
+}
+
+type RunJobResult struct {
+	TaskArn       string                `json:"taskArn"`
+	TaskId        string                `json:"taskId"`
+	Container     string                `json:"container"`
+	Phase         EcsJobExecutionPhase  `json:"phase"`
+	ExitCode      *int32                `json:"exitCode,omitempty"`
+	StopCode      ecstypes.TaskStopCode `json:"stopCode,omitempty"`
+	StoppedReason string                `json:"stoppedReason,omitempty"`
+	// ContainerReason explains why the container stopped (e.g. "OutOfMemoryError: Container killed due to memory usage")
+	ContainerReason string `json:"containerReason,omitempty"`
+	// Failure explains why the job failed when the stop reason matches a known failure mode
+	Failure *failures.Failure `json:"failure,omitempty"`
+}
+
+func (r RunJobResult) Succeeded() bool {
+	return r.Phase == EcsJobPhaseSucceeded
+}
+
+// RunJob launches a task from the latest active revision of the job workspace's task definition,
+// then waits for the task to stop and reports the essential container's exit code.
+// The task reuses the network placement of the most recent task in the family.
+// If ctx is cancelled before the task stops, the task is left running and ctx.Err() is returned.
+func RunJob(ctx context.Context, infra Outputs, options RunJobOptions) (*RunJobResult, error) {
+	taskDef, err := GetTaskDefinitionByArn(ctx, infra, infra.TaskFamily())
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving task definition %q: %w", infra.TaskFamily(), err)
+	}
+	container, err := resolveJobContainer(taskDef, options.Container, infra.MainContainerName)
+	if err != nil {
+		return nil, err
+	}
+
+	runInput, err := buildRunJobInput(ctx, infra, taskDef, container, options)
+	if err != nil {
+		return nil, err
+	}
+	client := ecs.NewFromConfig(nsaws.NewConfig(infra.Deployer, infra.Region))
+	out, err := client.RunTask(ctx, runInput)
+	if err != nil {
+		return nil, fmt.Errorf("error running task: %w", err)
+	}
+	if len(out.Failures) > 0 {
+		f := out.Failures[0]
+		return nil, fmt.Errorf("ecs RunTask failure: arn=%s reason=%s detail=%s",
+			aws.ToString(f.Arn), aws.ToString(f.Reason), aws.ToString(f.Detail))
+	}
+	if len(out.Tasks) == 0 {
+		return nil, fmt.Errorf("ecs RunTask returned no tasks and no failures")
+	}
+
+	task := out.Tasks[0]
+	result := &RunJobResult{
+		TaskArn:   aws.ToString(task.TaskArn),
+		TaskId:    parseTaskId(task.TaskArn),
+		Container: container,
+		Phase:     EcsJobPhaseQueued,
+	}
+	stopped, err := waitForJobTask(ctx, infra, result.TaskId, options)
+	if err != nil {
+		return result, err
+	}
+	summarizeStoppedJobTask(result, *stopped)
+	return result, nil
+}
+
+func buildRunJobInput(ctx context.Context, infra Outputs, taskDef *ecstypes.TaskDefinition, container string, options RunJobOptions) (*ecs.RunTaskInput, error) {
+	startedBy := options.StartedBy
+	if startedBy == "" {
+		startedBy = "nullstone:run-job"
+	}
+	if len(startedBy) > 36 {
+		startedBy = startedBy[:36]
+	}
+
+	runInput := &ecs.RunTaskInput{
+		Cluster:        aws.String(infra.ClusterArn()),
+		TaskDefinition: taskDef.TaskDefinitionArn,
+		Count:          aws.Int32(1),
+		StartedBy:      aws.String(startedBy),
+	}
+	if len(options.Command) > 0 || len(options.Environment) > 0 {
+		override := ecstypes.ContainerOverride{
+			Name:    aws.String(container),
+			Command: options.Command,
+		}
+		names := make([]string, 0, len(options.Environment))
+		for name := range options.Environment {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			override.Environment = append(override.Environment, ecstypes.KeyValuePair{
+				Name:  aws.String(name),
+				Value: aws.String(options.Environment[name]),
+			})
+		}
+		runInput.Overrides = &ecstypes.TaskOverride{ContainerOverrides: []ecstypes.ContainerOverride{override}}
+	}
+
+	// Job workspaces don't expose their network placement as outputs,
+	// so we borrow the placement from the most recent task in the family (see Actioner.rerunJob)
+	tasks, err := GetTaskFamilyTasks(ctx, infra)
+	if err != nil {
+		return nil, err
+	}
+	var latest *ecstypes.Task
+	for i, task := range tasks {
+		if latest == nil || aws.ToTime(task.CreatedAt).After(aws.ToTime(latest.CreatedAt)) {
+			latest = &tasks[i]
+		}
+	}
+	if latest != nil {
+		runInput.EnableExecuteCommand = latest.EnableExecuteCommand
+		if pv := aws.ToString(latest.PlatformVersion); pv != "" {
+			runInput.PlatformVersion = aws.String(pv)
+		}
+		// LaunchType and CapacityProviderStrategy are mutually exclusive in RunTask.
+		if cp := aws.ToString(latest.CapacityProviderName); cp != "" {
+			runInput.CapacityProviderStrategy = []ecstypes.CapacityProviderStrategyItem{
+				{CapacityProvider: aws.String(cp), Weight: 1},
+			}
+		} else if latest.LaunchType != "" {
+			runInput.LaunchType = latest.LaunchType
+		}
+		if subnets := subnetsFromAttachments(latest.Attachments); len(subnets) > 0 {
+			runInput.NetworkConfiguration = &ecstypes.NetworkConfiguration{
+				AwsvpcConfiguration: &ecstypes.AwsVpcConfiguration{Subnets: subnets},
+			}
+		}
+	}
+	if runInput.NetworkConfiguration == nil && taskDef.NetworkMode == ecstypes.NetworkModeAwsvpc {
+		return nil, fmt.Errorf("cannot determine subnets for task family %q: no previous task was found", infra.TaskFamily())
+	}
+	return runInput, nil
+}
+
+// waitForJobTask polls the task until it stops
+// Once the task is running, its logs are followed until shortly after it stops
+func waitForJobTask(ctx context.Context, infra Outputs, taskId string, options RunJobOptions) (*ecstypes.Task, error) {
+	pollInterval := options.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = defaultRunJobPollInterval
+	}
+
+	logsCtx, cancelLogs := context.WithCancel(ctx)
+	defer cancelLogs()
+	logsDone := make(chan error, 1)
+	followingLogs := false
+	startLogs := func() {
+		if followingLogs || options.LogStreamer == nil {
+			return
+		}
+		followingLogs = true
+		startTime := time.Now().Add(-time.Minute)
+		go func() {
+			logsDone <- options.LogStreamer.Stream(logsCtx, app.LogStreamOptions{
+				StartTime: &startTime,
+				Task:      taskId,
+				Emitter:   options.LogEmitter,
+			})
+		}()
+	}
+	stopLogs := func() {
+		if !followingLogs {
+			return
+		}
+		select {
+		case <-time.After(runJobLogFlushDelay):
+		case <-ctx.Done():
+		}
+		cancelLogs()
+		<-logsDone
+	}
+
+	for {
+		tasks, err := DescribeTasks(ctx, infra, []string{taskId})
+		if err != nil {
+			return nil, err
+		}
+		if len(tasks) > 0 {
+			task := tasks[0]
+			switch aws.ToString(task.LastStatus) {
+			case "RUNNING", "DEACTIVATING", "STOPPING", "DEPROVISIONING":
+				startLogs()
+			case "STOPPED":
+				// Tasks that stop quickly may skip RUNNING between polls; their logs are still worth reading
+				startLogs()
+				stopLogs()
+				return &task, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// summarizeStoppedJobTask fills in the outcome of a stopped job task using the same
+// phase classification as EcsJobExecution
+func summarizeStoppedJobTask(result *RunJobResult, task ecstypes.Task) {
+	st := StatusTaskFromEcsTask(task)
+	result.Phase = deriveEcsJobPhase(st)
+	result.StopCode = task.StopCode
+	result.StoppedReason = aws.ToString(task.StoppedReason)
+	result.Failure = st.Failure
+	for _, c := range task.Containers {
+		if aws.ToString(c.Name) != result.Container {
+			continue
+		}
+		result.ExitCode = c.ExitCode
+		result.ContainerReason = aws.ToString(c.Reason)
+	}
+}
+
+// resolveJobContainer picks the container that receives overrides and reports the job's exit code
+func resolveJobContainer(taskDef *ecstypes.TaskDefinition, requested, mainContainerName string) (string, error) {
+	name := requested
+	if name == "" {
+		name = mainContainerName
+	}
+	if name != "" {
+		for _, cd := range taskDef.ContainerDefinitions {
+			if aws.ToString(cd.Name) == name {
+				return name, nil
+			}
+		}
+		return "", fmt.Errorf("container %q was not found in task definition %q", name, aws.ToString(taskDef.Family))
+	}
+
+	essential := make([]string, 0)
+	for _, cd := range taskDef.ContainerDefinitions {
+		// Essential defaults to true when omitted from the task definition
+		if cd.Essential == nil || *cd.Essential {
+			essential = append(essential, aws.ToString(cd.Name))
+		}
+	}
+	if len(essential) != 1 {
+		return "", fmt.Errorf("task definition %q has %d essential containers, a container name is required", aws.ToString(taskDef.Family), len(essential))
+	}
+	return essential[0], nil
+}
@@ -0,0 +1,97 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveJobContainer(t *testing.T) {
+	taskDef := func(containers ...ecstypes.ContainerDefinition) *ecstypes.TaskDefinition {
+		return &ecstypes.TaskDefinition{Family: aws.String("migrate"), ContainerDefinitions: containers}
+	}
+	app := ecstypes.ContainerDefinition{Name: aws.String("app")}
+	sidecar := ecstypes.ContainerDefinition{Name: aws.String("log-router"), Essential: aws.Bool(false)}
+	proxy := ecstypes.ContainerDefinition{Name: aws.String("proxy"), Essential: aws.Bool(true)}
+
+	tests := []struct {
+		name      string
+		taskDef   *ecstypes.TaskDefinition
+		requested string
+		main      string
+		want      string
+		wantErr   string
+	}{
+		{
+			name:      "requested container",
+			taskDef:   taskDef(app, sidecar),
+			requested: "log-router",
+			main:      "app",
+			want:      "log-router",
+		},
+		{
+			name:    "main container",
+			taskDef: taskDef(app, proxy),
+			main:    "app",
+			want:    "app",
+		},
+		{
+			name:    "only essential container",
+			taskDef: taskDef(app, sidecar),
+			want:    "app",
+		},
+		{
+			name:    "multiple essential containers",
+			taskDef: taskDef(app, proxy),
+			wantErr: `task definition "migrate" has 2 essential containers, a container name is required`,
+		},
+		{
+			name:      "unknown container",
+			taskDef:   taskDef(app),
+			requested: "worker",
+			wantErr:   `container "worker" was not found in task definition "migrate"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := resolveJobContainer(test.taskDef, test.requested, test.main)
+			if test.wantErr != "" {
+				assert.EqualError(t, err, test.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestSummarizeStoppedJobTask(t *testing.T) {
+	task := ecstypes.Task{
+		TaskArn:       aws.String("arn:aws:ecs:us-east-1:123456789012:task/cluster/abc123"),
+		LastStatus:    aws.String("STOPPED"),
+		StopCode:      ecstypes.TaskStopCodeEssentialContainerExited,
+		StoppedReason: aws.String("Essential container in task exited"),
+		Containers: []ecstypes.Container{
+			{Name: aws.String("log-router"), ExitCode: aws.Int32(0)},
+			{Name: aws.String("app"), ExitCode: aws.Int32(2), Reason: aws.String("")},
+		},
+	}
+	result := &RunJobResult{TaskId: "abc123", Container: "app"}
+	summarizeStoppedJobTask(result, task)
+	require.NotNil(t, result.Failure)
+	assert.Equal(t, "EssentialContainerExited", result.Failure.Name)
+	assert.Equal(t, "app", result.Failure.Object.Container)
+	assert.Equal(t, &RunJobResult{
+		TaskId:        "abc123",
+		Container:     "app",
+		Phase:         EcsJobPhaseFailed,
+		ExitCode:      aws.Int32(2),
+		StopCode:      ecstypes.TaskStopCodeEssentialContainerExited,
+		StoppedReason: "Essential container in task exited",
+		Failure:       result.Failure,
+	}, result)
+	assert.False(t, result.Succeeded())
+}
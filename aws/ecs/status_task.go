@@ -3,6 +3,7 @@ package ecs
 import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/nullstone-io/deployment-sdk/aws/ecs/failures"
 	"strings"
 	"time"
 )
@@ -50,6 +51,8 @@ type StatusTask struct {
 	StatusExplanation      string                `json:"statusExplanation"`
 	Health                 string                `json:"health"`
 	Containers             []StatusTaskContainer `json:"containers"`
+	// Failure explains why the task stopped when it stopped abnormally
+	Failure *failures.Failure `json:"failure,omitempty"`
 }
 
 func StatusTaskFromEcsTask(task ecstypes.Task) StatusTask {
@@ -80,6 +83,7 @@ func StatusTaskFromEcsTask(task ecstypes.Task) StatusTask {
 		Status:                 aws.ToString(task.LastStatus),
 		Health:                 string(task.HealthStatus),
 		Containers:             containers,
+		Failure:                failures.ClassifyTask(task),
 	}
 }
 
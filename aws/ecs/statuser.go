@@ -5,6 +5,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
 	"github.com/nullstone-io/deployment-sdk/app"
+	"github.com/nullstone-io/deployment-sdk/aws/ecs/failures"
 	"github.com/nullstone-io/deployment-sdk/logging"
 	"github.com/nullstone-io/deployment-sdk/outputs"
 	"time"
@@ -55,6 +56,8 @@ type Status struct {
 	IsJob      bool              `json:"isJob"`
 	Tasks      []StatusTask      `json:"tasks"`
 	Executions []EcsJobExecution `json:"executions"`
+	// Failures are the problems ECS reported for the service since it last reached a steady state
+	Failures []failures.Failure `json:"failures,omitempty"`
 }
 
 func NewStatuser(ctx context.Context, osWriters logging.OsWriters, source outputs.RetrieverSource, appDetails app.Details) (app.Statuser, error) {
@@ -139,6 +142,7 @@ func (s Statuser) Status(ctx context.Context) (any, error) {
 	}
 	if svc != nil {
 		st.ServiceName = aws.ToString(svc.ServiceName)
+		st.Failures = failures.ClassifyServiceEvents(st.ServiceName, svc.Events)
 	} else {
 		st.ServiceName = s.Infra.ServiceName
 	}
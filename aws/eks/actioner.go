@@ -22,8 +22,10 @@ func NewActioner(ctx context.Context, source outputs.RetrieverSource, blockDetai
 	outs.Deployer.RemoteProvider = credsFactory(types.AutomationPurposePerformAction, "deployer")
 
 	return k8s.Actioner{
-		Namespace: outs.ServiceNamespace,
-		AppName:   blockDetails.Block.Name,
+		Namespace:         outs.ServiceNamespace,
+		AppName:           blockDetails.Block.Name,
+		MaxReplicas:       outs.MaxScale,
+		MainContainerName: outs.MainContainerName,
 		NewConfigFn: func(ctx context.Context) (*rest.Config, error) {
 			return CreateKubeConfig(ctx, outs.ClusterNamespace, outs.Deployer)
 		},
@@ -5,6 +5,7 @@ import (
 
 	"github.com/nullstone-io/deployment-sdk/app"
 	"github.com/nullstone-io/deployment-sdk/k8s"
+	"github.com/nullstone-io/deployment-sdk/k8s/failures"
 	"github.com/nullstone-io/deployment-sdk/logging"
 	"github.com/nullstone-io/deployment-sdk/outputs"
 	"k8s.io/client-go/rest"
@@ -16,12 +17,23 @@ func NewDeployWatcher(ctx context.Context, osWriters logging.OsWriters, source o
 		return nil, err
 	}
 	outs.InitializeCreds(source, appDetails.Workspace)
+	workloadKind, err := k8s.ParseWorkloadKind(outs.WorkloadKind)
+	if err != nil {
+		return nil, err
+	}
+	catalog, err := failures.Default().WithProvider(failures.ProviderEKS)
+	if err != nil {
+		return nil, err
+	}
 
 	return &k8s.DeployWatcher{
-		OsWriters:    osWriters,
-		Details:      appDetails,
-		AppNamespace: outs.ServiceNamespace,
-		AppName:      outs.ServiceName,
+		OsWriters:       osWriters,
+		Details:         appDetails,
+		AppNamespace:    outs.ServiceNamespace,
+		WorkloadKind:    workloadKind,
+		AppName:         outs.ServiceName,
+		SkipRouteChecks: outs.SkipRouteChecks,
+		Failures:        catalog,
 		NewConfigFn: func(ctx context.Context) (*rest.Config, error) {
 			return CreateKubeConfig(ctx, outs.ClusterNamespace, outs.Deployer)
 		},
@@ -21,6 +21,14 @@ type Outputs struct {
 	Deployer          nsaws.IamIdentity `ns:"deployer,optional"`
 	MainContainerName string            `ns:"main_container_name,optional"`
 	JobDefinitionName string            `ns:"job_definition_name,optional"`
+	// WorkloadKind is the kind of object named ServiceName (Deployment, StatefulSet, DaemonSet, or Rollout)
+	WorkloadKind string `ns:"workload_kind,optional"`
+	// MaxScale limits how many replicas the scale/resume actions may request (0 = no limit)
+	MaxScale int32 `ns:"max_scale,optional"`
+	// SkipRouteChecks completes deployments once the workload rolls out without waiting for Ingresses and HTTPRoutes
+	SkipRouteChecks bool `ns:"skip_route_checks,optional"`
+	// SkipPreflightChecks deploys without verifying the ConfigMaps, Secrets and other objects the pod template references
+	SkipPreflightChecks bool `ns:"skip_preflight_checks,optional"`
 
 	ClusterNamespace ClusterNamespaceOutputs `ns:",connectionContract:cluster-namespace/aws/k8s:eks"`
 }
@@ -0,0 +1,34 @@
+package s3
+
+import (
+	"context"
+
+	"github.com/nullstone-io/deployment-sdk/app"
+	"github.com/nullstone-io/deployment-sdk/aws/cdn"
+	"github.com/nullstone-io/deployment-sdk/logging"
+	"github.com/nullstone-io/deployment-sdk/outputs"
+	"github.com/nullstone-io/deployment-sdk/staticsite"
+)
+
+func NewStatuser(ctx context.Context, osWriters logging.OsWriters, source outputs.RetrieverSource, appDetails app.Details) (app.Statuser, error) {
+	outs, err := outputs.Retrieve[Outputs](ctx, source, appDetails.Workspace, appDetails.WorkspaceConfig)
+	if err != nil {
+		return nil, err
+	}
+	outs.InitializeCreds(source, appDetails.Workspace)
+
+	cdnOutputs := cdn.Outputs{
+		Region:               outs.Region,
+		Deployer:             outs.Deployer,
+		CdnIds:               outs.CdnIds,
+		ArtifactsKeyTemplate: outs.ArtifactsKeyTemplate,
+	}
+	return staticsite.Statuser{
+		OsWriters: osWriters,
+		Details:   appDetails,
+		GetCdnStatusesFn: func(ctx context.Context) ([]staticsite.CdnStatus, error) {
+			return cdn.GetCdnStatuses(ctx, cdnOutputs)
+		},
+		ListArtifactVersionsFn: DirPusher{OsWriters: osWriters, Infra: outs, AppDetails: appDetails}.ListArtifactVersions,
+	}, nil
+}
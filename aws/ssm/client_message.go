@@ -0,0 +1,138 @@
+package ssm
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Message types exchanged over the session manager data channel
+// See https://github.com/aws/session-manager-plugin/blob/mainline/src/message/clientmessage.go
+const (
+	MessageTypeInputStreamData  = "input_stream_data"
+	MessageTypeOutputStreamData = "output_stream_data"
+	MessageTypeAcknowledge      = "acknowledge"
+	MessageTypeChannelClosed    = "channel_closed"
+	MessageTypeStartPublication = "start_publication"
+	MessageTypePausePublication = "pause_publication"
+)
+
+type PayloadType uint32
+
+const (
+	PayloadTypeOutput               PayloadType = 1
+	PayloadTypeError                PayloadType = 2
+	PayloadTypeSize                 PayloadType = 3
+	PayloadTypeParameter            PayloadType = 4
+	PayloadTypeHandshakeRequest     PayloadType = 5
+	PayloadTypeHandshakeResponse    PayloadType = 6
+	PayloadTypeHandshakeComplete    PayloadType = 7
+	PayloadTypeEncChallengeRequest  PayloadType = 8
+	PayloadTypeEncChallengeResponse PayloadType = 9
+	PayloadTypeFlag                 PayloadType = 10
+	PayloadTypeStdErr               PayloadType = 11
+	PayloadTypeExitCode             PayloadType = 12
+)
+
+// Binary layout of a ClientMessage
+// Every field is big-endian; MessageType is right-padded with spaces to 32 bytes.
+const (
+	clientMessageHeaderLength = 116
+
+	hlOffset             = 0
+	messageTypeOffset    = 4
+	messageTypeLength    = 32
+	schemaVersionOffset  = 36
+	createdDateOffset    = 40
+	sequenceNumberOffset = 48
+	flagsOffset          = 56
+	messageIdOffset      = 64
+	payloadDigestOffset  = 80
+	payloadDigestLength  = 32
+	payloadTypeOffset    = 112
+	payloadLengthOffset  = 116
+	payloadOffset        = 120
+)
+
+// ClientMessage is the binary frame used by the SSM session manager protocol on the data channel websocket
+type ClientMessage struct {
+	MessageType    string
+	SchemaVersion  uint32
+	CreatedDate    time.Time
+	SequenceNumber int64
+	Flags          uint64
+	MessageId      uuid.UUID
+	PayloadType    PayloadType
+	Payload        []byte
+}
+
+// MarshalBinary encodes the message into the wire format expected by the SSM agent
+func (m ClientMessage) MarshalBinary() ([]byte, error) {
+	if len(m.MessageType) > messageTypeLength {
+		return nil, fmt.Errorf("message type %q exceeds %d bytes", m.MessageType, messageTypeLength)
+	}
+
+	buf := make([]byte, payloadOffset+len(m.Payload))
+	binary.BigEndian.PutUint32(buf[hlOffset:], clientMessageHeaderLength)
+	copy(buf[messageTypeOffset:], []byte(m.MessageType+strings.Repeat(" ", messageTypeLength-len(m.MessageType))))
+	binary.BigEndian.PutUint32(buf[schemaVersionOffset:], m.SchemaVersion)
+	binary.BigEndian.PutUint64(buf[createdDateOffset:], uint64(m.CreatedDate.UnixMilli()))
+	binary.BigEndian.PutUint64(buf[sequenceNumberOffset:], uint64(m.SequenceNumber))
+	binary.BigEndian.PutUint64(buf[flagsOffset:], m.Flags)
+	putMessageId(buf[messageIdOffset:], m.MessageId)
+	digest := sha256.Sum256(m.Payload)
+	copy(buf[payloadDigestOffset:], digest[:])
+	binary.BigEndian.PutUint32(buf[payloadTypeOffset:], uint32(m.PayloadType))
+	binary.BigEndian.PutUint32(buf[payloadLengthOffset:], uint32(len(m.Payload)))
+	copy(buf[payloadOffset:], m.Payload)
+	return buf, nil
+}
+
+// UnmarshalBinary decodes a message received from the SSM agent and verifies its payload digest
+func (m *ClientMessage) UnmarshalBinary(data []byte) error {
+	if len(data) < payloadOffset {
+		return fmt.Errorf("client message is too short (%d bytes)", len(data))
+	}
+	headerLength := binary.BigEndian.Uint32(data[hlOffset:])
+	if headerLength != clientMessageHeaderLength {
+		return fmt.Errorf("unexpected client message header length %d", headerLength)
+	}
+	payloadLength := binary.BigEndian.Uint32(data[payloadLengthOffset:])
+	if uint64(len(data)) < uint64(payloadOffset)+uint64(payloadLength) {
+		return fmt.Errorf("client message payload is truncated (want %d bytes, have %d)", payloadLength, len(data)-payloadOffset)
+	}
+
+	m.MessageType = strings.TrimRight(string(bytes.TrimRight(data[messageTypeOffset:messageTypeOffset+messageTypeLength], "\x00")), " ")
+	m.SchemaVersion = binary.BigEndian.Uint32(data[schemaVersionOffset:])
+	m.CreatedDate = time.UnixMilli(int64(binary.BigEndian.Uint64(data[createdDateOffset:])))
+	m.SequenceNumber = int64(binary.BigEndian.Uint64(data[sequenceNumberOffset:]))
+	m.Flags = binary.BigEndian.Uint64(data[flagsOffset:])
+	m.MessageId = getMessageId(data[messageIdOffset:])
+	m.PayloadType = PayloadType(binary.BigEndian.Uint32(data[payloadTypeOffset:]))
+	m.Payload = append([]byte{}, data[payloadOffset:payloadOffset+int(payloadLength)]...)
+
+	digest := sha256.Sum256(m.Payload)
+	if !bytes.Equal(digest[:], data[payloadDigestOffset:payloadDigestOffset+payloadDigestLength]) {
+		return fmt.Errorf("client message payload digest mismatch")
+	}
+	return nil
+}
+
+// putMessageId writes a UUID the way the session manager plugin does:
+// the least-significant 8 bytes first, followed by the most-significant 8 bytes.
+func putMessageId(buf []byte, id uuid.UUID) {
+	copy(buf[0:8], id[8:16])
+	copy(buf[8:16], id[0:8])
+}
+
+func getMessageId(buf []byte) uuid.UUID {
+	var id uuid.UUID
+	copy(id[8:16], buf[0:8])
+	copy(id[0:8], buf[8:16])
+	return id
+}
@@ -0,0 +1,55 @@
+package ssm
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientMessage_RoundTrip(t *testing.T) {
+	msg := ClientMessage{
+		MessageType:    MessageTypeInputStreamData,
+		SchemaVersion:  1,
+		CreatedDate:    time.UnixMilli(1700000000123),
+		SequenceNumber: 42,
+		Flags:          3,
+		MessageId:      uuid.MustParse("0123e456-7890-4abc-8def-0123456789ab"),
+		PayloadType:    PayloadTypeSize,
+		Payload:        []byte(`{"cols":80,"rows":24}`),
+	}
+
+	raw, err := msg.MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(clientMessageHeaderLength), binary.BigEndian.Uint32(raw[0:4]))
+	assert.Equal(t, "input_stream_data               ", string(raw[messageTypeOffset:messageTypeOffset+messageTypeLength]))
+	// The least-significant half of the message id is written first
+	assert.Equal(t, msg.MessageId[8:16], raw[messageIdOffset:messageIdOffset+8])
+
+	var got ClientMessage
+	require.NoError(t, got.UnmarshalBinary(raw))
+	assert.Equal(t, msg, got)
+}
+
+func TestClientMessage_UnmarshalBinary(t *testing.T) {
+	valid, err := ClientMessage{MessageType: MessageTypeOutputStreamData, Payload: []byte("hello")}.MarshalBinary()
+	require.NoError(t, err)
+
+	t.Run("too short", func(t *testing.T) {
+		var got ClientMessage
+		assert.Error(t, got.UnmarshalBinary(valid[:payloadOffset-1]))
+	})
+	t.Run("truncated payload", func(t *testing.T) {
+		var got ClientMessage
+		assert.Error(t, got.UnmarshalBinary(valid[:len(valid)-1]))
+	})
+	t.Run("digest mismatch", func(t *testing.T) {
+		tampered := append([]byte{}, valid...)
+		tampered[len(tampered)-1] = 'X'
+		var got ClientMessage
+		assert.Error(t, got.UnmarshalBinary(tampered))
+	})
+}
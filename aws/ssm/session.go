@@ -0,0 +1,487 @@
+package ssm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// ClientVersion is reported to the SSM agent during the handshake
+	// Agents use this to decide which protocol features the client supports
+	ClientVersion = "1.2.694.0"
+
+	keepAliveInterval = 5 * time.Minute
+	stdinBufferSize   = 1024
+)
+
+// Handshake action types and statuses
+// See https://github.com/aws/session-manager-plugin/blob/mainline/src/message/messageparser.go
+const (
+	actionTypeKMSEncryption = "KMSEncryption"
+	actionTypeSessionType   = "SessionType"
+
+	actionStatusSuccess     = 1
+	actionStatusFailed      = 2
+	actionStatusUnsupported = 3
+)
+
+var (
+	ErrKMSEncryptionUnsupported = errors.New("session requires KMS encryption which is not supported")
+)
+
+// TerminalSize is sent to the agent so the remote pty matches the local terminal
+type TerminalSize struct {
+	Cols uint32 `json:"cols"`
+	Rows uint32 `json:"rows"`
+}
+
+// SessionIO wires a session to local streams
+type SessionIO struct {
+	// Stdin is forwarded to the remote process once the handshake completes
+	// If nil, no input is sent
+	Stdin io.Reader
+	// Stdout receives the remote process output
+	Stdout io.Writer
+	// Stderr receives remote stderr when the agent separates streams
+	// If nil, stderr is written to Stdout
+	Stderr io.Writer
+	// TerminalSize is sent once the handshake completes
+	TerminalSize *TerminalSize
+	// Resize delivers terminal size changes during an interactive session
+	Resize <-chan TerminalSize
+}
+
+// SessionResult describes how a session ended
+type SessionResult struct {
+	// ExitCode is the exit code of the remote process
+	// This is only reported by agents that support it; otherwise nil
+	ExitCode *int
+	// CloseReason is the message sent by the agent when it closed the channel
+	CloseReason string
+}
+
+// Session is a session manager data channel connected to the SSM agent
+// This implements enough of the session-manager-plugin protocol to proxy stdio for ECS Exec
+type Session struct {
+	conn *websocket.Conn
+
+	writeMu sync.Mutex
+	inSeq   int64
+}
+
+type openDataChannelInput struct {
+	MessageSchemaVersion string `json:"MessageSchemaVersion"`
+	RequestId            string `json:"RequestId"`
+	TokenValue           string `json:"TokenValue"`
+	ClientId             string `json:"ClientId"`
+	ClientVersion        string `json:"ClientVersion"`
+}
+
+// StartSession connects to the stream url returned from a StartSession/ExecuteCommand call
+// and opens the data channel using the session token
+func StartSession(ctx context.Context, streamUrl, tokenValue string) (*Session, error) {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, streamUrl, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to session stream: %w", err)
+	}
+
+	open := openDataChannelInput{
+		MessageSchemaVersion: "1.0",
+		RequestId:            uuid.NewString(),
+		TokenValue:           tokenValue,
+		ClientId:             uuid.NewString(),
+		ClientVersion:        ClientVersion,
+	}
+	raw, err := json.Marshal(open)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err := conn.WriteMessage(websocket.TextMessage, raw); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("error opening session data channel: %w", err)
+	}
+	return &Session{conn: conn}, nil
+}
+
+func (s *Session) Close() error {
+	return s.conn.Close()
+}
+
+// Run proxies stdio over the session until the agent closes the channel or ctx is cancelled
+func (s *Session) Run(ctx context.Context, sio SessionIO) (*SessionResult, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// Closing the connection is the only way to unblock a pending read
+	go func() {
+		<-ctx.Done()
+		s.conn.Close()
+	}()
+
+	r := &sessionRunner{
+		session: s,
+		io:      sio,
+		pending: map[int64]ClientMessage{},
+		result:  &SessionResult{},
+		cancel:  cancel,
+	}
+	if r.io.Stderr == nil {
+		r.io.Stderr = r.io.Stdout
+	}
+	go s.keepAlive(ctx)
+
+	for {
+		msgType, data, err := s.conn.ReadMessage()
+		if err != nil {
+			if pumpErr := r.pumpError(); pumpErr != nil {
+				return r.result, pumpErr
+			}
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return r.result, ctxErr
+			}
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+				return r.result, nil
+			}
+			return r.result, fmt.Errorf("error reading from session: %w", err)
+		}
+		if msgType != websocket.BinaryMessage {
+			continue
+		}
+
+		var msg ClientMessage
+		if err := msg.UnmarshalBinary(data); err != nil {
+			return r.result, err
+		}
+		done, err := r.handle(ctx, msg)
+		if err != nil || done {
+			return r.result, err
+		}
+	}
+}
+
+func (s *Session) keepAlive(ctx context.Context) {
+	ticker := time.NewTicker(keepAliveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.writeMu.Lock()
+			err := s.conn.WriteControl(websocket.PingMessage, []byte("keepalive"), time.Now().Add(10*time.Second))
+			s.writeMu.Unlock()
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (s *Session) writeMessage(msg ClientMessage) error {
+	raw, err := msg.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	return s.conn.WriteMessage(websocket.BinaryMessage, raw)
+}
+
+// sendInput sends an input_stream_data message, assigning the next input sequence number
+func (s *Session) sendInput(payloadType PayloadType, payload []byte) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	msg := ClientMessage{
+		MessageType:    MessageTypeInputStreamData,
+		SchemaVersion:  1,
+		CreatedDate:    time.Now(),
+		SequenceNumber: s.inSeq,
+		MessageId:      uuid.New(),
+		PayloadType:    payloadType,
+		Payload:        payload,
+	}
+	raw, err := msg.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	if err := s.conn.WriteMessage(websocket.BinaryMessage, raw); err != nil {
+		return fmt.Errorf("error sending input to session: %w", err)
+	}
+	s.inSeq++
+	return nil
+}
+
+type acknowledgeContent struct {
+	AcknowledgedMessageType           string `json:"AcknowledgedMessageType"`
+	AcknowledgedMessageId             string `json:"AcknowledgedMessageId"`
+	AcknowledgedMessageSequenceNumber int64  `json:"AcknowledgedMessageSequenceNumber"`
+	IsSequentialMessage               bool   `json:"IsSequentialMessage"`
+}
+
+func (s *Session) acknowledge(msg ClientMessage) error {
+	raw, err := json.Marshal(acknowledgeContent{
+		AcknowledgedMessageType:           msg.MessageType,
+		AcknowledgedMessageId:             msg.MessageId.String(),
+		AcknowledgedMessageSequenceNumber: msg.SequenceNumber,
+		IsSequentialMessage:               true,
+	})
+	if err != nil {
+		return err
+	}
+	return s.writeMessage(ClientMessage{
+		MessageType:   MessageTypeAcknowledge,
+		SchemaVersion: 1,
+		CreatedDate:   time.Now(),
+		Flags:         3,
+		MessageId:     uuid.New(),
+		Payload:       raw,
+	})
+}
+
+type handshakeRequestPayload struct {
+	AgentVersion           string                  `json:"AgentVersion"`
+	RequestedClientActions []requestedClientAction `json:"RequestedClientActions"`
+}
+
+type requestedClientAction struct {
+	ActionType       string          `json:"ActionType"`
+	ActionParameters json.RawMessage `json:"ActionParameters"`
+}
+
+type handshakeResponsePayload struct {
+	ClientVersion          string                  `json:"ClientVersion"`
+	ProcessedClientActions []processedClientAction `json:"ProcessedClientActions"`
+	Errors                 []string                `json:"Errors"`
+}
+
+type processedClientAction struct {
+	ActionType   string `json:"ActionType"`
+	ActionStatus int    `json:"ActionStatus"`
+	Error        string `json:"Error,omitempty"`
+}
+
+type channelClosedPayload struct {
+	MessageId     string `json:"MessageId"`
+	CreatedDate   string `json:"CreatedDate"`
+	DestinationId string `json:"DestinationId"`
+	SessionId     string `json:"SessionId"`
+	MessageType   string `json:"MessageType"`
+	SchemaVersion int    `json:"SchemaVersion"`
+	Output        string `json:"Output"`
+}
+
+type sessionRunner struct {
+	session *Session
+	io      SessionIO
+	result  *SessionResult
+	cancel  context.CancelFunc
+
+	// outSeq is the next output sequence number we expect from the agent
+	// Messages that arrive ahead of it are buffered in pending until the gap is filled
+	outSeq  int64
+	pending map[int64]ClientMessage
+
+	started bool
+	errMu   sync.Mutex
+	errPump error
+}
+
+func (r *sessionRunner) pumpError() error {
+	r.errMu.Lock()
+	defer r.errMu.Unlock()
+	return r.errPump
+}
+
+func (r *sessionRunner) failPump(err error) {
+	r.errMu.Lock()
+	if r.errPump == nil {
+		r.errPump = err
+	}
+	r.errMu.Unlock()
+	r.cancel()
+}
+
+func (r *sessionRunner) handle(ctx context.Context, msg ClientMessage) (bool, error) {
+	switch msg.MessageType {
+	case MessageTypeOutputStreamData:
+		if err := r.session.acknowledge(msg); err != nil {
+			return false, fmt.Errorf("error acknowledging session message: %w", err)
+		}
+		if msg.SequenceNumber < r.outSeq {
+			// The agent resent a message we already processed
+			return false, nil
+		}
+		r.pending[msg.SequenceNumber] = msg
+		for {
+			next, ok := r.pending[r.outSeq]
+			if !ok {
+				return false, nil
+			}
+			delete(r.pending, r.outSeq)
+			r.outSeq++
+			if err := r.handleOutput(ctx, next); err != nil {
+				return false, err
+			}
+		}
+	case MessageTypeChannelClosed:
+		var payload channelClosedPayload
+		if err := json.Unmarshal(msg.Payload, &payload); err == nil {
+			r.result.CloseReason = payload.Output
+		}
+		return true, nil
+	default:
+		// acknowledge, start_publication, pause_publication require no action from this client
+		return false, nil
+	}
+}
+
+func (r *sessionRunner) handleOutput(ctx context.Context, msg ClientMessage) error {
+	switch msg.PayloadType {
+	case PayloadTypeHandshakeRequest:
+		return r.respondToHandshake(msg.Payload)
+	case PayloadTypeHandshakeComplete:
+		return r.start(ctx)
+	case PayloadTypeOutput:
+		// Older agents skip the handshake and start streaming output right away
+		if err := r.start(ctx); err != nil {
+			return err
+		}
+		return r.write(r.io.Stdout, msg.Payload)
+	case PayloadTypeStdErr, PayloadTypeError:
+		return r.write(r.io.Stderr, msg.Payload)
+	case PayloadTypeExitCode:
+		if code, err := strconv.Atoi(strings.TrimSpace(string(msg.Payload))); err == nil {
+			r.result.ExitCode = &code
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+func (r *sessionRunner) write(w io.Writer, payload []byte) error {
+	if w == nil {
+		return nil
+	}
+	_, err := w.Write(payload)
+	return err
+}
+
+func (r *sessionRunner) respondToHandshake(payload []byte) error {
+	var req handshakeRequestPayload
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return fmt.Errorf("invalid handshake request from agent: %w", err)
+	}
+
+	var handshakeErr error
+	res := handshakeResponsePayload{
+		ClientVersion:          ClientVersion,
+		ProcessedClientActions: make([]processedClientAction, 0),
+		Errors:                 make([]string, 0),
+	}
+	for _, action := range req.RequestedClientActions {
+		processed := processedClientAction{ActionType: action.ActionType}
+		switch action.ActionType {
+		case actionTypeSessionType:
+			processed.ActionStatus = actionStatusSuccess
+		case actionTypeKMSEncryption:
+			processed.ActionStatus = actionStatusFailed
+			processed.Error = ErrKMSEncryptionUnsupported.Error()
+			res.Errors = append(res.Errors, processed.Error)
+			handshakeErr = ErrKMSEncryptionUnsupported
+		default:
+			processed.ActionStatus = actionStatusUnsupported
+			processed.Error = fmt.Sprintf("unsupported action %q", action.ActionType)
+		}
+		res.ProcessedClientActions = append(res.ProcessedClientActions, processed)
+	}
+
+	raw, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	if err := r.session.sendInput(PayloadTypeHandshakeResponse, raw); err != nil {
+		return err
+	}
+	return handshakeErr
+}
+
+// start sends the initial terminal size and begins forwarding stdin and resize events
+// This runs once, after the handshake completes (or the first output arrives for agents without a handshake)
+func (r *sessionRunner) start(ctx context.Context) error {
+	if r.started {
+		return nil
+	}
+	r.started = true
+
+	if r.io.TerminalSize != nil {
+		if err := r.sendSize(*r.io.TerminalSize); err != nil {
+			return err
+		}
+	}
+	if r.io.Resize != nil {
+		go r.pumpResize(ctx)
+	}
+	if r.io.Stdin != nil {
+		go r.pumpStdin(ctx)
+	}
+	return nil
+}
+
+func (r *sessionRunner) sendSize(size TerminalSize) error {
+	raw, err := json.Marshal(size)
+	if err != nil {
+		return err
+	}
+	return r.session.sendInput(PayloadTypeSize, raw)
+}
+
+func (r *sessionRunner) pumpResize(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case size, ok := <-r.io.Resize:
+			if !ok {
+				return
+			}
+			if err := r.sendSize(size); err != nil {
+				r.failPump(err)
+				return
+			}
+		}
+	}
+}
+
+func (r *sessionRunner) pumpStdin(ctx context.Context) {
+	buf := make([]byte, stdinBufferSize)
+	for {
+		n, err := r.io.Stdin.Read(buf)
+		if n > 0 {
+			if ctx.Err() != nil {
+				return
+			}
+			if sendErr := r.session.sendInput(PayloadTypeOutput, append([]byte{}, buf[:n]...)); sendErr != nil {
+				r.failPump(sendErr)
+				return
+			}
+		}
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				r.failPump(fmt.Errorf("error reading stdin: %w", err))
+			}
+			return
+		}
+	}
+}
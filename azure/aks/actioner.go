@@ -0,0 +1,29 @@
+package aks
+
+import (
+	"context"
+
+	"github.com/nullstone-io/deployment-sdk/k8s"
+	"github.com/nullstone-io/deployment-sdk/outputs"
+	"github.com/nullstone-io/deployment-sdk/workspace"
+	"gopkg.in/nullstone-io/go-api-client.v0/types"
+	"k8s.io/client-go/rest"
+)
+
+func NewActioner(ctx context.Context, source outputs.RetrieverSource, blockDetails workspace.Details) (workspace.Actioner, error) {
+	outs, err := outputs.Retrieve[Outputs](ctx, source, blockDetails.Workspace, blockDetails.WorkspaceConfig)
+	if err != nil {
+		return nil, err
+	}
+	outs.Deployer.InitializeCreds(source, blockDetails.Workspace, types.AutomationPurposePerformAction, "deployer")
+
+	return k8s.Actioner{
+		Namespace:         outs.ServiceNamespace,
+		AppName:           blockDetails.Block.Name,
+		MaxReplicas:       outs.MaxScale,
+		MainContainerName: outs.MainContainerName,
+		NewConfigFn: func(ctx context.Context) (*rest.Config, error) {
+			return CreateKubeConfig(ctx, outs.ClusterNamespace, outs.Deployer)
+		},
+	}, nil
+}
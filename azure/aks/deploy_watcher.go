@@ -16,12 +16,18 @@ func NewDeployWatcher(ctx context.Context, osWriters logging.OsWriters, source o
 		return nil, err
 	}
 	outs.InitializeCreds(source, appDetails.Workspace)
+	workloadKind, err := k8s.ParseWorkloadKind(outs.WorkloadKind)
+	if err != nil {
+		return nil, err
+	}
 
 	return &k8s.DeployWatcher{
-		OsWriters:    osWriters,
-		Details:      appDetails,
-		AppNamespace: outs.ServiceNamespace,
-		AppName:      outs.ServiceName,
+		OsWriters:       osWriters,
+		Details:         appDetails,
+		AppNamespace:    outs.ServiceNamespace,
+		WorkloadKind:    workloadKind,
+		AppName:         outs.ServiceName,
+		SkipRouteChecks: outs.SkipRouteChecks,
 		NewConfigFn: func(ctx context.Context) (*rest.Config, error) {
 			return CreateKubeConfig(ctx, outs.ClusterNamespace, outs.Deployer)
 		},
@@ -50,6 +50,7 @@ func (d Deployer) Deploy(ctx context.Context, meta app.DeployMetadata) (string,
 		MainContainerName: d.Infra.MainContainerName,
 		ServiceName:       d.Infra.ServiceName,
 		JobDefinitionName: d.Infra.JobDefinitionName,
+		SkipPreflight:     d.Infra.SkipPreflightChecks,
 		OsWriters:         d.OsWriters,
 	}
 	if valid, err := deployer.Validate(meta); !valid {
@@ -23,6 +23,14 @@ type Outputs struct {
 	MainContainerName string          `ns:"main_container_name,optional"`
 	ImageRepoUrl      docker.ImageUrl `ns:"image_repo_url,optional"`
 	Deployer          azure.Principal `ns:"deployer"`
+	// WorkloadKind is the kind of object named ServiceName (Deployment, StatefulSet, DaemonSet, or Rollout)
+	WorkloadKind string `ns:"workload_kind,optional"`
+	// MaxScale limits how many replicas the scale/resume actions may request (0 = no limit)
+	MaxScale int32 `ns:"max_scale,optional"`
+	// SkipRouteChecks completes deployments once the workload rolls out without waiting for Ingresses and HTTPRoutes
+	SkipRouteChecks bool `ns:"skip_route_checks,optional"`
+	// SkipPreflightChecks deploys without verifying the ConfigMaps, Secrets and other objects the pod template references
+	SkipPreflightChecks bool `ns:"skip_preflight_checks,optional"`
 
 	ClusterNamespace ClusterNamespaceOutputs `ns:",connectionContract:cluster-namespace/azure/k8s:aks"`
 }
@@ -32,6 +40,8 @@ func (o *Outputs) InitializeCreds(source outputs.RetrieverSource, ws *types.Work
 }
 
 type ClusterNamespaceOutputs struct {
+	Location             string `ns:"location,optional"`
+	ClusterName          string `ns:"cluster_name,optional"`
 	ClusterEndpoint      string `ns:"cluster_endpoint"`
 	ClusterCACertificate string `ns:"cluster_ca_certificate"`
 }
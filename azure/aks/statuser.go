@@ -0,0 +1,38 @@
+package aks
+
+import (
+	"context"
+
+	"github.com/nullstone-io/deployment-sdk/app"
+	"github.com/nullstone-io/deployment-sdk/k8s"
+	"github.com/nullstone-io/deployment-sdk/logging"
+	"github.com/nullstone-io/deployment-sdk/outputs"
+	"k8s.io/client-go/rest"
+)
+
+func NewStatuser(ctx context.Context, osWriters logging.OsWriters, source outputs.RetrieverSource, appDetails app.Details) (app.Statuser, error) {
+	outs, err := outputs.Retrieve[Outputs](ctx, source, appDetails.Workspace, appDetails.WorkspaceConfig)
+	if err != nil {
+		return nil, err
+	}
+	outs.InitializeCreds(source, appDetails.Workspace)
+	workloadKind, err := k8s.ParseWorkloadKind(outs.WorkloadKind)
+	if err != nil {
+		return nil, err
+	}
+
+	return &k8s.Statuser{
+		OsWriters: osWriters,
+		Details:   appDetails,
+		Cluster: k8s.ClusterInfo{
+			Region:      outs.ClusterNamespace.Location,
+			ClusterName: outs.ClusterNamespace.ClusterName,
+		},
+		AppNamespace: outs.ServiceNamespace,
+		WorkloadKind: workloadKind,
+		AppName:      appDetails.App.Name,
+		NewConfigFn: func(ctx context.Context) (*rest.Config, error) {
+			return CreateKubeConfig(ctx, outs.ClusterNamespace, outs.Deployer)
+		},
+	}, nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/monitor/azquery"
@@ -51,6 +52,19 @@ func (s LogStreamer) Stream(ctx context.Context, options app.LogStreamOptions) e
 	logger.Println(options.QueryTimeMessage())
 	logger.Println(options.WatchMessage())
 
+	// Text conditions are translated into KQL; severity and fields are filtered client-side
+	emitter, err := options.Query.FilterEmitter(options.Emitter)
+	if err != nil {
+		return err
+	}
+	options.Emitter = emitter
+	if !options.Query.IsEmpty() {
+		logger.Printf("Filtering logs: %s\n", options.Query)
+		if len(options.Query.ResourceLabels) > 0 {
+			logger.Println("Resource label filters are not supported by Azure Monitor and will be ignored")
+		}
+	}
+
 	client, err := azquery.NewLogsClient(&s.Infra.LogReader, nil)
 	if err != nil {
 		return fmt.Errorf("error creating Log Analytics client: %w", err)
@@ -84,7 +98,7 @@ func (s LogStreamer) writeLatestEventsFunc(client *azquery.LogsClient, options a
 	}
 
 	return func(ctx context.Context) error {
-		query := s.buildKQLQuery(lastEventTime, options.EndTime)
+		query := s.buildKQLQuery(lastEventTime, options.EndTime, options.Query)
 		timespan := buildTimespan(lastEventTime, options.EndTime)
 
 		body := azquery.Body{
@@ -149,7 +163,7 @@ func (s LogStreamer) writeLatestEventsFunc(client *azquery.LogsClient, options a
 	}
 }
 
-func (s LogStreamer) buildKQLQuery(startTime *time.Time, endTime *time.Time) string {
+func (s LogStreamer) buildKQLQuery(startTime *time.Time, endTime *time.Time, logQuery *app.LogQuery) string {
 	// Use the log_filter output if available, otherwise default to ContainerAppConsoleLogs_CL
 	baseQuery := s.Infra.LogFilter
 	if baseQuery == "" {
@@ -163,10 +177,35 @@ func (s LogStreamer) buildKQLQuery(startTime *time.Time, endTime *time.Time) str
 	if endTime != nil {
 		query += fmt.Sprintf(" | where TimeGenerated <= datetime(%s)", endTime.UTC().Format(time.RFC3339))
 	}
+	query += kqlQueryClauses(logQuery)
 	query += " | order by TimeGenerated asc"
 	return query
 }
 
+// kqlMessageColumn resolves the log message from whichever column the table has (see writeLatestEventsFunc)
+const kqlMessageColumn = `tostring(column_ifexists("Log_s", column_ifexists("Message", "")))`
+
+// kqlQueryClauses translates the text conditions of a LogQuery into KQL where clauses
+// KQL regular expressions use RE2 syntax, so Regex has the same semantics server-side as client-side
+func kqlQueryClauses(logQuery *app.LogQuery) string {
+	if logQuery.IsEmpty() {
+		return ""
+	}
+	clauses := ""
+	if logQuery.Contains != "" {
+		clauses += fmt.Sprintf(" | where %s contains_cs %s", kqlMessageColumn, kqlString(logQuery.Contains))
+	}
+	if logQuery.Regex != "" {
+		clauses += fmt.Sprintf(" | where %s matches regex %s", kqlMessageColumn, kqlString(logQuery.Regex))
+	}
+	return clauses
+}
+
+// kqlString renders s as a verbatim KQL string literal, where a double quote is escaped by doubling it
+func kqlString(s string) string {
+	return `@"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
 func buildTimespan(startTime *time.Time, endTime *time.Time) *azquery.TimeInterval {
 	if startTime == nil && endTime == nil {
 		ts := azquery.TimeInterval("PT1H")
@@ -0,0 +1,31 @@
+package azuremonitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nullstone-io/deployment-sdk/app"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogStreamer_BuildKQLQuery(t *testing.T) {
+	start := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	s := LogStreamer{}
+
+	assert.Equal(t,
+		`ContainerAppConsoleLogs_CL | where TimeGenerated >= datetime(2025-03-01T12:00:00Z) | order by TimeGenerated asc`,
+		s.buildKQLQuery(&start, nil, nil))
+
+	query := &app.LogQuery{
+		MinSeverity: app.LogSeverityError,
+		Contains:    `say "hi"`,
+		Regex:       `status=5\d\d`,
+	}
+	s.Infra.LogFilter = `AppTraces | where AppRoleName == "api"`
+	assert.Equal(t,
+		`AppTraces | where AppRoleName == "api"`+
+			` | where tostring(column_ifexists("Log_s", column_ifexists("Message", ""))) contains_cs @"say ""hi"""`+
+			` | where tostring(column_ifexists("Log_s", column_ifexists("Message", ""))) matches regex @"status=5\d\d"`+
+			` | order by TimeGenerated asc`,
+		s.buildKQLQuery(nil, nil, query))
+}
@@ -0,0 +1,78 @@
+package blob
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cdn/armcdn"
+	"github.com/nullstone-io/deployment-sdk/app"
+	"github.com/nullstone-io/deployment-sdk/logging"
+	"github.com/nullstone-io/deployment-sdk/outputs"
+	"github.com/nullstone-io/deployment-sdk/staticsite"
+)
+
+func NewStatuser(ctx context.Context, osWriters logging.OsWriters, source outputs.RetrieverSource, appDetails app.Details) (app.Statuser, error) {
+	outs, err := outputs.Retrieve[Outputs](ctx, source, appDetails.Workspace, appDetails.WorkspaceConfig)
+	if err != nil {
+		return nil, err
+	}
+	outs.InitializeCreds(source, appDetails.Workspace)
+
+	return staticsite.Statuser{
+		OsWriters: osWriters,
+		Details:   appDetails,
+		GetCdnStatusesFn: func(ctx context.Context) ([]staticsite.CdnStatus, error) {
+			return GetCdnStatuses(ctx, outs)
+		},
+		ListArtifactVersionsFn: DirPusher{OsWriters: osWriters, Infra: outs, AppDetails: appDetails}.ListArtifactVersions,
+	}, nil
+}
+
+// GetCdnStatuses reports the version served by the CDN endpoint
+// CDN purges are awaited by the deployer (see BlobDeployer.Deploy), so there are never pending invalidations to report
+func GetCdnStatuses(ctx context.Context, infra Outputs) ([]staticsite.CdnStatus, error) {
+	result := make([]staticsite.CdnStatus, 0)
+	if infra.CdnProfileName == "" || infra.CdnEndpointName == "" {
+		return result, nil
+	}
+
+	cdnClient, err := armcdn.NewEndpointsClient(infra.SubscriptionId, &infra.Deployer, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating CDN client: %w", err)
+	}
+	res, err := cdnClient.Get(ctx, infra.ResourceGroup, infra.CdnProfileName, infra.CdnEndpointName, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting CDN endpoint %s: %w", infra.CdnEndpointName, err)
+	}
+	return append(result, mapEndpointStatus(res.Endpoint, infra.ArtifactsKeyTemplate)), nil
+}
+
+func mapEndpointStatus(endpoint armcdn.Endpoint, keyTemplate string) staticsite.CdnStatus {
+	status := staticsite.CdnStatus{
+		Domains:              make([]string, 0),
+		PendingInvalidations: make([]staticsite.Invalidation, 0),
+	}
+	if endpoint.Name != nil {
+		status.Id = *endpoint.Name
+	}
+	props := endpoint.Properties
+	if props == nil {
+		return status
+	}
+	if props.ResourceState != nil {
+		status.State = string(*props.ResourceState)
+	}
+	for _, domain := range props.CustomDomains {
+		if domain != nil && domain.Properties != nil && domain.Properties.HostName != nil {
+			status.Domains = append(status.Domains, *domain.Properties.HostName)
+		}
+	}
+	if props.HostName != nil {
+		status.Domains = append(status.Domains, *props.HostName)
+	}
+	// The endpoint only reveals the served version when its origin path points at a version's artifacts directory
+	if props.OriginPath != nil {
+		status.AppVersion = staticsite.AppVersionFromPath(keyTemplate, *props.OriginPath)
+	}
+	return status
+}
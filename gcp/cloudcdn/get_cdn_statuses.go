@@ -0,0 +1,121 @@
+package cloudcdn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	compute "cloud.google.com/go/compute/apiv1"
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/nullstone-io/deployment-sdk/staticsite"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+)
+
+// pendingInvalidationsFilter selects cache invalidations that have not completed
+// Cloud CDN runs each invalidation as a global compute operation targeting the url map
+const pendingInvalidationsFilter = `(operationType = "invalidateCache") AND (status != "DONE")`
+
+// GetCdnStatuses reports the version served by each url map and its pending cache invalidations
+func GetCdnStatuses(ctx context.Context, infra Outputs) ([]staticsite.CdnStatus, error) {
+	result := make([]staticsite.CdnStatus, 0)
+	if len(infra.CdnUrlMapNames) < 1 {
+		return result, nil
+	}
+
+	tokenSource, err := infra.Deployer.TokenSource(ctx, CdnScopes...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating token source from service account: %w", err)
+	}
+	client, err := compute.NewUrlMapsRESTClient(ctx, option.WithTokenSource(tokenSource))
+	if err != nil {
+		return nil, fmt.Errorf("error creating google compute client: %w", err)
+	}
+	defer client.Close()
+	opsClient, err := compute.NewGlobalOperationsRESTClient(ctx, option.WithTokenSource(tokenSource))
+	if err != nil {
+		return nil, fmt.Errorf("error creating google compute operations client: %w", err)
+	}
+	defer opsClient.Close()
+
+	urlMaps, err := GetUrlMaps(ctx, infra, client)
+	if err != nil {
+		return nil, err
+	}
+	ops, err := listPendingInvalidations(ctx, infra, opsClient)
+	if err != nil {
+		return nil, err
+	}
+	for _, urlMap := range urlMaps {
+		result = append(result, mapUrlMapStatus(urlMap, ops))
+	}
+	return result, nil
+}
+
+func listPendingInvalidations(ctx context.Context, infra Outputs, client *compute.GlobalOperationsClient) ([]*computepb.Operation, error) {
+	ops := make([]*computepb.Operation, 0)
+	filter := pendingInvalidationsFilter
+	it := client.List(ctx, &computepb.ListGlobalOperationsRequest{
+		Project: infra.ProjectId,
+		Filter:  &filter,
+	})
+	for {
+		op, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			return ops, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("error listing cache invalidations: %w", err)
+		}
+		ops = append(ops, op)
+	}
+}
+
+func mapUrlMapStatus(urlMap *computepb.UrlMap, pendingOps []*computepb.Operation) staticsite.CdnStatus {
+	status := staticsite.CdnStatus{
+		Id:                   urlMap.GetName(),
+		Domains:              make([]string, 0),
+		AppVersion:           servedVersion(urlMap),
+		PendingInvalidations: make([]staticsite.Invalidation, 0),
+	}
+	for _, hostRule := range urlMap.HostRules {
+		for _, host := range hostRule.Hosts {
+			if host != "*" {
+				status.Domains = append(status.Domains, host)
+			}
+		}
+	}
+	for _, op := range pendingOps {
+		if !targetsUrlMap(op.GetTargetLink(), urlMap.GetName()) {
+			continue
+		}
+		inv := staticsite.Invalidation{Id: op.GetName(), Status: op.GetStatus().String()}
+		if t, err := time.Parse(time.RFC3339, op.GetInsertTime()); err == nil {
+			inv.CreatedAt = &t
+		}
+		status.PendingInvalidations = append(status.PendingInvalidations, inv)
+	}
+	return status
+}
+
+// servedVersion reads the X-Nullstone-Version request header that UpdateCdnVersion maintains on the url map's path matchers
+func servedVersion(urlMap *computepb.UrlMap) string {
+	for _, pathMatcher := range urlMap.PathMatchers {
+		if pathMatcher.HeaderAction == nil {
+			continue
+		}
+		for _, header := range pathMatcher.HeaderAction.RequestHeadersToAdd {
+			if header.GetHeaderName() == appVersionHeaderName {
+				return header.GetHeaderValue()
+			}
+		}
+	}
+	return ""
+}
+
+// targetsUrlMap checks whether an operation's target link (e.g. https://www.googleapis.com/compute/v1/projects/acme/global/urlMaps/site)
+// refers to the url map with the given name
+func targetsUrlMap(targetLink, urlMapName string) bool {
+	return strings.HasSuffix(targetLink, "/urlMaps/"+urlMapName)
+}
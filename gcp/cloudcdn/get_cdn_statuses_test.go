@@ -0,0 +1,71 @@
+package cloudcdn
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/nullstone-io/deployment-sdk/staticsite"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapUrlMapStatus(t *testing.T) {
+	str := func(s string) *string { return &s }
+	opStatus := func(s computepb.Operation_Status) *computepb.Operation_Status { return &s }
+
+	urlMap := &computepb.UrlMap{
+		Name: str("site"),
+		HostRules: []*computepb.HostRule{
+			{Hosts: []string{"example.com", "www.example.com"}, PathMatcher: str("main")},
+			{Hosts: []string{"*"}, PathMatcher: str("main")},
+		},
+		PathMatchers: []*computepb.PathMatcher{
+			{Name: str("assets")},
+			{
+				Name: str("main"),
+				HeaderAction: &computepb.HttpHeaderAction{
+					RequestHeadersToAdd: []*computepb.HttpHeaderOption{
+						{HeaderName: str("X-Custom"), HeaderValue: str("x")},
+						{HeaderName: str("X-Nullstone-Version"), HeaderValue: str("v1.4.0")},
+					},
+				},
+			},
+		},
+	}
+	ops := []*computepb.Operation{
+		{
+			Name:       str("operation-1"),
+			Status:     opStatus(computepb.Operation_RUNNING),
+			TargetLink: str("https://www.googleapis.com/compute/v1/projects/acme/global/urlMaps/site"),
+			InsertTime: str("2025-03-01T12:00:00-08:00"),
+		},
+		{
+			Name:       str("operation-2"),
+			Status:     opStatus(computepb.Operation_PENDING),
+			TargetLink: str("https://www.googleapis.com/compute/v1/projects/acme/global/urlMaps/site-admin"),
+		},
+	}
+
+	createdAt := time.Date(2025, 3, 1, 20, 0, 0, 0, time.UTC)
+	got := mapUrlMapStatus(urlMap, ops)
+	assert.Equal(t, "site", got.Id)
+	assert.Equal(t, "v1.4.0", got.AppVersion)
+	assert.Equal(t, []string{"example.com", "www.example.com"}, got.Domains)
+	if assert.Len(t, got.PendingInvalidations, 1) {
+		inv := got.PendingInvalidations[0]
+		assert.Equal(t, "operation-1", inv.Id)
+		assert.Equal(t, "RUNNING", inv.Status)
+		if assert.NotNil(t, inv.CreatedAt) {
+			assert.True(t, createdAt.Equal(*inv.CreatedAt))
+		}
+	}
+
+	noVersion := mapUrlMapStatus(&computepb.UrlMap{Name: str("bare")}, nil)
+	assert.Equal(t, staticsite.CdnStatus{Id: "bare", Domains: []string{}, PendingInvalidations: []staticsite.Invalidation{}}, noVersion)
+}
+
+func TestRequestLogFilter(t *testing.T) {
+	assert.Equal(t,
+		`resource.type="http_load_balancer" AND resource.labels.url_map_name=("site" OR "site-admin")`,
+		RequestLogFilter([]string{"site", "site-admin"}))
+}
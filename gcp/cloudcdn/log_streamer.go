@@ -0,0 +1,64 @@
+package cloudcdn
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nullstone-io/deployment-sdk/app"
+	"github.com/nullstone-io/deployment-sdk/gcp"
+	"github.com/nullstone-io/deployment-sdk/gcp/cloudlogging"
+	"github.com/nullstone-io/deployment-sdk/gcp/creds"
+	"github.com/nullstone-io/deployment-sdk/logging"
+	"github.com/nullstone-io/deployment-sdk/outputs"
+	"gopkg.in/nullstone-io/go-api-client.v0/types"
+)
+
+type LogOutputs struct {
+	ProjectId      string             `ns:"project_id"`
+	CdnUrlMapNames []string           `ns:"cdn_url_map_names,optional"`
+	LogReader      gcp.ServiceAccount `ns:"log_reader"`
+	// LogFilter overrides the filter built from CdnUrlMapNames
+	LogFilter string `ns:"log_filter,optional"`
+}
+
+func (o *LogOutputs) InitializeCreds(source outputs.RetrieverSource, ws *types.Workspace) {
+	o.LogReader.RemoteTokenSourcer = creds.NewTokenSourcer(source, ws.StackId, ws.BlockId, ws.EnvId, types.AutomationPurposeViewLogs, "log_reader")
+}
+
+// NewLogStreamer streams the request logs that the HTTP(S) load balancer (and Cloud CDN) writes to Cloud Logging for the app's url maps
+func NewLogStreamer(ctx context.Context, osWriters logging.OsWriters, source outputs.RetrieverSource, appDetails app.Details) (app.LogStreamer, error) {
+	outs, err := outputs.Retrieve[LogOutputs](ctx, source, appDetails.Workspace, appDetails.WorkspaceConfig)
+	if err != nil {
+		return nil, err
+	}
+	outs.InitializeCreds(source, appDetails.Workspace)
+
+	filter := outs.LogFilter
+	if filter == "" {
+		if len(outs.CdnUrlMapNames) < 1 {
+			return nil, fmt.Errorf("cannot stream logs: the app has no attached CDNs and no log_filter")
+		}
+		filter = RequestLogFilter(outs.CdnUrlMapNames)
+	}
+
+	return cloudlogging.LogStreamer{
+		OsWriters: osWriters,
+		Details:   appDetails,
+		Infra: cloudlogging.Outputs{
+			ProjectId: outs.ProjectId,
+			LogFilter: filter,
+			LogReader: outs.LogReader,
+		},
+	}, nil
+}
+
+// RequestLogFilter builds a Cloud Logging filter that matches load balancer request logs for the url maps
+// e.g. resource.type="http_load_balancer" AND resource.labels.url_map_name=("site-a" OR "site-b")
+func RequestLogFilter(urlMapNames []string) string {
+	quoted := make([]string, 0, len(urlMapNames))
+	for _, name := range urlMapNames {
+		quoted = append(quoted, fmt.Sprintf("%q", name))
+	}
+	return fmt.Sprintf(`resource.type="http_load_balancer" AND resource.labels.url_map_name=(%s)`, strings.Join(quoted, " OR "))
+}
@@ -0,0 +1,257 @@
+package cloudfunctions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	v1pb "cloud.google.com/go/functions/apiv1/functionspb"
+	"cloud.google.com/go/functions/apiv2/functionspb"
+	"cloud.google.com/go/storage"
+	"github.com/nullstone-io/deployment-sdk/gcp/cloudrun"
+	"github.com/nullstone-io/deployment-sdk/gcp/creds"
+	"github.com/nullstone-io/deployment-sdk/outputs"
+	"github.com/nullstone-io/deployment-sdk/workspace"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"gopkg.in/nullstone-io/go-api-client.v0/types"
+)
+
+const (
+	ActionRollback     = "rollback"
+	ActionRouteTraffic = "route-traffic"
+)
+
+type RollbackInput struct {
+	// Version is the app version to roll back to; its source object is resolved with the artifacts key template
+	Version string `json:"version,omitempty"`
+	// SourceObject is the key of the source archive in the artifacts bucket, used when Version is empty
+	SourceObject string `json:"sourceObject,omitempty"`
+}
+
+type RollbackResult struct {
+	Function     string `json:"function"`
+	Environment  string `json:"environment"`
+	SourceObject string `json:"sourceObject"`
+	Version      string `json:"version,omitempty"`
+	Operation    string `json:"operation,omitempty"`
+}
+
+type RouteTrafficInput struct {
+	RevisionName string `json:"revisionName"`
+	Percent      int32  `json:"percent"`
+}
+
+type RouteTrafficResult struct {
+	Function     string `json:"function"`
+	Service      string `json:"service"`
+	RevisionName string `json:"revisionName"`
+	Percent      int32  `json:"percent"`
+}
+
+func NewActioner(ctx context.Context, source outputs.RetrieverSource, blockDetails workspace.Details) (workspace.Actioner, error) {
+	outs, err := outputs.Retrieve[Outputs](ctx, source, blockDetails.Workspace, blockDetails.WorkspaceConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	ws := blockDetails.Workspace
+	outs.Deployer.RemoteTokenSourcer = creds.NewTokenSourcer(source, ws.StackId, ws.BlockId, ws.EnvId, types.AutomationPurposePerformAction, "deployer")
+
+	return Actioner{
+		Infra:   outs,
+		AppName: blockDetails.Block.Name,
+	}, nil
+}
+
+type Actioner struct {
+	Infra   Outputs
+	AppName string
+}
+
+func (a Actioner) PerformAction(ctx context.Context, options workspace.ActionOptions) (*workspace.ActionResult, error) {
+	switch options.Action {
+	case ActionRollback:
+		return a.rollback(ctx, options.Input)
+	case ActionRouteTraffic:
+		return a.routeTraffic(ctx, options.Input)
+	default:
+		return nil, workspace.ActionNotSupportedError{
+			InnerErr: fmt.Errorf("unknown cloud functions action %q", options.Action),
+		}
+	}
+}
+
+// rollback rebuilds the function from a previous source archive in the artifacts bucket.
+// Only the source changes; environment variables, runtime, and entrypoint are left as they are.
+// The rebuild takes a few minutes, so this returns once the update has started.
+func (a Actioner) rollback(ctx context.Context, input json.RawMessage) (*workspace.ActionResult, error) {
+	var in RollbackInput
+	if len(input) > 0 {
+		if err := json.Unmarshal(input, &in); err != nil {
+			return nil, fmt.Errorf("invalid input for %s: %w", ActionRollback, err)
+		}
+	}
+	objectKey, version := in.SourceObject, in.Version
+	if version != "" {
+		objectKey = a.Infra.ArtifactsKey(version)
+	} else if objectKey != "" {
+		version = a.Infra.AppVersionFromKey(objectKey)
+	} else {
+		return nil, fmt.Errorf("%s requires version or sourceObject", ActionRollback)
+	}
+	if err := a.checkSourceObject(ctx, objectKey); err != nil {
+		return nil, err
+	}
+
+	client, err := NewFunctionClient(ctx, a.Infra.Deployer)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Cloud Functions client: %w", err)
+	}
+	defer client.Close()
+	fn, err := client.GetFunction(ctx, &functionspb.GetFunctionRequest{Name: a.Infra.FunctionName})
+	if err != nil {
+		return nil, fmt.Errorf("error getting Cloud Function: %w", err)
+	}
+
+	result := RollbackResult{
+		Function:     a.Infra.ShortFunctionName(),
+		SourceObject: objectKey,
+		Version:      version,
+	}
+	if fn.GetEnvironment() == functionspb.Environment_GEN_1 {
+		result.Environment = EnvironmentGen1
+		result.Operation, err = a.rollbackGen1(ctx, objectKey)
+	} else {
+		result.Environment = EnvironmentGen2
+		result.Operation, err = a.rollbackGen2(ctx, fn, objectKey)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error rolling back to %q: %w", objectKey, err)
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	return &workspace.ActionResult{
+		Status:  "started",
+		Message: fmt.Sprintf("rollback of function %q to %q started", result.Function, objectKey),
+		Data:    data,
+	}, nil
+}
+
+// checkSourceObject verifies the source archive exists before the function is updated
+// Otherwise, the update would only fail once the build starts
+func (a Actioner) checkSourceObject(ctx context.Context, objectKey string) error {
+	client, err := NewStorageClient(ctx, a.Infra.Deployer)
+	if err != nil {
+		return fmt.Errorf("error creating google storage client: %w", err)
+	}
+	defer client.Close()
+
+	_, err = client.Bucket(a.Infra.ArtifactsBucketName).Object(objectKey).Attrs(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return fmt.Errorf("source object %q does not exist in bucket %q", objectKey, a.Infra.ArtifactsBucketName)
+	} else if err != nil {
+		return fmt.Errorf("error retrieving source object %q: %w", objectKey, err)
+	}
+	return nil
+}
+
+func (a Actioner) rollbackGen1(ctx context.Context, objectKey string) (string, error) {
+	client, err := NewCloudFunctionsClient(ctx, a.Infra.Deployer)
+	if err != nil {
+		return "", fmt.Errorf("error creating Cloud Functions client: %w", err)
+	}
+	defer client.Close()
+
+	function, err := client.GetFunction(ctx, &v1pb.GetFunctionRequest{Name: a.Infra.FunctionName})
+	if err != nil {
+		return "", fmt.Errorf("error getting Cloud Function: %w", err)
+	}
+	SetSourceVersion(function, a.Infra.ArtifactsBucketName, objectKey)
+	op, err := client.UpdateFunction(ctx, &v1pb.UpdateFunctionRequest{
+		Function:   function,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"sourceArchiveUrl"}},
+	})
+	if err != nil {
+		return "", err
+	}
+	return op.Name(), nil
+}
+
+func (a Actioner) rollbackGen2(ctx context.Context, fn *functionspb.Function, objectKey string) (string, error) {
+	client, err := NewFunctionClient(ctx, a.Infra.Deployer)
+	if err != nil {
+		return "", fmt.Errorf("error creating Cloud Functions client: %w", err)
+	}
+	defer client.Close()
+
+	if fn.BuildConfig == nil {
+		fn.BuildConfig = &functionspb.BuildConfig{}
+	}
+	fn.BuildConfig.Source = &functionspb.Source{
+		Source: &functionspb.Source_StorageSource{
+			StorageSource: &functionspb.StorageSource{Bucket: a.Infra.ArtifactsBucketName, Object: objectKey},
+		},
+	}
+	op, err := client.UpdateFunction(ctx, &functionspb.UpdateFunctionRequest{
+		Function:   fn,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"build_config.source"}},
+	})
+	if err != nil {
+		return "", err
+	}
+	return op.Name(), nil
+}
+
+// routeTraffic points `percent` of a gen2 function's traffic at one of the revisions of its Cloud Run service.
+// When percent is less than 100 the remainder is routed to the latest ready revision.
+// The next deploy or rollback of the function sends all traffic to the latest revision again.
+func (a Actioner) routeTraffic(ctx context.Context, input json.RawMessage) (*workspace.ActionResult, error) {
+	var in RouteTrafficInput
+	if len(input) > 0 {
+		if err := json.Unmarshal(input, &in); err != nil {
+			return nil, fmt.Errorf("invalid input for %s: %w", ActionRouteTraffic, err)
+		}
+	}
+
+	client, err := NewFunctionClient(ctx, a.Infra.Deployer)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Cloud Functions client: %w", err)
+	}
+	defer client.Close()
+	fn, err := client.GetFunction(ctx, &functionspb.GetFunctionRequest{Name: a.Infra.FunctionName})
+	if err != nil {
+		return nil, fmt.Errorf("error getting Cloud Function: %w", err)
+	}
+	serviceId := fn.GetServiceConfig().GetService()
+	if fn.GetEnvironment() == functionspb.Environment_GEN_1 || serviceId == "" {
+		return nil, fmt.Errorf("%s requires a gen2 function", ActionRouteTraffic)
+	}
+
+	// A gen2 function is served by a Cloud Run service, so traffic is managed the same way as a Cloud Run service
+	runActioner := cloudrun.Actioner{
+		Infra:   cloudrun.Outputs{ServiceId: serviceId, Deployer: a.Infra.Deployer},
+		AppName: a.AppName,
+	}
+	if _, err := runActioner.PerformAction(ctx, workspace.ActionOptions{Action: cloudrun.ActionRouteTraffic, Input: input}); err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(RouteTrafficResult{
+		Function:     a.Infra.ShortFunctionName(),
+		Service:      shortName(serviceId),
+		RevisionName: in.RevisionName,
+		Percent:      in.Percent,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &workspace.ActionResult{
+		Status:  "completed",
+		Message: fmt.Sprintf("routed %d%% traffic to %q", in.Percent, in.RevisionName),
+		Data:    data,
+	}, nil
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 
 	cloudfunctions "cloud.google.com/go/functions/apiv1"
+	functions "cloud.google.com/go/functions/apiv2"
+	monitoring "cloud.google.com/go/monitoring/apiv3/v2"
+	"cloud.google.com/go/storage"
 	"github.com/nullstone-io/deployment-sdk/gcp"
 	"google.golang.org/api/option"
 )
@@ -19,3 +22,29 @@ func NewCloudFunctionsClient(ctx context.Context, account gcp.ServiceAccount) (*
 	}
 	return cloudfunctions.NewCloudFunctionsClient(ctx, option.WithTokenSource(tokenSource))
 }
+
+// NewFunctionClient creates a Cloud Functions v2 client
+// The v2 API reports both gen1 and gen2 functions, but only gen2 functions can be updated through it
+func NewFunctionClient(ctx context.Context, account gcp.ServiceAccount) (*functions.FunctionClient, error) {
+	tokenSource, err := account.TokenSource(ctx, GcpScopes...)
+	if err != nil {
+		return nil, err
+	}
+	return functions.NewFunctionClient(ctx, option.WithTokenSource(tokenSource))
+}
+
+func NewMetricClient(ctx context.Context, account gcp.ServiceAccount) (*monitoring.MetricClient, error) {
+	tokenSource, err := account.TokenSource(ctx, GcpScopes...)
+	if err != nil {
+		return nil, err
+	}
+	return monitoring.NewMetricClient(ctx, option.WithTokenSource(tokenSource))
+}
+
+func NewStorageClient(ctx context.Context, account gcp.ServiceAccount) (*storage.Client, error) {
+	tokenSource, err := account.TokenSource(ctx, GcpScopes...)
+	if err != nil {
+		return nil, err
+	}
+	return storage.NewClient(ctx, option.WithTokenSource(tokenSource))
+}
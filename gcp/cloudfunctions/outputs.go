@@ -30,3 +30,38 @@ func (o *Outputs) InitializeCreds(source outputs.RetrieverSource, ws *types.Work
 func (o *Outputs) ArtifactsKey(appVersion string) string {
 	return strings.Replace(o.ArtifactsKeyTemplate, KeyTemplateAppVersion, appVersion, -1)
 }
+
+// AppVersionFromKey extracts the app version from an artifacts object key by matching it against ArtifactsKeyTemplate
+// Returns an empty string if the key was not produced by the template
+func (o *Outputs) AppVersionFromKey(objectKey string) string {
+	prefix, suffix, found := strings.Cut(o.ArtifactsKeyTemplate, KeyTemplateAppVersion)
+	if !found {
+		return ""
+	}
+	if len(objectKey) <= len(prefix)+len(suffix) || !strings.HasPrefix(objectKey, prefix) || !strings.HasSuffix(objectKey, suffix) {
+		return ""
+	}
+	return objectKey[len(prefix) : len(objectKey)-len(suffix)]
+}
+
+// Location returns the project and region parsed from function_name (projects/{project}/locations/{region}/functions/{name})
+func (o *Outputs) Location() (projectId string, region string) {
+	parts := strings.Split(o.FunctionName, "/")
+	for i := 0; i+1 < len(parts); i++ {
+		switch parts[i] {
+		case "projects":
+			projectId = parts[i+1]
+		case "locations":
+			region = parts[i+1]
+		}
+	}
+	if o.ProjectId != "" {
+		projectId = o.ProjectId
+	}
+	return projectId, region
+}
+
+// ShortFunctionName returns the bare function name (the final segment of function_name)
+func (o *Outputs) ShortFunctionName() string {
+	return shortName(o.FunctionName)
+}
@@ -0,0 +1,116 @@
+package cloudfunctions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/monitoring/apiv3/v2/monitoringpb"
+	"google.golang.org/api/iterator"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// errorsWindow is the look-back for recent error counts
+	errorsWindow = time.Hour
+
+	// gen1 functions report executions labeled by status ("ok", "error", "timeout", "crash", ...)
+	metricExecutionCount = "cloudfunctions.googleapis.com/function/execution_count"
+	// gen2 functions are served by Cloud Run; a failed invocation is a 5xx response
+	metricRequestCount = "run.googleapis.com/request_count"
+)
+
+// recentErrors counts invocations and failed invocations over errorsWindow from Cloud Monitoring.
+// serviceName is the Cloud Run service that serves a gen2 function.
+//
+// This is best-effort: Cloud Monitoring requires roles/monitoring.viewer, which the deployer
+// service account may lack. On any error we log to stderr and return nil.
+func (s Statuser) recentErrors(ctx context.Context, status Status, serviceName string) *ErrorCounts {
+	stderr := s.OsWriters.Stderr()
+	if status.ProjectId == "" {
+		fmt.Fprintln(stderr, "cloud functions metrics: skipping error counts (no project id)")
+		return nil
+	}
+
+	client, err := NewMetricClient(ctx, s.Infra.Deployer)
+	if err != nil {
+		fmt.Fprintf(stderr, "cloud functions metrics: skipping error counts (client init failed): %s\n", err)
+		return nil
+	}
+	defer client.Close()
+
+	metricType, groupBy, isError := metricExecutionCount, "metric.label.status", isGen1ExecutionError
+	filter := fmt.Sprintf(`metric.type=%q AND resource.labels.function_name=%q`, metricType, status.FunctionName)
+	if status.Region != "" {
+		filter += fmt.Sprintf(` AND resource.labels.region=%q`, status.Region)
+	}
+	if status.Environment == EnvironmentGen2 {
+		metricType, groupBy, isError = metricRequestCount, "metric.label.response_code_class", isGen2RequestError
+		filter = fmt.Sprintf(`metric.type=%q AND resource.labels.service_name=%q`, metricType, serviceName)
+		if status.Region != "" {
+			filter += fmt.Sprintf(` AND resource.labels.location=%q`, status.Region)
+		}
+	}
+
+	now := time.Now()
+	it := client.ListTimeSeries(ctx, &monitoringpb.ListTimeSeriesRequest{
+		Name:   "projects/" + status.ProjectId,
+		Filter: filter,
+		Interval: &monitoringpb.TimeInterval{
+			StartTime: timestamppb.New(now.Add(-errorsWindow)),
+			EndTime:   timestamppb.New(now),
+		},
+		Aggregation: &monitoringpb.Aggregation{
+			AlignmentPeriod:    durationpb.New(errorsWindow),
+			PerSeriesAligner:   monitoringpb.Aggregation_ALIGN_DELTA,
+			CrossSeriesReducer: monitoringpb.Aggregation_REDUCE_SUM,
+			GroupByFields:      []string{groupBy},
+		},
+		View: monitoringpb.ListTimeSeriesRequest_FULL,
+	})
+	series := make([]*monitoringpb.TimeSeries, 0)
+	for {
+		ts, err := it.Next()
+		if err == iterator.Done {
+			break
+		} else if err != nil {
+			fmt.Fprintf(stderr, "cloud functions metrics: query for %s failed: %s\n", metricType, err)
+			return nil
+		}
+		series = append(series, ts)
+	}
+
+	counts := tallyErrors(series, isError)
+	return &counts
+}
+
+func isGen1ExecutionError(labels map[string]string) bool {
+	return labels["status"] != "ok"
+}
+
+func isGen2RequestError(labels map[string]string) bool {
+	return labels["response_code_class"] == "5xx"
+}
+
+// tallyErrors sums the invocation counts across every aligned point, counting a series
+// as errors when isError matches its metric labels
+func tallyErrors(series []*monitoringpb.TimeSeries, isError func(labels map[string]string) bool) ErrorCounts {
+	counts := ErrorCounts{WindowMinutes: int(errorsWindow / time.Minute)}
+	for _, ts := range series {
+		var total int64
+		for _, pt := range ts.GetPoints() {
+			switch v := pt.GetValue().GetValue().(type) {
+			case *monitoringpb.TypedValue_Int64Value:
+				total += v.Int64Value
+			case *monitoringpb.TypedValue_DoubleValue:
+				total += int64(v.DoubleValue)
+			}
+		}
+		counts.Invocations += total
+		if isError(ts.GetMetric().GetLabels()) {
+			counts.Errors += total
+		}
+	}
+	return counts
+}
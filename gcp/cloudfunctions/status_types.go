@@ -0,0 +1,95 @@
+package cloudfunctions
+
+import (
+	"time"
+
+	"github.com/nullstone-io/deployment-sdk/gcp/cloudrun"
+)
+
+type FunctionState string
+
+const (
+	FunctionStateActive    FunctionState = "Active"
+	FunctionStateDeploying FunctionState = "Deploying"
+	FunctionStateFailed    FunctionState = "Failed"
+	FunctionStateDeleting  FunctionState = "Deleting"
+	FunctionStateUnknown   FunctionState = "Unknown"
+)
+
+const (
+	EnvironmentGen1 = "gen1"
+	EnvironmentGen2 = "gen2"
+)
+
+type Status struct {
+	FunctionName string `json:"functionName"`
+	ProjectId    string `json:"projectId"`
+	Region       string `json:"region"`
+	// Environment is the function's generation: gen1 or gen2
+	Environment   string         `json:"environment"`
+	State         FunctionState  `json:"state"`
+	StateMessages []StateMessage `json:"stateMessages"`
+	Runtime       string         `json:"runtime"`
+	EntryPoint    string         `json:"entryPoint"`
+	// Source is the artifacts object the active build was built from
+	Source *SourceObject `json:"source,omitempty"`
+	// VersionId is the gen1 function version, incremented by every update
+	VersionId int64      `json:"versionId,omitempty"`
+	BuildId   string     `json:"buildId,omitempty"`
+	Url       string     `json:"url,omitempty"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+	Trigger   Trigger    `json:"trigger"`
+	// RecentErrors counts failed invocations over the last hour (nil when Cloud Monitoring is unavailable)
+	RecentErrors *ErrorCounts `json:"recentErrors,omitempty"`
+	// Revision is the Cloud Run revision serving a gen2 function's latest build
+	Revision string `json:"revision,omitempty"`
+	// Service is the Cloud Run service underlying a gen2 function, including its revisions and traffic split
+	Service *cloudrun.Service `json:"service,omitempty"`
+}
+
+type StateMessage struct {
+	Severity string `json:"severity"`
+	Type     string `json:"type"`
+	Message  string `json:"message"`
+}
+
+type SourceObject struct {
+	Bucket     string `json:"bucket"`
+	Object     string `json:"object"`
+	Generation int64  `json:"generation,omitempty"`
+	// AppVersion is parsed from Object using the artifacts key template
+	AppVersion string `json:"appVersion,omitempty"`
+}
+
+type Trigger struct {
+	// Type is "http" or "event"
+	Type      string `json:"type"`
+	Url       string `json:"url,omitempty"`
+	EventType string `json:"eventType,omitempty"`
+	// Resource is the event source (e.g. pub/sub topic, bucket) for an event trigger
+	Resource string `json:"resource,omitempty"`
+	Retry    bool   `json:"retry,omitempty"`
+}
+
+type ErrorCounts struct {
+	WindowMinutes int   `json:"windowMinutes"`
+	Invocations   int64 `json:"invocations"`
+	Errors        int64 `json:"errors"`
+}
+
+// StatusOverview is the lightweight `overview` field on the frontend
+// AppStatusResult and implements app.StatusOverviewResult.
+type StatusOverview struct {
+	FunctionName string        `json:"functionName"`
+	Environment  string        `json:"environment"`
+	State        FunctionState `json:"state"`
+	AppVersion   string        `json:"appVersion,omitempty"`
+	Revision     string        `json:"revision,omitempty"`
+}
+
+func (s StatusOverview) GetDeploymentVersions() []string {
+	if s.AppVersion == "" {
+		return make([]string, 0)
+	}
+	return []string{s.AppVersion}
+}
@@ -0,0 +1,260 @@
+package cloudfunctions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	v1pb "cloud.google.com/go/functions/apiv1/functionspb"
+	"cloud.google.com/go/functions/apiv2/functionspb"
+	"github.com/nullstone-io/deployment-sdk/app"
+	"github.com/nullstone-io/deployment-sdk/gcp/cloudrun"
+	"github.com/nullstone-io/deployment-sdk/logging"
+	"github.com/nullstone-io/deployment-sdk/outputs"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	_ app.StatusOverviewResult = StatusOverview{}
+	_ app.Statuser             = Statuser{}
+)
+
+func NewStatuser(ctx context.Context, osWriters logging.OsWriters, source outputs.RetrieverSource, appDetails app.Details) (app.Statuser, error) {
+	outs, err := outputs.Retrieve[Outputs](ctx, source, appDetails.Workspace, appDetails.WorkspaceConfig)
+	if err != nil {
+		return nil, err
+	}
+	outs.InitializeCreds(source, appDetails.Workspace)
+
+	return Statuser{
+		OsWriters: osWriters,
+		Details:   appDetails,
+		Infra:     outs,
+	}, nil
+}
+
+type Statuser struct {
+	OsWriters logging.OsWriters
+	Details   app.Details
+	Infra     Outputs
+}
+
+func (s Statuser) StatusOverview(ctx context.Context) (app.StatusOverviewResult, error) {
+	ov := StatusOverview{FunctionName: s.Infra.ShortFunctionName()}
+	status, _, err := s.statusFunction(ctx)
+	if err != nil {
+		return ov, err
+	}
+	ov.Environment = status.Environment
+	ov.State = status.State
+	ov.Revision = status.Revision
+	if status.Source != nil {
+		ov.AppVersion = status.Source.AppVersion
+	}
+	return ov, nil
+}
+
+func (s Statuser) Status(ctx context.Context) (any, error) {
+	status, serviceId, err := s.statusFunction(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Best-effort: error counts from Cloud Monitoring and, for gen2, the underlying Cloud Run service.
+	// Failures are logged, not fatal.
+	status.RecentErrors = s.recentErrors(ctx, status, shortName(serviceId))
+	if serviceId != "" {
+		status.Service = s.statusService(ctx, serviceId)
+	}
+	return status, nil
+}
+
+// statusFunction retrieves the function's status and, for a gen2 function, the id of its Cloud Run service
+func (s Statuser) statusFunction(ctx context.Context) (Status, string, error) {
+	client, err := NewFunctionClient(ctx, s.Infra.Deployer)
+	if err != nil {
+		return Status{}, "", fmt.Errorf("error creating Cloud Functions client: %w", err)
+	}
+	defer client.Close()
+
+	fn, err := client.GetFunction(ctx, &functionspb.GetFunctionRequest{Name: s.Infra.FunctionName})
+	if err != nil {
+		return Status{}, "", fmt.Errorf("error getting Cloud Function: %w", err)
+	}
+	status := mapFunction(fn, s.Infra)
+	if status.Environment != EnvironmentGen1 {
+		return status, fn.GetServiceConfig().GetService(), nil
+	}
+
+	// The v2 API omits the source archive, version, and trigger of gen1 functions; the v1 API has them
+	v1Client, err := NewCloudFunctionsClient(ctx, s.Infra.Deployer)
+	if err != nil {
+		return Status{}, "", fmt.Errorf("error creating Cloud Functions client: %w", err)
+	}
+	defer v1Client.Close()
+	v1Fn, err := v1Client.GetFunction(ctx, &v1pb.GetFunctionRequest{Name: s.Infra.FunctionName})
+	if err != nil {
+		return Status{}, "", fmt.Errorf("error getting Cloud Function: %w", err)
+	}
+	applyGen1Details(&status, v1Fn, s.Infra)
+	return status, "", nil
+}
+
+// statusService retrieves the Cloud Run service that serves a gen2 function
+// Returns nil if the service could not be retrieved (logged)
+func (s Statuser) statusService(ctx context.Context, serviceId string) *cloudrun.Service {
+	rs := cloudrun.Statuser{
+		OsWriters: s.OsWriters,
+		Details:   s.Details,
+		Infra:     cloudrun.Outputs{ServiceId: serviceId, Deployer: s.Infra.Deployer},
+	}
+	result, err := rs.Status(ctx)
+	if err != nil {
+		fmt.Fprintf(s.OsWriters.Stderr(), "cloud functions: unable to retrieve cloud run service %q: %s\n", serviceId, err)
+		return nil
+	}
+	if status, ok := result.(cloudrun.Status); ok {
+		return status.Service
+	}
+	return nil
+}
+
+func mapFunction(fn *functionspb.Function, infra Outputs) Status {
+	projectId, region := infra.Location()
+	status := Status{
+		FunctionName:  infra.ShortFunctionName(),
+		ProjectId:     projectId,
+		Region:        region,
+		Environment:   EnvironmentGen2,
+		State:         mapFunctionState(fn.GetState()),
+		StateMessages: make([]StateMessage, 0),
+		Runtime:       fn.GetBuildConfig().GetRuntime(),
+		EntryPoint:    fn.GetBuildConfig().GetEntryPoint(),
+		BuildId:       shortName(fn.GetBuildConfig().GetBuild()),
+		Url:           fn.GetUrl(),
+		UpdatedAt:     tsToTime(fn.GetUpdateTime()),
+		Revision:      fn.GetServiceConfig().GetRevision(),
+	}
+	if fn.GetEnvironment() == functionspb.Environment_GEN_1 {
+		status.Environment = EnvironmentGen1
+	}
+	for _, msg := range fn.GetStateMessages() {
+		status.StateMessages = append(status.StateMessages, StateMessage{
+			Severity: msg.GetSeverity().String(),
+			Type:     msg.GetType(),
+			Message:  msg.GetMessage(),
+		})
+	}
+
+	// Prefer the resolved source: it pins the object generation that was built
+	src := fn.GetBuildConfig().GetSourceProvenance().GetResolvedStorageSource()
+	if src == nil {
+		src = fn.GetBuildConfig().GetSource().GetStorageSource()
+	}
+	if src != nil && src.GetObject() != "" {
+		status.Source = &SourceObject{
+			Bucket:     src.GetBucket(),
+			Object:     src.GetObject(),
+			Generation: src.GetGeneration(),
+			AppVersion: infra.AppVersionFromKey(src.GetObject()),
+		}
+	}
+
+	if et := fn.GetEventTrigger(); et != nil {
+		status.Trigger = Trigger{
+			Type:      "event",
+			EventType: et.GetEventType(),
+			Resource:  et.GetPubsubTopic(),
+			Retry:     et.GetRetryPolicy() == functionspb.EventTrigger_RETRY_POLICY_RETRY,
+		}
+		if status.Trigger.Resource == "" {
+			status.Trigger.Resource = eventFilterResource(et.GetEventFilters())
+		}
+	} else {
+		status.Trigger = Trigger{Type: "http", Url: fn.GetUrl()}
+	}
+	return status
+}
+
+// eventFilterResource returns the most descriptive event filter value (e.g. the bucket of a storage trigger)
+func eventFilterResource(filters []*functionspb.EventFilter) string {
+	for _, f := range filters {
+		if f.GetAttribute() != "type" {
+			return f.GetValue()
+		}
+	}
+	return ""
+}
+
+func applyGen1Details(status *Status, fn *v1pb.CloudFunction, infra Outputs) {
+	status.Runtime = fn.GetRuntime()
+	status.EntryPoint = fn.GetEntryPoint()
+	status.VersionId = fn.GetVersionId()
+	if id := fn.GetBuildId(); id != "" {
+		status.BuildId = id
+	}
+	if bucket, object, ok := parseGcsUrl(fn.GetSourceArchiveUrl()); ok {
+		status.Source = &SourceObject{
+			Bucket:     bucket,
+			Object:     object,
+			AppVersion: infra.AppVersionFromKey(object),
+		}
+	}
+	if ht := fn.GetHttpsTrigger(); ht != nil {
+		status.Trigger = Trigger{Type: "http", Url: ht.GetUrl()}
+		status.Url = ht.GetUrl()
+	} else if et := fn.GetEventTrigger(); et != nil {
+		status.Trigger = Trigger{
+			Type:      "event",
+			EventType: et.GetEventType(),
+			Resource:  et.GetResource(),
+			Retry:     et.GetFailurePolicy().GetRetry() != nil,
+		}
+	}
+}
+
+func mapFunctionState(state functionspb.Function_State) FunctionState {
+	switch state {
+	case functionspb.Function_ACTIVE:
+		return FunctionStateActive
+	case functionspb.Function_DEPLOYING:
+		return FunctionStateDeploying
+	case functionspb.Function_FAILED:
+		return FunctionStateFailed
+	case functionspb.Function_DELETING:
+		return FunctionStateDeleting
+	default:
+		return FunctionStateUnknown
+	}
+}
+
+// parseGcsUrl splits a gs://bucket/object url into its bucket and object
+func parseGcsUrl(url string) (string, string, bool) {
+	rest, ok := strings.CutPrefix(url, "gs://")
+	if !ok {
+		return "", "", false
+	}
+	bucket, object, ok := strings.Cut(rest, "/")
+	if !ok || bucket == "" || object == "" {
+		return "", "", false
+	}
+	return bucket, object, true
+}
+
+// shortName returns the final path segment of a resource name
+func shortName(resourceName string) string {
+	return resourceName[strings.LastIndex(resourceName, "/")+1:]
+}
+
+// tsToTime converts a protobuf timestamp to *time.Time, returning nil for an absent or zero timestamp
+func tsToTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
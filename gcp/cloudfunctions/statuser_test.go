@@ -0,0 +1,141 @@
+package cloudfunctions
+
+import (
+	"testing"
+
+	v1pb "cloud.google.com/go/functions/apiv1/functionspb"
+	"cloud.google.com/go/functions/apiv2/functionspb"
+	"cloud.google.com/go/monitoring/apiv3/v2/monitoringpb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/api/metric"
+)
+
+func TestOutputs_AppVersionFromKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		key      string
+		want     string
+	}{
+		{name: "suffix", template: "{{app-version}}.zip", key: "v1.2.3.zip", want: "v1.2.3"},
+		{name: "prefix and suffix", template: "api/{{app-version}}/source.zip", key: "api/abc123/source.zip", want: "abc123"},
+		{name: "other object", template: "api/{{app-version}}.zip", key: "worker/v1.zip", want: ""},
+		{name: "empty version", template: "api/{{app-version}}.zip", key: "api/.zip", want: ""},
+		{name: "no placeholder", template: "api/source.zip", key: "api/source.zip", want: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			o := Outputs{ArtifactsKeyTemplate: test.template}
+			assert.Equal(t, test.want, o.AppVersionFromKey(test.key))
+		})
+	}
+}
+
+func TestMapFunction(t *testing.T) {
+	infra := Outputs{
+		FunctionName:         "projects/acme/locations/us-east1/functions/api",
+		ArtifactsKeyTemplate: "api/{{app-version}}.zip",
+	}
+
+	t.Run("gen2 http", func(t *testing.T) {
+		fn := &functionspb.Function{
+			Environment: functionspb.Environment_GEN_2,
+			State:       functionspb.Function_FAILED,
+			StateMessages: []*functionspb.StateMessage{
+				{Severity: functionspb.StateMessage_ERROR, Type: "CloudRunServiceNotReady", Message: "container failed to start"},
+			},
+			Url: "https://api-abc.a.run.app",
+			BuildConfig: &functionspb.BuildConfig{
+				Runtime:    "nodejs20",
+				EntryPoint: "handler",
+				Build:      "projects/123/locations/us-east1/builds/b-1",
+				Source: &functionspb.Source{Source: &functionspb.Source_StorageSource{
+					StorageSource: &functionspb.StorageSource{Bucket: "artifacts", Object: "api/v2.zip"},
+				}},
+				SourceProvenance: &functionspb.SourceProvenance{
+					ResolvedStorageSource: &functionspb.StorageSource{Bucket: "artifacts", Object: "api/v2.zip", Generation: 42},
+				},
+			},
+			ServiceConfig: &functionspb.ServiceConfig{Revision: "api-00003-xyz"},
+		}
+		got := mapFunction(fn, infra)
+		assert.Equal(t, "api", got.FunctionName)
+		assert.Equal(t, "acme", got.ProjectId)
+		assert.Equal(t, "us-east1", got.Region)
+		assert.Equal(t, EnvironmentGen2, got.Environment)
+		assert.Equal(t, FunctionStateFailed, got.State)
+		assert.Equal(t, []StateMessage{{Severity: "ERROR", Type: "CloudRunServiceNotReady", Message: "container failed to start"}}, got.StateMessages)
+		assert.Equal(t, "b-1", got.BuildId)
+		assert.Equal(t, "api-00003-xyz", got.Revision)
+		assert.Equal(t, &SourceObject{Bucket: "artifacts", Object: "api/v2.zip", Generation: 42, AppVersion: "v2"}, got.Source)
+		assert.Equal(t, Trigger{Type: "http", Url: "https://api-abc.a.run.app"}, got.Trigger)
+	})
+
+	t.Run("gen2 event", func(t *testing.T) {
+		fn := &functionspb.Function{
+			Environment: functionspb.Environment_GEN_2,
+			State:       functionspb.Function_ACTIVE,
+			EventTrigger: &functionspb.EventTrigger{
+				EventType:    "google.cloud.storage.object.v1.finalized",
+				RetryPolicy:  functionspb.EventTrigger_RETRY_POLICY_RETRY,
+				EventFilters: []*functionspb.EventFilter{{Attribute: "bucket", Value: "uploads"}},
+			},
+		}
+		got := mapFunction(fn, infra)
+		assert.Equal(t, FunctionStateActive, got.State)
+		assert.Nil(t, got.Source)
+		assert.Equal(t, Trigger{Type: "event", EventType: "google.cloud.storage.object.v1.finalized", Resource: "uploads", Retry: true}, got.Trigger)
+	})
+
+	t.Run("gen1", func(t *testing.T) {
+		fn := &functionspb.Function{Environment: functionspb.Environment_GEN_1, State: functionspb.Function_DEPLOYING}
+		got := mapFunction(fn, infra)
+		require.Equal(t, EnvironmentGen1, got.Environment)
+
+		applyGen1Details(&got, &v1pb.CloudFunction{
+			Runtime:    "python312",
+			EntryPoint: "main",
+			VersionId:  7,
+			BuildId:    "b-7",
+			SourceCode: &v1pb.CloudFunction_SourceArchiveUrl{SourceArchiveUrl: "gs://artifacts/api/v1.zip"},
+			Trigger: &v1pb.CloudFunction_EventTrigger{EventTrigger: &v1pb.EventTrigger{
+				EventType: "google.pubsub.topic.publish",
+				Resource:  "projects/acme/topics/jobs",
+			}},
+		}, infra)
+		assert.Equal(t, FunctionStateDeploying, got.State)
+		assert.Equal(t, "python312", got.Runtime)
+		assert.Equal(t, int64(7), got.VersionId)
+		assert.Equal(t, "b-7", got.BuildId)
+		assert.Equal(t, &SourceObject{Bucket: "artifacts", Object: "api/v1.zip", AppVersion: "v1"}, got.Source)
+		assert.Equal(t, Trigger{Type: "event", EventType: "google.pubsub.topic.publish", Resource: "projects/acme/topics/jobs"}, got.Trigger)
+	})
+}
+
+func TestTallyErrors(t *testing.T) {
+	series := func(labels map[string]string, values ...int64) *monitoringpb.TimeSeries {
+		ts := &monitoringpb.TimeSeries{Metric: &metric.Metric{Labels: labels}}
+		for _, v := range values {
+			ts.Points = append(ts.Points, &monitoringpb.Point{
+				Value: &monitoringpb.TypedValue{Value: &monitoringpb.TypedValue_Int64Value{Int64Value: v}},
+			})
+		}
+		return ts
+	}
+
+	got := tallyErrors([]*monitoringpb.TimeSeries{
+		series(map[string]string{"status": "ok"}, 90, 10),
+		series(map[string]string{"status": "error"}, 3),
+		series(map[string]string{"status": "timeout"}, 2),
+	}, isGen1ExecutionError)
+	assert.Equal(t, ErrorCounts{WindowMinutes: 60, Invocations: 105, Errors: 5}, got)
+
+	got = tallyErrors([]*monitoringpb.TimeSeries{
+		series(map[string]string{"response_code_class": "2xx"}, 40),
+		series(map[string]string{"response_code_class": "4xx"}, 6),
+		series(map[string]string{"response_code_class": "5xx"}, 4),
+	}, isGen2RequestError)
+	assert.Equal(t, ErrorCounts{WindowMinutes: 60, Invocations: 50, Errors: 4}, got)
+}
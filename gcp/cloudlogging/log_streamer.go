@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/nullstone-io/deployment-sdk/outputs"
 	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 var (
@@ -73,11 +75,19 @@ func (s LogStreamer) Stream(ctx context.Context, options app.LogStreamOptions) e
 		options.Selectors = append(options.Selectors, fmt.Sprintf("labels.%q=%q", revisionNameLabel, options.Revision))
 	}
 
+	// Translate the provider-neutral query into filter clauses; the client-side filter covers the rest (e.g. Regex)
+	emitter, err := options.Query.FilterEmitter(options.Emitter)
+	if err != nil {
+		return err
+	}
+	options.Emitter = emitter
+	options.Selectors = append(options.Selectors, QueryFilters(options.Query)...)
+
 	logger := log.New(s.OsWriters.Stderr(), "", 0)
 	logger.Println(options.QueryTimeMessage())
 	logger.Println(options.WatchMessage())
 	logger.Println("Querying using the filter:")
-	logger.Printf("\t%s\n", s.Infra.LogFilter)
+	logger.Printf("\t%s\n", buildFilter(s.Infra.LogFilter, options.Selectors, nil, nil))
 
 	tokenSource, err := s.Infra.LogReader.TokenSource(ctx, LogScopes...)
 	if err != nil {
@@ -113,7 +123,9 @@ func (s LogStreamer) writeLatestEventsFunc(filter string, options app.LogStreamO
 	startTime := options.StartTime
 	selectors := options.Selectors
 	var lastEventTime *time.Time
-	visitedSpans := map[string]bool{}
+	// Entries are de-duplicated by insert id because the next query overlaps at lastEventTime
+	// (many entries, such as load balancer request logs, have no span id)
+	visited := map[string]bool{}
 
 	return func(ctx context.Context, client *logadmin.Client) error {
 		if lastEventTime != nil {
@@ -134,11 +146,15 @@ func (s LogStreamer) writeLatestEventsFunc(filter string, options app.LogStreamO
 			if err != nil {
 				return fmt.Errorf("failed to fetch log entry: %w", err)
 			}
-			if _, ok := visitedSpans[entry.SpanID]; ok {
+			key := entry.InsertID
+			if key == "" {
+				key = entry.SpanID
+			}
+			if _, ok := visited[key]; ok {
 				continue
 			}
 			lastEventTime = &entry.Timestamp
-			visitedSpans[entry.SpanID] = true
+			visited[key] = true
 			options.Emitter(LogMessageFromFilteredLogEvent(*entry))
 		}
 	}
@@ -162,14 +178,55 @@ func LogMessageFromFilteredLogEvent(entry gcplogging.Entry) app.LogMessage {
 	case string:
 		msg = p
 	default:
-		raw, _ := json.Marshal(entry.Payload)
+		if entry.HTTPRequest != nil && entry.HTTPRequest.Request != nil {
+			msg = formatHttpRequest(entry)
+			break
+		}
+		var payload any = p
+		if st, ok := p.(*structpb.Struct); ok {
+			// Marshal a jsonPayload as a plain JSON object (not the protobuf representation)
+			payload = st.AsMap()
+		}
+		raw, _ := json.Marshal(payload)
 		msg = string(raw)
 	}
+	level := ""
+	if entry.Severity != gcplogging.Default {
+		level = strings.ToLower(entry.Severity.String())
+	}
 	return app.LogMessage{
 		SourceType: "cloud-logging",
 		Source:     entry.LogName,
 		Stream:     "",
 		Message:    msg,
 		Timestamp:  entry.Timestamp,
+		Level:      level,
+	}
+}
+
+// formatHttpRequest renders a request log entry (e.g. from a load balancer or Cloud Run) as a single access log line
+// e.g. GET https://example.com/index.html 200 1024B 12ms cache=hit (response_from_cache)
+func formatHttpRequest(entry gcplogging.Entry) string {
+	req := entry.HTTPRequest
+	parts := []string{req.Request.Method, req.Request.URL.String(), strconv.Itoa(req.Status)}
+	if req.ResponseSize > 0 {
+		parts = append(parts, fmt.Sprintf("%dB", req.ResponseSize))
+	}
+	if req.Latency > 0 {
+		parts = append(parts, req.Latency.Round(time.Millisecond).String())
+	}
+	if req.CacheLookup {
+		if req.CacheHit {
+			parts = append(parts, "cache=hit")
+		} else {
+			parts = append(parts, "cache=miss")
+		}
+	}
+	// Load balancer request logs explain who produced the response in jsonPayload.statusDetails
+	if payload, ok := entry.Payload.(*structpb.Struct); ok {
+		if details := payload.GetFields()["statusDetails"].GetStringValue(); details != "" {
+			parts = append(parts, fmt.Sprintf("(%s)", details))
+		}
 	}
+	return strings.Join(parts, " ")
 }
@@ -0,0 +1,45 @@
+package cloudlogging
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	gcplogging "cloud.google.com/go/logging"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestLogMessageFromFilteredLogEvent_HttpRequest(t *testing.T) {
+	u, _ := url.Parse("https://example.com/index.html")
+	payload, _ := structpb.NewStruct(map[string]any{
+		"@type":         "type.googleapis.com/google.cloud.loadbalancing.type.LoadBalancerLogEntry",
+		"statusDetails": "response_from_cache",
+	})
+	entry := gcplogging.Entry{
+		LogName: "projects/acme/logs/requests",
+		Payload: payload,
+		HTTPRequest: &gcplogging.HTTPRequest{
+			Request:      &http.Request{Method: http.MethodGet, URL: u},
+			Status:       200,
+			ResponseSize: 1024,
+			Latency:      12345 * time.Microsecond,
+			CacheLookup:  true,
+			CacheHit:     true,
+		},
+	}
+
+	got := LogMessageFromFilteredLogEvent(entry)
+	assert.Equal(t, "GET https://example.com/index.html 200 1024B 12ms cache=hit (response_from_cache)", got.Message)
+
+	entry.Payload = nil
+	entry.HTTPRequest.CacheLookup = false
+	entry.HTTPRequest.Latency = 0
+	entry.HTTPRequest.Status = 404
+	got = LogMessageFromFilteredLogEvent(entry)
+	assert.Equal(t, "GET https://example.com/index.html 404 1024B", got.Message)
+
+	got = LogMessageFromFilteredLogEvent(gcplogging.Entry{Payload: "plain text"})
+	assert.Equal(t, "plain text", got.Message)
+}
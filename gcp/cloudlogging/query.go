@@ -0,0 +1,48 @@
+package cloudlogging
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/nullstone-io/deployment-sdk/app"
+	"github.com/nullstone-io/deployment-sdk/maps"
+)
+
+// filterKey matches field names that can be referenced without quoting in a Cloud Logging filter
+var filterKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// QueryFilters translates a LogQuery into Cloud Logging filter clauses that are joined with AND
+// https://cloud.google.com/logging/docs/view/logging-query-language
+//
+// Regex is left for the client-side filter because it cannot be applied to every
+// field of a jsonPayload in a single clause.
+func QueryFilters(query *app.LogQuery) []string {
+	filters := make([]string, 0)
+	if query.IsEmpty() {
+		return filters
+	}
+	if severity := app.ParseLogSeverity(string(query.MinSeverity)); severity != "" {
+		filters = append(filters, fmt.Sprintf("severity>=%s", strings.ToUpper(string(severity))))
+	}
+	if query.Contains != "" {
+		// A bare string is a global restriction that matches entries with any field containing the text
+		filters = append(filters, fmt.Sprintf("%q", query.Contains))
+	}
+	for _, key := range maps.SortedKeys(query.Fields) {
+		filters = append(filters, fmt.Sprintf("jsonPayload.%s=%q", filterFieldName(key), query.Fields[key]))
+	}
+	for _, key := range maps.SortedKeys(query.ResourceLabels) {
+		filters = append(filters, fmt.Sprintf("resource.labels.%s=%q", filterFieldName(key), query.ResourceLabels[key]))
+	}
+	return filters
+}
+
+// filterFieldName quotes a field name that contains characters that are not allowed in a bare field path
+// e.g. jsonPayload."http.status"
+func filterFieldName(key string) string {
+	if filterKey.MatchString(key) {
+		return key
+	}
+	return fmt.Sprintf("%q", key)
+}
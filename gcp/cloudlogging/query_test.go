@@ -0,0 +1,51 @@
+package cloudlogging
+
+import (
+	"testing"
+	"time"
+
+	gcplogging "cloud.google.com/go/logging"
+	"github.com/nullstone-io/deployment-sdk/app"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestQueryFilters(t *testing.T) {
+	assert.Equal(t, []string{}, QueryFilters(nil))
+
+	query := &app.LogQuery{
+		MinSeverity:    "warn",
+		Contains:       `say "hi"`,
+		Regex:          "^GET",
+		Fields:         map[string]string{"status": "500", "http.route": "/api"},
+		ResourceLabels: map[string]string{"service_name": "api"},
+	}
+	assert.Equal(t, []string{
+		`severity>=WARNING`,
+		`"say \"hi\""`,
+		`jsonPayload."http.route"="/api"`,
+		`jsonPayload.status="500"`,
+		`resource.labels.service_name="api"`,
+	}, QueryFilters(query))
+
+	filter := buildFilter(`resource.type="cloud_run_revision"`, QueryFilters(&app.LogQuery{MinSeverity: app.LogSeverityError}), nil, nil)
+	assert.Equal(t, `(resource.type="cloud_run_revision") AND severity>=ERROR`, filter)
+}
+
+func TestLogMessageFromFilteredLogEvent_JsonPayload(t *testing.T) {
+	payload, _ := structpb.NewStruct(map[string]any{"message": "boom", "status": 500})
+	got := LogMessageFromFilteredLogEvent(gcplogging.Entry{
+		Payload:   payload,
+		Severity:  gcplogging.Error,
+		Timestamp: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+	})
+	assert.Equal(t, `{"message":"boom","status":500}`, got.Message)
+	assert.Equal(t, "error", got.Level)
+
+	m, err := (&app.LogQuery{MinSeverity: app.LogSeverityError, Fields: map[string]string{"status": "500"}}).Compile()
+	assert.NoError(t, err)
+	assert.True(t, m.Match(got))
+
+	got = LogMessageFromFilteredLogEvent(gcplogging.Entry{Payload: "plain", Severity: gcplogging.Default})
+	assert.Equal(t, "", got.Level)
+}
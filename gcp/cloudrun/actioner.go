@@ -3,7 +3,9 @@ package cloudrun
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"cloud.google.com/go/run/apiv2/runpb"
@@ -18,10 +20,18 @@ const (
 	ActionRouteTraffic    = "route-traffic"
 	ActionCancelExecution = "cancel-execution"
 	ActionRerunJob        = "rerun-job"
+	ActionExecuteJob      = "execute-job"
+	ActionScale           = "scale"
+	ActionPause           = "pause"
+	ActionResume          = "resume"
 
 	// restartAnnotation is bumped on the revision template to force Cloud Run to
 	// mint a fresh revision on UpdateService even when nothing else changed.
 	restartAnnotation = "nullstone.io/restarted-at"
+	// pausedScalingAnnotation holds the service-level scaling from before a pause so resume can restore it.
+	pausedScalingAnnotation = "nullstone.io/paused-scaling"
+
+	defaultExecuteJobTimeout = 30 * time.Minute
 )
 
 type RestartRevisionInput struct {
@@ -72,6 +82,44 @@ type RerunJobResult struct {
 	Operation       string `json:"operation,omitempty"`
 }
 
+type ExecuteJobInput struct {
+	Args        []string          `json:"args,omitempty"`
+	Environment map[string]string `json:"environment,omitempty"`
+	Container   string            `json:"container,omitempty"`
+	TaskCount   *int32            `json:"taskCount,omitempty"`
+	// TaskTimeoutSeconds overrides how long each task may run before Cloud Run fails it.
+	TaskTimeoutSeconds int `json:"taskTimeoutSeconds,omitempty"`
+	// TimeoutSeconds bounds how long to wait for the execution to complete (default 30m).
+	TimeoutSeconds int `json:"timeoutSeconds,omitempty"`
+}
+
+type ScaleInput struct {
+	// MinInstances and MaxInstances set the service-level instance bounds.
+	// A nil value leaves the current setting unchanged.
+	MinInstances *int32 `json:"minInstances,omitempty"`
+	MaxInstances *int32 `json:"maxInstances,omitempty"`
+}
+
+type ScaleResult struct {
+	Service      string `json:"service"`
+	MinInstances int32  `json:"minInstances"`
+	MaxInstances int32  `json:"maxInstances"`
+}
+
+type PauseInput struct{}
+
+type PauseResult struct {
+	Service string `json:"service"`
+}
+
+type ResumeInput struct{}
+
+type ResumeResult struct {
+	Service      string `json:"service"`
+	MinInstances int32  `json:"minInstances"`
+	MaxInstances int32  `json:"maxInstances"`
+}
+
 func NewActioner(ctx context.Context, source outputs.RetrieverSource, blockDetails workspace.Details) (workspace.Actioner, error) {
 	outs, err := outputs.Retrieve[Outputs](ctx, source, blockDetails.Workspace, blockDetails.WorkspaceConfig)
 	if err != nil {
@@ -102,6 +150,14 @@ func (a Actioner) PerformAction(ctx context.Context, options workspace.ActionOpt
 		return a.cancelExecution(ctx, options.Input)
 	case ActionRerunJob:
 		return a.rerunJob(ctx, options.Input)
+	case ActionExecuteJob:
+		return a.executeJob(ctx, options.Input)
+	case ActionScale:
+		return a.scale(ctx, options.Input)
+	case ActionPause:
+		return a.pause(ctx, options.Input)
+	case ActionResume:
+		return a.resume(ctx, options.Input)
 	default:
 		return nil, workspace.ActionNotSupportedError{
 			InnerErr: fmt.Errorf("unknown cloud run action %q", options.Action),
@@ -348,6 +404,302 @@ func (a Actioner) rerunJob(ctx context.Context, input json.RawMessage) (*workspa
 	}, nil
 }
 
+// executeJob runs a new execution of the job with the requested overrides and waits for it to complete.
+// The result reports the execution's phase and per-task exit codes; use ExecuteJob directly to also follow the execution's logs.
+func (a Actioner) executeJob(ctx context.Context, input json.RawMessage) (*workspace.ActionResult, error) {
+	if a.Infra.JobId == "" {
+		return nil, fmt.Errorf("%s requires a job workspace", ActionExecuteJob)
+	}
+	var in ExecuteJobInput
+	if len(input) > 0 {
+		if err := json.Unmarshal(input, &in); err != nil {
+			return nil, fmt.Errorf("invalid input for %s: %w", ActionExecuteJob, err)
+		}
+	}
+	if in.TaskCount != nil && *in.TaskCount <= 0 {
+		return nil, fmt.Errorf("%s requires taskCount to be positive, got %d", ActionExecuteJob, *in.TaskCount)
+	}
+	timeout := defaultExecuteJobTimeout
+	if in.TimeoutSeconds > 0 {
+		timeout = time.Duration(in.TimeoutSeconds) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result, err := ExecuteJob(ctx, a.Infra, ExecuteJobOptions{
+		Args:        in.Args,
+		Environment: in.Environment,
+		Container:   in.Container,
+		TaskCount:   in.TaskCount,
+		TaskTimeout: time.Duration(in.TaskTimeoutSeconds) * time.Second,
+	})
+	if err != nil {
+		if result != nil && errors.Is(err, context.DeadlineExceeded) {
+			return nil, fmt.Errorf("timed out waiting for execution %q to complete", result.ExecutionId)
+		}
+		return nil, err
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	status := "completed"
+	msg := fmt.Sprintf("execution %q %s", result.ExecutionId, strings.ToLower(string(result.Phase)))
+	if result.Failure != nil {
+		status = "failed"
+		msg = fmt.Sprintf("%s: %s", msg, result.Failure.Title)
+		if result.Failure.ExitCode != nil {
+			msg = fmt.Sprintf("%s (exit code %d)", msg, *result.Failure.ExitCode)
+		}
+	}
+	return &workspace.ActionResult{
+		Status:  status,
+		Message: msg,
+		Data:    data,
+	}, nil
+}
+
+// scale updates the service-level min/max instance counts.
+// Service-level scaling applies to every revision and does not create a new revision.
+func (a Actioner) scale(ctx context.Context, input json.RawMessage) (*workspace.ActionResult, error) {
+	if a.Infra.ServiceId == "" {
+		return nil, fmt.Errorf("%s requires a service workspace", ActionScale)
+	}
+	var in ScaleInput
+	if len(input) > 0 {
+		if err := json.Unmarshal(input, &in); err != nil {
+			return nil, fmt.Errorf("invalid input for %s: %w", ActionScale, err)
+		}
+	}
+	if in.MinInstances == nil && in.MaxInstances == nil {
+		return nil, fmt.Errorf("%s requires minInstances or maxInstances", ActionScale)
+	}
+
+	client, err := NewServicesClient(ctx, a.Infra.Deployer)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing cloud run services client: %w", err)
+	}
+	defer client.Close()
+
+	svc, err := client.GetService(ctx, &runpb.GetServiceRequest{Name: a.Infra.ServiceId})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving service: %w", err)
+	}
+	if _, ok := svc.GetAnnotations()[pausedScalingAnnotation]; ok {
+		return nil, fmt.Errorf("service %q is paused, resume it before scaling", a.Infra.ServiceId)
+	}
+	scaling := svc.GetScaling()
+	if scaling == nil {
+		scaling = &runpb.ServiceScaling{}
+	}
+	if in.MinInstances != nil {
+		scaling.MinInstanceCount = *in.MinInstances
+	}
+	if in.MaxInstances != nil {
+		scaling.MaxInstanceCount = *in.MaxInstances
+	}
+	if err := a.checkScaleLimit(scaling); err != nil {
+		return nil, err
+	}
+	svc.Scaling = scaling
+
+	op, err := client.UpdateService(ctx, &runpb.UpdateServiceRequest{Service: svc})
+	if err != nil {
+		return nil, fmt.Errorf("error scaling service %q: %w", a.Infra.ServiceId, err)
+	}
+	if _, err := op.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("error waiting for scaling update: %w", err)
+	}
+
+	data, err := json.Marshal(ScaleResult{
+		Service:      a.Infra.ServiceName(),
+		MinInstances: scaling.MinInstanceCount,
+		MaxInstances: scaling.MaxInstanceCount,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &workspace.ActionResult{
+		Status:  "completed",
+		Message: fmt.Sprintf("scaled service %q to min=%d max=%d instances", a.Infra.ServiceId, scaling.MinInstanceCount, scaling.MaxInstanceCount),
+		Data:    data,
+	}, nil
+}
+
+// pause switches the service to manual scaling with zero instances so it stops serving.
+// The prior service-level scaling is stored in a service annotation so that resume can restore it.
+func (a Actioner) pause(ctx context.Context, _ json.RawMessage) (*workspace.ActionResult, error) {
+	if a.Infra.ServiceId == "" {
+		return nil, fmt.Errorf("%s requires a service workspace", ActionPause)
+	}
+
+	client, err := NewServicesClient(ctx, a.Infra.Deployer)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing cloud run services client: %w", err)
+	}
+	defer client.Close()
+
+	svc, err := client.GetService(ctx, &runpb.GetServiceRequest{Name: a.Infra.ServiceId})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving service: %w", err)
+	}
+	if _, ok := svc.GetAnnotations()[pausedScalingAnnotation]; ok {
+		return nil, fmt.Errorf("service %q is already paused", a.Infra.ServiceId)
+	}
+	raw, err := encodePausedScaling(svc.GetScaling())
+	if err != nil {
+		return nil, err
+	}
+	if svc.Annotations == nil {
+		svc.Annotations = map[string]string{}
+	}
+	svc.Annotations[pausedScalingAnnotation] = raw
+	zero := int32(0)
+	svc.Scaling = &runpb.ServiceScaling{
+		ScalingMode:         runpb.ServiceScaling_MANUAL,
+		ManualInstanceCount: &zero,
+	}
+
+	op, err := client.UpdateService(ctx, &runpb.UpdateServiceRequest{Service: svc})
+	if err != nil {
+		return nil, fmt.Errorf("error pausing service %q: %w", a.Infra.ServiceId, err)
+	}
+	if _, err := op.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("error waiting for service to pause: %w", err)
+	}
+
+	data, err := json.Marshal(PauseResult{Service: a.Infra.ServiceName()})
+	if err != nil {
+		return nil, err
+	}
+	return &workspace.ActionResult{
+		Status:  "completed",
+		Message: fmt.Sprintf("paused service %q", a.Infra.ServiceId),
+		Data:    data,
+	}, nil
+}
+
+// resume restores the service-level scaling that was recorded when the service was paused.
+func (a Actioner) resume(ctx context.Context, _ json.RawMessage) (*workspace.ActionResult, error) {
+	if a.Infra.ServiceId == "" {
+		return nil, fmt.Errorf("%s requires a service workspace", ActionResume)
+	}
+
+	client, err := NewServicesClient(ctx, a.Infra.Deployer)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing cloud run services client: %w", err)
+	}
+	defer client.Close()
+
+	svc, err := client.GetService(ctx, &runpb.GetServiceRequest{Name: a.Infra.ServiceId})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving service: %w", err)
+	}
+	raw, ok := svc.GetAnnotations()[pausedScalingAnnotation]
+	if !ok {
+		return nil, fmt.Errorf("service %q is not paused", a.Infra.ServiceId)
+	}
+	scaling, err := decodePausedScaling(raw)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.checkScaleLimit(scaling); err != nil {
+		return nil, err
+	}
+	svc.Scaling = scaling
+	delete(svc.Annotations, pausedScalingAnnotation)
+
+	op, err := client.UpdateService(ctx, &runpb.UpdateServiceRequest{Service: svc})
+	if err != nil {
+		return nil, fmt.Errorf("error resuming service %q: %w", a.Infra.ServiceId, err)
+	}
+	if _, err := op.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("error waiting for service to resume: %w", err)
+	}
+
+	data, err := json.Marshal(ResumeResult{
+		Service:      a.Infra.ServiceName(),
+		MinInstances: scaling.MinInstanceCount,
+		MaxInstances: scaling.MaxInstanceCount,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &workspace.ActionResult{
+		Status:  "completed",
+		Message: fmt.Sprintf("resumed service %q", a.Infra.ServiceId),
+		Data:    data,
+	}, nil
+}
+
+// checkScaleLimit validates instance counts against each other and the max_scale output
+func (a Actioner) checkScaleLimit(scaling *runpb.ServiceScaling) error {
+	type count struct {
+		name  string
+		value int32
+	}
+	counts := []count{
+		{name: "minInstances", value: scaling.GetMinInstanceCount()},
+		{name: "maxInstances", value: scaling.GetMaxInstanceCount()},
+	}
+	if scaling.ManualInstanceCount != nil {
+		counts = append(counts, count{name: "manualInstances", value: scaling.GetManualInstanceCount()})
+	}
+	for _, c := range counts {
+		if c.value < 0 {
+			return fmt.Errorf("%s must not be negative, got %d", c.name, c.value)
+		}
+		if a.Infra.MaxScale > 0 && c.value > a.Infra.MaxScale {
+			return fmt.Errorf("%s %d exceeds the max scale limit of %d", c.name, c.value, a.Infra.MaxScale)
+		}
+	}
+	if maxCount := scaling.GetMaxInstanceCount(); maxCount > 0 && scaling.GetMinInstanceCount() > maxCount {
+		return fmt.Errorf("minInstances %d exceeds maxInstances %d", scaling.GetMinInstanceCount(), maxCount)
+	}
+	return nil
+}
+
+// pausedScaling is the annotation payload that records a service's scaling from before a pause
+type pausedScaling struct {
+	Mode            string `json:"mode,omitempty"`
+	MinInstances    int32  `json:"minInstances,omitempty"`
+	MaxInstances    int32  `json:"maxInstances,omitempty"`
+	ManualInstances *int32 `json:"manualInstances,omitempty"`
+}
+
+func encodePausedScaling(scaling *runpb.ServiceScaling) (string, error) {
+	paused := pausedScaling{
+		Mode:         scaling.GetScalingMode().String(),
+		MinInstances: scaling.GetMinInstanceCount(),
+		MaxInstances: scaling.GetMaxInstanceCount(),
+	}
+	if scaling != nil {
+		paused.ManualInstances = scaling.ManualInstanceCount
+	}
+	raw, err := json.Marshal(paused)
+	if err != nil {
+		return "", fmt.Errorf("error recording service scaling: %w", err)
+	}
+	return string(raw), nil
+}
+
+func decodePausedScaling(raw string) (*runpb.ServiceScaling, error) {
+	var paused pausedScaling
+	if err := json.Unmarshal([]byte(raw), &paused); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", pausedScalingAnnotation, err)
+	}
+	scaling := &runpb.ServiceScaling{
+		MinInstanceCount:    paused.MinInstances,
+		MaxInstanceCount:    paused.MaxInstances,
+		ManualInstanceCount: paused.ManualInstances,
+	}
+	if mode, ok := runpb.ServiceScaling_ScalingMode_value[paused.Mode]; ok {
+		scaling.ScalingMode = runpb.ServiceScaling_ScalingMode(mode)
+	}
+	return scaling, nil
+}
+
 func (a Actioner) executionName(executionId string) string {
 	return fmt.Sprintf("%s/executions/%s", a.Infra.JobId, executionId)
 }
@@ -41,3 +41,88 @@ func TestExecutionName(t *testing.T) {
 	a := Actioner{Infra: Outputs{JobId: "projects/p/locations/us-east1/jobs/migrate"}}
 	assert.Equal(t, "projects/p/locations/us-east1/jobs/migrate/executions/migrate-abc12", a.executionName("migrate-abc12"))
 }
+
+func TestPausedScaling(t *testing.T) {
+	manual := int32(2)
+	tests := []struct {
+		name    string
+		scaling *runpb.ServiceScaling
+		want    *runpb.ServiceScaling
+	}{
+		{
+			name:    "no scaling",
+			scaling: nil,
+			want:    &runpb.ServiceScaling{},
+		},
+		{
+			name:    "automatic",
+			scaling: &runpb.ServiceScaling{ScalingMode: runpb.ServiceScaling_AUTOMATIC, MinInstanceCount: 1, MaxInstanceCount: 10},
+			want:    &runpb.ServiceScaling{ScalingMode: runpb.ServiceScaling_AUTOMATIC, MinInstanceCount: 1, MaxInstanceCount: 10},
+		},
+		{
+			name:    "manual",
+			scaling: &runpb.ServiceScaling{ScalingMode: runpb.ServiceScaling_MANUAL, ManualInstanceCount: &manual},
+			want:    &runpb.ServiceScaling{ScalingMode: runpb.ServiceScaling_MANUAL, ManualInstanceCount: &manual},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			raw, err := encodePausedScaling(test.scaling)
+			if assert.NoError(t, err) {
+				got, err := decodePausedScaling(raw)
+				if assert.NoError(t, err) {
+					assert.Equal(t, test.want.GetScalingMode(), got.GetScalingMode())
+					assert.Equal(t, test.want.GetMinInstanceCount(), got.GetMinInstanceCount())
+					assert.Equal(t, test.want.GetMaxInstanceCount(), got.GetMaxInstanceCount())
+					assert.Equal(t, test.want.ManualInstanceCount, got.ManualInstanceCount)
+				}
+			}
+		})
+	}
+}
+
+func TestActioner_CheckScaleLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxScale int32
+		scaling  *runpb.ServiceScaling
+		wantErr  string
+	}{
+		{
+			name:    "no limit",
+			scaling: &runpb.ServiceScaling{MinInstanceCount: 50, MaxInstanceCount: 100},
+		},
+		{
+			name:     "within limit",
+			maxScale: 10,
+			scaling:  &runpb.ServiceScaling{MinInstanceCount: 1, MaxInstanceCount: 10},
+		},
+		{
+			name:     "max exceeds limit",
+			maxScale: 10,
+			scaling:  &runpb.ServiceScaling{MaxInstanceCount: 11},
+			wantErr:  "maxInstances 11 exceeds the max scale limit of 10",
+		},
+		{
+			name:    "negative",
+			scaling: &runpb.ServiceScaling{MinInstanceCount: -1},
+			wantErr: "minInstances must not be negative, got -1",
+		},
+		{
+			name:    "min above max",
+			scaling: &runpb.ServiceScaling{MinInstanceCount: 5, MaxInstanceCount: 2},
+			wantErr: "minInstances 5 exceeds maxInstances 2",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := Actioner{Infra: Outputs{MaxScale: test.maxScale}}
+			err := a.checkScaleLimit(test.scaling)
+			if test.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.wantErr)
+			}
+		})
+	}
+}
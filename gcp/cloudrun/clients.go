@@ -53,6 +53,14 @@ func NewTasksClient(ctx context.Context, account gcp.ServiceAccount) (*run.Tasks
 	return run.NewTasksClient(ctx, option.WithTokenSource(tokenSource))
 }
 
+func NewWorkerPoolsClient(ctx context.Context, account gcp.ServiceAccount) (*run.WorkerPoolsClient, error) {
+	tokenSource, err := account.TokenSource(ctx, GcpScopes...)
+	if err != nil {
+		return nil, err
+	}
+	return run.NewWorkerPoolsClient(ctx, option.WithTokenSource(tokenSource))
+}
+
 func NewMetricClient(ctx context.Context, account gcp.ServiceAccount) (*monitoring.MetricClient, error) {
 	tokenSource, err := account.TokenSource(ctx, GcpScopes...)
 	if err != nil {
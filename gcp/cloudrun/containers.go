@@ -0,0 +1,92 @@
+package cloudrun
+
+import (
+	"fmt"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/nullstone-io/deployment-sdk/app"
+	"github.com/nullstone-io/deployment-sdk/docker"
+	env_vars "github.com/nullstone-io/deployment-sdk/env-vars"
+)
+
+// SidecarContainer is a container in a multi-container template that is deployed alongside the main container
+// Sidecars that aren't listed in the `sidecars` output are left unchanged on deploy
+type SidecarContainer struct {
+	Name string `json:"name"`
+	// ImageRepoUrl is the sidecar's image repository
+	// If empty, the repository of the sidecar's current image is kept
+	ImageRepoUrl docker.ImageUrl `json:"image_repo_url"`
+	// Version pins the sidecar's image tag
+	// If empty, the sidecar is deployed with the app version
+	Version string `json:"version,omitempty"`
+	// EnvVars are set on the sidecar on every deploy
+	EnvVars map[string]string `json:"env_vars,omitempty"`
+}
+
+// updateContainers applies the deploy to the main container and each sidecar in the template's containers
+// kind names the resource in log messages (e.g. service, job, worker pool)
+func (d Deployer) updateContainers(containers []*runpb.Container, meta app.DeployMetadata, kind string) error {
+	stdout := d.OsWriters.Stdout()
+
+	_, mainContainer := GetContainerByName(containers, d.Infra.MainContainerName)
+	if mainContainer == nil {
+		return fmt.Errorf("cannot find main container %q in template", d.Infra.MainContainerName)
+	}
+	SetContainerImageTag(mainContainer, d.Infra.ImageRepoUrl, meta.Version)
+	fmt.Fprintln(stdout, fmt.Sprintf("Updating main container image tag to application version %q in %s", meta.Version, kind))
+	ReplaceEnvVars(mainContainer, env_vars.GetStandard(meta))
+	fmt.Fprintf(stdout, "Updating environment variables in %s\n", kind)
+	if ApplyUserEnvVars(mainContainer, env_vars.ResolveUser(meta)) {
+		fmt.Fprintf(stdout, "Applying additional environment variables from deploy in %s\n", kind)
+	}
+	if ReplaceOtelResourceAttributesEnvVar(mainContainer, meta) {
+		fmt.Fprintf(stdout, "Updating OpenTelemetry resource attributes (service.version and service.commit.sha) in %s\n", kind)
+	}
+
+	for _, sidecar := range d.Infra.Sidecars {
+		if sidecar.Name == d.Infra.MainContainerName {
+			continue
+		}
+		_, container := GetContainerByName(containers, sidecar.Name)
+		if container == nil {
+			return fmt.Errorf("cannot find sidecar container %q in template", sidecar.Name)
+		}
+		version := sidecar.Version
+		if version == "" {
+			version = meta.Version
+		}
+		SetContainerImageTag(container, sidecar.ImageRepoUrl, version)
+		fmt.Fprintf(stdout, "Updating sidecar container %q image tag to %q in %s\n", sidecar.Name, version, kind)
+		ReplaceEnvVars(container, env_vars.GetStandard(meta))
+		ApplyUserEnvVars(container, sidecar.EnvVars)
+		ReplaceOtelResourceAttributesEnvVar(container, meta)
+	}
+	return nil
+}
+
+// RevisionContainer reports the image deployed to one container of a multi-container revision
+type RevisionContainer struct {
+	Name       string `json:"name"`
+	Image      string `json:"image"`
+	AppVersion string `json:"appVersion,omitempty"`
+	// Main is true for the app's main container; the others are sidecars
+	Main bool `json:"main"`
+}
+
+// revisionContainers lists the containers of a multi-container revision
+// Returns nil for single-container revisions; their version is reported on the revision itself
+func revisionContainers(containers []*runpb.Container, mainContainerName string) []RevisionContainer {
+	if len(containers) < 2 {
+		return nil
+	}
+	result := make([]RevisionContainer, 0, len(containers))
+	for _, c := range containers {
+		result = append(result, RevisionContainer{
+			Name:       c.GetName(),
+			Image:      c.GetImage(),
+			AppVersion: docker.ParseImageUrl(c.GetImage()).Tag,
+			Main:       c.GetName() == mainContainerName,
+		})
+	}
+	return result
+}
@@ -0,0 +1,184 @@
+package cloudrun
+
+import (
+	"testing"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/nullstone-io/deployment-sdk/app"
+	"github.com/nullstone-io/deployment-sdk/docker"
+	"github.com/nullstone-io/deployment-sdk/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeployer_UpdateContainers(t *testing.T) {
+	newContainers := func() []*runpb.Container {
+		return []*runpb.Container{
+			{
+				Name:  "main",
+				Image: "us-docker.pkg.dev/p/app/api:v1",
+				Env:   []*runpb.EnvVar{{Name: "NULLSTONE_VERSION", Values: &runpb.EnvVar_Value{Value: "v1"}}},
+			},
+			{
+				Name:  "proxy",
+				Image: "us-docker.pkg.dev/p/app/proxy:v1",
+				Env:   []*runpb.EnvVar{{Name: "NULLSTONE_VERSION", Values: &runpb.EnvVar_Value{Value: "v1"}}},
+			},
+			{Name: "collector", Image: "otel/collector:0.98"},
+		}
+	}
+	meta := app.DeployMetadata{Version: "v2"}
+
+	tests := []struct {
+		name       string
+		sidecars   []SidecarContainer
+		wantImages map[string]string
+		wantErr    string
+	}{
+		{
+			name: "sidecars not listed are unchanged",
+			wantImages: map[string]string{
+				"main":      "us-docker.pkg.dev/p/app/api:v2",
+				"proxy":     "us-docker.pkg.dev/p/app/proxy:v1",
+				"collector": "otel/collector:0.98",
+			},
+		},
+		{
+			name:     "sidecar follows app version",
+			sidecars: []SidecarContainer{{Name: "proxy"}},
+			wantImages: map[string]string{
+				"main":      "us-docker.pkg.dev/p/app/api:v2",
+				"proxy":     "us-docker.pkg.dev/p/app/proxy:v2",
+				"collector": "otel/collector:0.98",
+			},
+		},
+		{
+			name: "sidecar with pinned version and repo",
+			sidecars: []SidecarContainer{
+				{Name: "collector", ImageRepoUrl: docker.ParseImageUrl("otel/opentelemetry-collector"), Version: "0.100"},
+			},
+			wantImages: map[string]string{
+				"main":      "us-docker.pkg.dev/p/app/api:v2",
+				"proxy":     "us-docker.pkg.dev/p/app/proxy:v1",
+				"collector": "otel/opentelemetry-collector:0.100",
+			},
+		},
+		{
+			name:     "missing sidecar",
+			sidecars: []SidecarContainer{{Name: "cache"}},
+			wantErr:  `cannot find sidecar container "cache" in template`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := Deployer{
+				OsWriters: logging.StandardOsWriters{},
+				Infra:     Outputs{MainContainerName: "main", Sidecars: test.sidecars},
+			}
+			containers := newContainers()
+			err := d.updateContainers(containers, meta, "service")
+			if test.wantErr != "" {
+				assert.EqualError(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			got := map[string]string{}
+			for _, c := range containers {
+				got[c.Name] = c.Image
+			}
+			assert.Equal(t, test.wantImages, got)
+		})
+	}
+
+	t.Run("sidecar env vars", func(t *testing.T) {
+		d := Deployer{
+			OsWriters: logging.StandardOsWriters{},
+			Infra: Outputs{
+				MainContainerName: "main",
+				Sidecars:          []SidecarContainer{{Name: "proxy", EnvVars: map[string]string{"UPSTREAM": "localhost:8080"}}},
+			},
+		}
+		containers := newContainers()
+		require.NoError(t, d.updateContainers(containers, meta, "service"))
+		env := map[string]string{}
+		for _, e := range containers[1].Env {
+			env[e.Name] = e.GetValue()
+		}
+		assert.Equal(t, map[string]string{"NULLSTONE_VERSION": "v2", "UPSTREAM": "localhost:8080"}, env)
+	})
+}
+
+func TestRevisionContainers(t *testing.T) {
+	assert.Nil(t, revisionContainers([]*runpb.Container{{Name: "main", Image: "api:v1"}}, "main"))
+
+	got := revisionContainers([]*runpb.Container{
+		{Name: "main", Image: "us-docker.pkg.dev/p/app/api:v2"},
+		{Name: "proxy", Image: "us-docker.pkg.dev/p/app/proxy:v1"},
+	}, "main")
+	assert.Equal(t, []RevisionContainer{
+		{Name: "main", Image: "us-docker.pkg.dev/p/app/api:v2", AppVersion: "v2", Main: true},
+		{Name: "proxy", Image: "us-docker.pkg.dev/p/app/proxy:v1", AppVersion: "v1"},
+	}, got)
+}
+
+func TestMapWorkerPool(t *testing.T) {
+	instances := func(n int32) *runpb.WorkerPoolScaling {
+		return &runpb.WorkerPoolScaling{ManualInstanceCount: &n}
+	}
+	template := &runpb.WorkerPoolRevisionTemplate{
+		Containers: []*runpb.Container{{Name: "main", Image: "us-docker.pkg.dev/p/app/worker:v3"}},
+	}
+
+	tests := []struct {
+		name      string
+		pool      *runpb.WorkerPool
+		wantState ServiceState
+		wantCode  FailureCode
+	}{
+		{
+			name:      "healthy",
+			pool:      &runpb.WorkerPool{Name: "projects/p/locations/us-east1/workerPools/worker", Template: template, Scaling: instances(2)},
+			wantState: ServiceStateHealthy,
+		},
+		{
+			name:      "scaled to zero",
+			pool:      &runpb.WorkerPool{Template: template, Scaling: instances(0)},
+			wantState: ServiceStateCold,
+		},
+		{
+			name:      "no scaling",
+			pool:      &runpb.WorkerPool{Template: template},
+			wantState: ServiceStateCold,
+		},
+		{
+			name:      "reconciling",
+			pool:      &runpb.WorkerPool{Template: template, Scaling: instances(2), Reconciling: true},
+			wantState: ServiceStateScaling,
+		},
+		{
+			name: "failed",
+			pool: &runpb.WorkerPool{
+				Template:          template,
+				Scaling:           instances(2),
+				TerminalCondition: &runpb.Condition{State: runpb.Condition_CONDITION_FAILED, Message: "container exited"},
+			},
+			wantState: ServiceStateFailing,
+			wantCode:  FailureContainerFailedToStart,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := mapWorkerPool(test.pool, "main")
+			assert.Equal(t, test.wantState, got.State)
+			assert.Equal(t, "v3", got.AppVersion)
+			if test.wantCode != "" {
+				require.NotNil(t, got.Failure)
+				assert.Equal(t, test.wantCode, got.Failure.Code)
+			} else {
+				assert.Nil(t, got.Failure)
+			}
+		})
+	}
+}
@@ -2,8 +2,12 @@ package cloudrun
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	"cloud.google.com/go/longrunning/autogen/longrunningpb"
 	"github.com/nullstone-io/deployment-sdk/app"
 	"github.com/nullstone-io/deployment-sdk/logging"
 	"github.com/nullstone-io/deployment-sdk/outputs"
@@ -31,8 +35,17 @@ func NewDeployWatcher(ctx context.Context, osWriters logging.OsWriters, source o
 				Infra:     outs,
 			},
 		}, nil
+	} else if outs.WorkerPoolId != "" {
+		return &app.PollingDeployWatcher{
+			OsWriters: osWriters,
+			StatusGetter: &WorkerPoolDeployLogger{
+				OsWriters: osWriters,
+				Details:   appDetails,
+				Infra:     outs,
+			},
+		}, nil
 	} else {
-		return nil, fmt.Errorf("cannot watch deployment, no service_id or job_id in app module")
+		return nil, fmt.Errorf("cannot watch deployment, no service_id, job_id, or worker_pool_id in app module")
 	}
 }
 
@@ -44,8 +57,57 @@ type ServiceDeployWatcher struct {
 	Infra     Outputs
 }
 
+// Watch waits for the service update operations in reference to finish
+// A multi-region deploy joins each region's operation in reference; all of them must succeed
 func (s ServiceDeployWatcher) Watch(ctx context.Context, reference string, isFirstDeploy bool) error {
-	stderr := s.OsWriters.Stderr()
-	fmt.Fprintln(stderr, "Nullstone does not support waiting for a healthy Cloud Run service.")
+	stdout, stderr := s.OsWriters.Stdout(), s.OsWriters.Stderr()
+
+	if reference == "" {
+		fmt.Fprintf(stdout, "This deployment does not have to wait for any resource to become healthy.\n")
+		return nil
+	}
+
+	client, err := NewServicesClient(ctx, s.Infra.Deployer)
+	if err != nil {
+		return fmt.Errorf("error initializing cloud run services client: %w", err)
+	}
+	defer client.Close()
+
+	delay := 5 * time.Second
+	timeout := 15 * time.Minute
+
+	t1 := time.After(timeout)
+	for _, opName := range strings.Split(reference, operationSeparator) {
+		region := parseLocation(opName).Region
+		fmt.Fprintf(stdout, "Waiting for Cloud Run service in %q to become ready...\n", region)
+		for {
+			op, err := client.GetOperation(ctx, &longrunningpb.GetOperationRequest{Name: opName})
+			if err != nil {
+				return fmt.Errorf("error getting operation status: %w", err)
+			}
+			if op.Done {
+				if operr := op.GetError(); operr != nil {
+					fmt.Fprintf(stderr, "Deployment failed in %q: %s\n", region, operr.Message)
+					return app.ErrFailed
+				}
+				fmt.Fprintf(stdout, "Cloud Run service in %q is ready\n", region)
+				break
+			}
+
+			select {
+			case <-ctx.Done():
+				if cerr := ctx.Err(); cerr != nil {
+					if errors.Is(cerr, context.DeadlineExceeded) {
+						return app.ErrTimeout
+					}
+					return &app.CancelError{Reason: cerr.Error()}
+				}
+				return &app.CancelError{}
+			case <-t1:
+				return app.ErrTimeout
+			case <-time.After(delay):
+			}
+		}
+	}
 	return nil
 }
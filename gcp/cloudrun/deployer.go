@@ -3,12 +3,12 @@ package cloudrun
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"cloud.google.com/go/run/apiv2/runpb"
 	"github.com/mitchellh/colorstring"
 	"github.com/nullstone-io/deployment-sdk/app"
 	"github.com/nullstone-io/deployment-sdk/docker"
-	env_vars "github.com/nullstone-io/deployment-sdk/env-vars"
 	"github.com/nullstone-io/deployment-sdk/logging"
 	"github.com/nullstone-io/deployment-sdk/otel"
 	"github.com/nullstone-io/deployment-sdk/outputs"
@@ -38,7 +38,13 @@ func (d Deployer) Print() {
 	stdout, _ := d.OsWriters.Stdout(), d.OsWriters.Stderr()
 	colorstring.Fprintln(stdout, "[bold]Retrieved Cloud Run service outputs")
 	fmt.Fprintf(stdout, "\tservice_id:     %s\n", d.Infra.ServiceId)
+	if len(d.Infra.ServiceIds) > 0 {
+		fmt.Fprintf(stdout, "\tservice_ids:    %s\n", strings.Join(d.Infra.ServiceIds, ", "))
+	}
 	fmt.Fprintf(stdout, "\tjob_id:         %s\n", d.Infra.JobId)
+	if d.Infra.WorkerPoolId != "" {
+		fmt.Fprintf(stdout, "\tworker_pool_id: %s\n", d.Infra.WorkerPoolId)
+	}
 	fmt.Fprintf(stdout, "\timage_repo_url: %s\n", d.Infra.ImageRepoUrl)
 }
 
@@ -53,11 +59,16 @@ func (d Deployer) Deploy(ctx context.Context, meta app.DeployMetadata) (string,
 	fmt.Fprintln(stdout)
 	fmt.Fprintf(stdout, "Deploying app %q\n", d.Details.App.Name)
 	if d.Infra.ServiceId != "" {
+		if serviceIds := d.Infra.RegionalServiceIds(); len(serviceIds) > 1 {
+			return d.deployRegions(ctx, meta, serviceIds)
+		}
 		return d.deployService(ctx, meta)
 	} else if d.Infra.JobId != "" {
 		return d.deployJob(ctx, meta)
+	} else if d.Infra.WorkerPoolId != "" {
+		return d.deployWorkerPool(ctx, meta)
 	} else {
-		fmt.Fprintf(stdout, "No service_id or job_id in app module. Skipping deployment.\n")
+		fmt.Fprintf(stdout, "No service_id, job_id, or worker_pool_id in app module. Skipping deployment.\n")
 		return "", nil
 	}
 }
@@ -77,28 +88,20 @@ func (d Deployer) deployService(ctx context.Context, meta app.DeployMetadata) (s
 		return "", fmt.Errorf("cloud run service %q not found", d.Infra.ServiceId)
 	}
 
-	mainContainerIndex, mainContainer := GetContainerByName(svc.Template.Containers, d.Infra.MainContainerName)
-	if mainContainerIndex < 0 {
-		return "", fmt.Errorf("cannot find main container %q in template", d.Infra.MainContainerName)
-	}
-	SetContainerImageTag(mainContainer, d.Infra.ImageRepoUrl, meta.Version)
-	fmt.Fprintln(stdout, fmt.Sprintf("Updating main container image tag to application version %q in service", meta.Version))
-	ReplaceEnvVars(mainContainer, env_vars.GetStandard(meta))
-	fmt.Fprintln(stdout, "Updating environment variables in service")
-	if ApplyUserEnvVars(mainContainer, env_vars.ResolveUser(meta)) {
-		fmt.Fprintln(stdout, "Applying additional environment variables from deploy in service")
+	if err := d.updateContainers(svc.Template.Containers, meta, "service"); err != nil {
+		return "", err
 	}
-	if ReplaceOtelResourceAttributesEnvVar(mainContainer, meta) {
-		fmt.Fprintln(d.OsWriters.Stdout(), "Updating OpenTelemetry resource attributes (service.version and service.commit.sha) in service")
+
+	if d.Infra.Rollout != nil {
+		return d.rolloutService(ctx, client, svc, *d.Infra.Rollout)
 	}
-	svc.Template.Containers[mainContainerIndex] = mainContainer
 
-	op, err := client.UpdateService(ctx, &runpb.UpdateServiceRequest{Service: svc})
+	ref, err := d.updateService(ctx, client, svc)
 	if err != nil {
 		return "", err
 	}
 	fmt.Fprintln(stdout, "Updated service successfully")
-	return op.Name(), nil
+	return ref, nil
 }
 
 func (d Deployer) deployJob(ctx context.Context, meta app.DeployMetadata) (string, error) {
@@ -116,21 +119,9 @@ func (d Deployer) deployJob(ctx context.Context, meta app.DeployMetadata) (strin
 		return "", fmt.Errorf("cloud run job %q not found", d.Infra.JobId)
 	}
 
-	mainContainerIndex, mainContainer := GetContainerByName(job.Template.Template.Containers, d.Infra.MainContainerName)
-	if mainContainerIndex < 0 {
-		return "", fmt.Errorf("cannot find main container %q in template", d.Infra.MainContainerName)
-	}
-	SetContainerImageTag(mainContainer, d.Infra.ImageRepoUrl, meta.Version)
-	fmt.Fprintln(stdout, fmt.Sprintf("Updating main container image tag to application version %q in job", meta.Version))
-	ReplaceEnvVars(mainContainer, env_vars.GetStandard(meta))
-	fmt.Fprintln(stdout, "Updating environment variables in job")
-	if ApplyUserEnvVars(mainContainer, env_vars.ResolveUser(meta)) {
-		fmt.Fprintln(stdout, "Applying additional environment variables from deploy in job")
-	}
-	if ReplaceOtelResourceAttributesEnvVar(mainContainer, meta) {
-		fmt.Fprintln(d.OsWriters.Stdout(), "Updating OpenTelemetry resource attributes (service.version and service.commit.sha) in job")
+	if err := d.updateContainers(job.Template.Template.Containers, meta, "job"); err != nil {
+		return "", err
 	}
-	job.Template.Template.Containers[mainContainerIndex] = mainContainer
 
 	op, err := client.UpdateJob(ctx, &runpb.UpdateJobRequest{Job: job})
 	if err != nil {
@@ -0,0 +1,215 @@
+package cloudrun
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/nullstone-io/deployment-sdk/app"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const (
+	defaultExecuteJobPollInterval = 5 * time.Second
+	// executeJobLogFlushDelay gives Cloud Logging a moment to ingest the final log entries after an execution completes
+	executeJobLogFlushDelay = 5 * time.Second
+)
+
+type ExecuteJobOptions struct {
+	// Args overrides the arguments of the job's container
+	Args []string
+	// Environment is merged into the environment variables of the job's container
+	Environment map[string]string
+	// Container is the container that receives the overrides
+	// If empty, the app's main container is used, or the only container in the job template if the app has no main container
+	Container string
+	// TaskCount overrides the number of tasks in the execution
+	TaskCount *int32
+	// TaskTimeout overrides how long each task may run before it is failed
+	TaskTimeout time.Duration
+
+	// LogStreamer follows the execution's logs while it runs (typically a cloudlogging.LogStreamer)
+	// If nil, logs are not followed
+	LogStreamer app.LogStreamer
+	// LogEmitter receives log messages when following logs
+	LogEmitter app.LogEmitter
+
+	// PollInterval dictates how often the execution is polled for its status (default 5s)
+	PollInterval time.Duration
+}
+
+// ExecuteJob runs a new execution of the job workspace's job with the requested overrides,
+// then waits for the execution to complete and reports its phase and per-task exit codes.
+// If ctx is cancelled before the execution completes, the execution is left running and ctx.Err() is returned
+// along with the execution as it was last observed.
+func ExecuteJob(ctx context.Context, infra Outputs, options ExecuteJobOptions) (*JobExecution, error) {
+	client, err := NewJobsClient(ctx, infra.Deployer)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing cloud run jobs client: %w", err)
+	}
+	defer client.Close()
+
+	if options.Container == "" && infra.MainContainerName == "" && hasContainerOverrides(options) {
+		job, err := client.GetJob(ctx, &runpb.GetJobRequest{Name: infra.JobId})
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving job %q: %w", infra.JobId, err)
+		}
+		if containers := job.GetTemplate().GetTemplate().GetContainers(); len(containers) == 1 {
+			options.Container = containers[0].GetName()
+		}
+	}
+	overrides, err := buildJobOverrides(infra, options)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := client.RunJob(ctx, &runpb.RunJobRequest{
+		Name:      infra.JobId,
+		Overrides: overrides,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error running job %q: %w", infra.JobId, err)
+	}
+	exec, err := op.Metadata()
+	if err != nil {
+		return nil, fmt.Errorf("error reading execution from run job operation: %w", err)
+	}
+	if exec.GetName() == "" {
+		return nil, fmt.Errorf("run job operation %q did not report an execution", op.Name())
+	}
+
+	s := Statuser{Infra: infra}
+	result := s.mapExecution(exec)
+	exec, err = waitForExecution(ctx, infra, exec.GetName(), options)
+	if err != nil {
+		return &result, err
+	}
+
+	result = s.mapExecution(exec)
+	tasks, diag, err := s.listExecutionTasks(ctx, exec.GetName())
+	if err != nil {
+		return &result, err
+	}
+	result.Tasks = tasks
+	result.Failure = deriveExecutionFailure(result, diag)
+	return &result, nil
+}
+
+// buildJobOverrides translates the requested overrides into a RunJob override
+// Returns nil when nothing is overridden so the execution uses the job's template as-is
+// Args and environment overrides require a container name (options.Container or the app's main container)
+func buildJobOverrides(infra Outputs, options ExecuteJobOptions) (*runpb.RunJobRequest_Overrides, error) {
+	overrides := &runpb.RunJobRequest_Overrides{}
+	hasOverrides := false
+	if hasContainerOverrides(options) {
+		container := options.Container
+		if container == "" {
+			container = infra.MainContainerName
+		}
+		if container == "" {
+			return nil, fmt.Errorf("a container name is required to override args or environment of job %q", infra.JobName())
+		}
+		co := &runpb.RunJobRequest_Overrides_ContainerOverride{
+			Name: container,
+			Args: options.Args,
+		}
+		names := make([]string, 0, len(options.Environment))
+		for name := range options.Environment {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			co.Env = append(co.Env, &runpb.EnvVar{
+				Name:   name,
+				Values: &runpb.EnvVar_Value{Value: options.Environment[name]},
+			})
+		}
+		overrides.ContainerOverrides = []*runpb.RunJobRequest_Overrides_ContainerOverride{co}
+		hasOverrides = true
+	}
+	if options.TaskCount != nil {
+		overrides.TaskCount = *options.TaskCount
+		hasOverrides = true
+	}
+	if options.TaskTimeout > 0 {
+		overrides.Timeout = durationpb.New(options.TaskTimeout)
+		hasOverrides = true
+	}
+	if !hasOverrides {
+		return nil, nil
+	}
+	return overrides, nil
+}
+
+func hasContainerOverrides(options ExecuteJobOptions) bool {
+	return len(options.Args) > 0 || len(options.Environment) > 0
+}
+
+// waitForExecution polls the execution until it completes
+// Once the execution has started, its logs are followed until shortly after it completes
+func waitForExecution(ctx context.Context, infra Outputs, executionName string, options ExecuteJobOptions) (*runpb.Execution, error) {
+	pollInterval := options.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = defaultExecuteJobPollInterval
+	}
+
+	client, err := NewExecutionsClient(ctx, infra.Deployer)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing cloud run executions client: %w", err)
+	}
+	defer client.Close()
+
+	logsCtx, cancelLogs := context.WithCancel(ctx)
+	defer cancelLogs()
+	logsDone := make(chan error, 1)
+	followingLogs := false
+	startLogs := func() {
+		if followingLogs || options.LogStreamer == nil {
+			return
+		}
+		followingLogs = true
+		startTime := time.Now().Add(-time.Minute)
+		go func() {
+			logsDone <- options.LogStreamer.Stream(logsCtx, app.LogStreamOptions{
+				StartTime: &startTime,
+				Execution: shortName(executionName),
+				Emitter:   options.LogEmitter,
+			})
+		}()
+	}
+	stopLogs := func() {
+		if !followingLogs {
+			return
+		}
+		select {
+		case <-time.After(executeJobLogFlushDelay):
+		case <-ctx.Done():
+		}
+		cancelLogs()
+		<-logsDone
+	}
+
+	for {
+		exec, err := client.GetExecution(ctx, &runpb.GetExecutionRequest{Name: executionName})
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving execution %q: %w", shortName(executionName), err)
+		}
+		if exec.GetCompletionTime() != nil {
+			// Executions that complete quickly may finish between polls; their logs are still worth reading
+			startLogs()
+			stopLogs()
+			return exec, nil
+		}
+		if exec.GetStartTime() != nil || exec.GetRunningCount() > 0 {
+			startLogs()
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
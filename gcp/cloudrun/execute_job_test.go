@@ -0,0 +1,72 @@
+package cloudrun
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestBuildJobOverrides(t *testing.T) {
+	infra := Outputs{MainContainerName: "main"}
+	three := int32(3)
+
+	tests := []struct {
+		name    string
+		options ExecuteJobOptions
+		want    *runpb.RunJobRequest_Overrides
+	}{
+		{
+			name:    "no overrides",
+			options: ExecuteJobOptions{},
+			want:    nil,
+		},
+		{
+			name: "args and env on main container",
+			options: ExecuteJobOptions{
+				Args:        []string{"migrate", "--dry-run"},
+				Environment: map[string]string{"LOG_LEVEL": "debug", "DB_POOL": "2"},
+			},
+			want: &runpb.RunJobRequest_Overrides{
+				ContainerOverrides: []*runpb.RunJobRequest_Overrides_ContainerOverride{
+					{
+						Name: "main",
+						Args: []string{"migrate", "--dry-run"},
+						Env: []*runpb.EnvVar{
+							{Name: "DB_POOL", Values: &runpb.EnvVar_Value{Value: "2"}},
+							{Name: "LOG_LEVEL", Values: &runpb.EnvVar_Value{Value: "debug"}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "explicit container",
+			options: ExecuteJobOptions{Args: []string{"seed"}, Container: "worker"},
+			want: &runpb.RunJobRequest_Overrides{
+				ContainerOverrides: []*runpb.RunJobRequest_Overrides_ContainerOverride{{Name: "worker", Args: []string{"seed"}}},
+			},
+		},
+		{
+			name:    "task count and timeout",
+			options: ExecuteJobOptions{TaskCount: &three, TaskTimeout: 10 * time.Minute},
+			want: &runpb.RunJobRequest_Overrides{
+				TaskCount: 3,
+				Timeout:   durationpb.New(10 * time.Minute),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := buildJobOverrides(infra, test.options)
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+
+	_, err := buildJobOverrides(Outputs{JobId: "projects/p/locations/us-east1/jobs/migrate"}, ExecuteJobOptions{Args: []string{"seed"}})
+	assert.EqualError(t, err, `a container name is required to override args or environment of job "migrate"`)
+}
@@ -18,12 +18,26 @@ type Outputs struct {
 	ImageRepoUrl      docker.ImageUrl    `ns:"image_repo_url,optional"`
 	Deployer          gcp.ServiceAccount `ns:"deployer"`
 	MainContainerName string             `ns:"main_container_name,optional"`
+	// MaxScale limits how many instances the scale/resume actions may request (0 = no limit)
+	MaxScale int32 `ns:"max_scale,optional"`
+	// ServiceIds lists every regional service when the same service runs in multiple regions (e.g. behind a global load balancer)
+	// ServiceId is the primary region's service and is expected to be one of ServiceIds
+	ServiceIds []string `ns:"service_ids,optional"`
+	// RegionalDeployStrategy controls how a deploy proceeds across ServiceIds: `sequential` (default) or `parallel`
+	RegionalDeployStrategy string `ns:"regional_deploy_strategy,optional"`
+	// Rollout enables progressive delivery for service deploys
+	// If unset, a deploy sends all traffic to the new revision as soon as it's ready
+	Rollout *RolloutConfig `ns:"rollout,optional"`
+	// WorkerPoolId identifies a Cloud Run worker pool (a deploy target for non-request workloads), used when there is no service_id or job_id
+	WorkerPoolId string `ns:"worker_pool_id,optional"`
+	// Sidecars lists the containers besides the main container that are deployed with the app
+	Sidecars []SidecarContainer `ns:"sidecars,optional"`
 }
 
 // Location returns the project and region for this workspace. When the
 // project_id/region outputs are absent, it falls back to parsing them from the
-// service_id/job_id, which use the form
-// projects/{project}/locations/{region}/{services|jobs}/{name}.
+// service_id/job_id/worker_pool_id, which use the form
+// projects/{project}/locations/{region}/{services|jobs|workerPools}/{name}.
 func (o *Outputs) Location() LocationInfo {
 	loc := LocationInfo{ProjectId: o.ProjectId, Region: o.Region}
 	if loc.ProjectId != "" && loc.Region != "" {
@@ -33,7 +47,24 @@ func (o *Outputs) Location() LocationInfo {
 	if id == "" {
 		id = o.JobId
 	}
-	parts := strings.Split(id, "/")
+	if id == "" {
+		id = o.WorkerPoolId
+	}
+	parsed := parseLocation(id)
+	if loc.ProjectId == "" {
+		loc.ProjectId = parsed.ProjectId
+	}
+	if loc.Region == "" {
+		loc.Region = parsed.Region
+	}
+	return loc
+}
+
+// parseLocation parses the project and region from a resource name of the form
+// projects/{project}/locations/{region}/...
+func parseLocation(resourceName string) LocationInfo {
+	var loc LocationInfo
+	parts := strings.Split(resourceName, "/")
 	for i := 0; i+1 < len(parts); i++ {
 		switch parts[i] {
 		case "projects":
@@ -65,6 +96,12 @@ func (o *Outputs) JobName() string {
 	return shortName(o.JobId)
 }
 
+// WorkerPoolName returns the bare worker pool name parsed from worker_pool_id,
+// which uses the form projects/{project}/locations/{region}/workerPools/{name}.
+func (o *Outputs) WorkerPoolName() string {
+	return shortName(o.WorkerPoolId)
+}
+
 func (o *Outputs) InitializeCreds(source outputs.RetrieverSource, ws *types.Workspace) {
 	o.Deployer.RemoteTokenSourcer = creds.NewTokenSourcer(source, ws.StackId, ws.BlockId, ws.EnvId, types.AutomationPurposeDeploy, "deployer")
 }
@@ -0,0 +1,169 @@
+package cloudrun
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/nullstone-io/deployment-sdk/app"
+)
+
+// RegionalDeployStrategy is how a deploy proceeds across the regional services of a multi-region app
+type RegionalDeployStrategy string
+
+const (
+	// RegionalDeploySequential deploys one region at a time in the order of ServiceIds and waits for each region to become healthy
+	// The first region acts as a canary: a failure stops the deploy before it reaches the remaining regions
+	RegionalDeploySequential RegionalDeployStrategy = "sequential"
+	// RegionalDeployParallel deploys every region at once
+	RegionalDeployParallel RegionalDeployStrategy = "parallel"
+
+	// operationSeparator joins the operations of each regional deploy into a single deploy reference
+	operationSeparator = ","
+)
+
+func ParseRegionalDeployStrategy(s string) (RegionalDeployStrategy, error) {
+	switch RegionalDeployStrategy(strings.ToLower(s)) {
+	case "", RegionalDeploySequential:
+		return RegionalDeploySequential, nil
+	case RegionalDeployParallel:
+		return RegionalDeployParallel, nil
+	}
+	return "", fmt.Errorf("invalid regional_deploy_strategy %q (must be %s or %s)", s, RegionalDeploySequential, RegionalDeployParallel)
+}
+
+// RegionalServiceIds returns the service in each region, starting with the primary service (ServiceId)
+func (o *Outputs) RegionalServiceIds() []string {
+	ids := make([]string, 0, len(o.ServiceIds)+1)
+	if o.ServiceId != "" {
+		ids = append(ids, o.ServiceId)
+	}
+	for _, id := range o.ServiceIds {
+		if id != "" && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// ForService returns a copy of the outputs scoped to a single regional service
+// The region is parsed from the service id rather than the region output, which names the primary region
+func (o Outputs) ForService(serviceId string) Outputs {
+	o.ServiceId = serviceId
+	o.ServiceIds = nil
+	o.Region = ""
+	return o
+}
+
+// deployRegions deploys every regional service according to the regional deploy strategy
+// The returned reference joins each region's operation so that ServiceDeployWatcher can wait on all of them
+func (d Deployer) deployRegions(ctx context.Context, meta app.DeployMetadata, serviceIds []string) (string, error) {
+	stdout := d.OsWriters.Stdout()
+	strategy, err := ParseRegionalDeployStrategy(d.Infra.RegionalDeployStrategy)
+	if err != nil {
+		return "", err
+	}
+
+	regions := make([]string, 0, len(serviceIds))
+	for _, id := range serviceIds {
+		regions = append(regions, parseLocation(id).Region)
+	}
+	fmt.Fprintf(stdout, "Deploying to %d regions (%s): %s\n", len(serviceIds), strategy, strings.Join(regions, ", "))
+
+	if strategy == RegionalDeployParallel {
+		refs := make([]string, len(serviceIds))
+		errs := make([]error, len(serviceIds))
+		var wg sync.WaitGroup
+		for i, id := range serviceIds {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				regional := Deployer{OsWriters: d.OsWriters, Details: d.Details, Infra: d.Infra.ForService(id)}
+				if refs[i], errs[i] = regional.deployService(ctx, meta); errs[i] != nil {
+					errs[i] = fmt.Errorf("error deploying to region %q: %w", regions[i], errs[i])
+				}
+			}()
+		}
+		wg.Wait()
+		if err := errors.Join(errs...); err != nil {
+			return "", err
+		}
+		return strings.Join(refs, operationSeparator), nil
+	}
+
+	refs := make([]string, 0, len(serviceIds))
+	for i, id := range serviceIds {
+		fmt.Fprintf(stdout, "Deploying to region %q (%d/%d)\n", regions[i], i+1, len(serviceIds))
+		regional := Deployer{OsWriters: d.OsWriters, Details: d.Details, Infra: d.Infra.ForService(id)}
+		ref, err := regional.deployService(ctx, meta)
+		if err != nil {
+			return "", fmt.Errorf("error deploying to region %q: %w", regions[i], err)
+		}
+		refs = append(refs, ref)
+		// The last region is watched by the caller along with the rest
+		if i < len(serviceIds)-1 {
+			watcher := ServiceDeployWatcher{OsWriters: d.OsWriters, Details: d.Details, Infra: regional.Infra}
+			if err := watcher.Watch(ctx, ref, false); err != nil {
+				return "", fmt.Errorf("region %q did not become healthy, skipping remaining regions: %w", regions[i], err)
+			}
+		}
+	}
+	return strings.Join(refs, operationSeparator), nil
+}
+
+// statusRegions retrieves the status of each regional service concurrently
+// A region that fails to report is recorded with its error rather than failing the whole status
+func (s Statuser) statusRegions(ctx context.Context, serviceIds []string, enrich bool) []RegionalService {
+	result := make([]RegionalService, len(serviceIds))
+	var wg sync.WaitGroup
+	for i, id := range serviceIds {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			regional := Statuser{OsWriters: s.OsWriters, Details: s.Details, Infra: s.Infra.ForService(id)}
+			result[i].Location = regional.Infra.Location()
+			svc, err := regional.statusService(ctx)
+			if err != nil {
+				result[i].Error = err.Error()
+				return
+			}
+			if enrich {
+				regional.enrichServiceMetrics(ctx, svc)
+			}
+			result[i].Service = svc
+		}()
+	}
+	wg.Wait()
+	return result
+}
+
+// serviceStateSeverity orders service states from healthiest to least healthy for aggregation across regions
+var serviceStateSeverity = []ServiceState{
+	ServiceStateHealthy,
+	ServiceStateCold,
+	ServiceStateScaling,
+	ServiceStateDegraded,
+	ServiceStateFailing,
+}
+
+// AggregateServiceState returns the least healthy state across regions
+// A region whose status couldn't be retrieved counts as Degraded
+func AggregateServiceState(regions []RegionalService) ServiceState {
+	worst := -1
+	for _, region := range regions {
+		state := ServiceStateDegraded
+		if region.Service != nil {
+			state = region.Service.State
+		}
+		if idx := slices.Index(serviceStateSeverity, state); idx > worst {
+			worst = idx
+		}
+	}
+	if worst < 0 {
+		return ""
+	}
+	return serviceStateSeverity[worst]
+}
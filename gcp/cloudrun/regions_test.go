@@ -0,0 +1,100 @@
+package cloudrun
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRegionalDeployStrategy(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    RegionalDeployStrategy
+		wantErr string
+	}{
+		{input: "", want: RegionalDeploySequential},
+		{input: "sequential", want: RegionalDeploySequential},
+		{input: "Parallel", want: RegionalDeployParallel},
+		{input: "rolling", wantErr: `invalid regional_deploy_strategy "rolling" (must be sequential or parallel)`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			got, err := ParseRegionalDeployStrategy(test.input)
+			if test.wantErr != "" {
+				assert.EqualError(t, err, test.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestOutputsRegionalServiceIds(t *testing.T) {
+	east := "projects/p/locations/us-east1/services/api"
+	west := "projects/p/locations/us-west1/services/api"
+	eu := "projects/p/locations/europe-west1/services/api"
+
+	t.Run("single region", func(t *testing.T) {
+		o := Outputs{ServiceId: east}
+		assert.Equal(t, []string{east}, o.RegionalServiceIds())
+	})
+	t.Run("primary first without duplicates", func(t *testing.T) {
+		o := Outputs{ServiceId: west, ServiceIds: []string{east, west, eu}}
+		assert.Equal(t, []string{west, east, eu}, o.RegionalServiceIds())
+	})
+	t.Run("job workspace", func(t *testing.T) {
+		o := Outputs{JobId: "projects/p/locations/us-east1/jobs/migrate"}
+		assert.Empty(t, o.RegionalServiceIds())
+	})
+}
+
+func TestOutputsForService(t *testing.T) {
+	o := Outputs{
+		ProjectId:  "p",
+		Region:     "us-east1",
+		ServiceId:  "projects/p/locations/us-east1/services/api",
+		ServiceIds: []string{"projects/p/locations/us-east1/services/api", "projects/p/locations/europe-west1/services/api"},
+	}
+	regional := o.ForService("projects/p/locations/europe-west1/services/api")
+	assert.Equal(t, "projects/p/locations/europe-west1/services/api", regional.ServiceId)
+	assert.Empty(t, regional.ServiceIds)
+	assert.Equal(t, LocationInfo{ProjectId: "p", Region: "europe-west1"}, regional.Location())
+	assert.Equal(t, "api", regional.ServiceName())
+}
+
+func TestAggregateServiceState(t *testing.T) {
+	region := func(state ServiceState) RegionalService {
+		return RegionalService{Service: &Service{State: state}}
+	}
+	tests := []struct {
+		name    string
+		regions []RegionalService
+		want    ServiceState
+	}{
+		{name: "all healthy", regions: []RegionalService{region(ServiceStateHealthy), region(ServiceStateHealthy)}, want: ServiceStateHealthy},
+		{name: "one cold", regions: []RegionalService{region(ServiceStateHealthy), region(ServiceStateCold)}, want: ServiceStateCold},
+		{name: "one failing", regions: []RegionalService{region(ServiceStateFailing), region(ServiceStateDegraded)}, want: ServiceStateFailing},
+		{name: "unreachable region", regions: []RegionalService{region(ServiceStateHealthy), {Error: "permission denied"}}, want: ServiceStateDegraded},
+		{name: "no regions", want: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, AggregateServiceState(test.regions))
+		})
+	}
+}
+
+func TestServiceOverview(t *testing.T) {
+	svc := &Service{Revisions: []Revision{
+		{Name: "api-00003", Role: RevisionRoleLatest, AppVersion: "v3"},
+		{Name: "api-00002", Role: RevisionRolePrior, TrafficPercent: 100, AppVersion: "v2"},
+		{Name: "api-00001", Role: RevisionRoleIdle, AppVersion: "v1"},
+	}}
+	count, split, versions := serviceOverview(svc)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, []TrafficSplitEntry{{RevisionName: "api-00003"}, {RevisionName: "api-00002", TrafficPercent: 100}}, split)
+	assert.Equal(t, []string{"v3", "v2"}, versions)
+}
@@ -0,0 +1,408 @@
+package cloudrun
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	run "cloud.google.com/go/run/apiv2"
+	"cloud.google.com/go/run/apiv2/runpb"
+)
+
+const (
+	// defaultRolloutTag is the revision tag given to the new revision when the rollout config doesn't name one
+	defaultRolloutTag = "candidate"
+	// rolloutRevertTimeout bounds how long reverting traffic may take after a failed or cancelled rollout
+	rolloutRevertTimeout = 5 * time.Minute
+	// minRolloutBakeSeconds is the shortest bake for a step whose error rate is checked
+	// Cloud Monitoring reports request counts per minute, so a shorter bake has no metrics for the new revision
+	minRolloutBakeSeconds = 60
+	// rolloutInconclusiveRetries is how many more times an inconclusive error rate is checked (each after another minimum bake)
+	// before the rollout is reverted
+	rolloutInconclusiveRetries = 3
+)
+
+// errInconclusiveErrorRate is returned when there aren't enough request metrics to judge the new revision's error rate
+var errInconclusiveErrorRate = errors.New("error rate is inconclusive")
+
+// RolloutConfig configures progressive delivery for a Cloud Run service (the `rollout` output)
+// The new revision is created with no traffic and a revision tag, then traffic is shifted to it in steps.
+// Between steps, the new revision's error rate is checked; traffic is reverted if it exceeds MaxErrorRatePercent
+// or if there aren't enough request metrics to judge it.
+type RolloutConfig struct {
+	// Steps is the schedule of traffic percentages sent to the new revision (e.g. 5 -> 25 -> 100)
+	// A final 100% step is added if the schedule doesn't end at 100%
+	Steps []RolloutStep `json:"steps"`
+	// Tag is the revision tag assigned to the new revision; it provides a URL that reaches the new revision directly
+	// Defaults to "candidate"
+	Tag string `json:"tag,omitempty"`
+	// MaxErrorRatePercent is the 5xx rate of the new revision above which traffic is reverted
+	// Defaults to 5%
+	MaxErrorRatePercent float64 `json:"max_error_rate_percent,omitempty"`
+	// MinRequestsPerSecond is the request rate the new revision must receive before its error rate is judged
+	MinRequestsPerSecond float64 `json:"min_requests_per_second,omitempty"`
+}
+
+type RolloutStep struct {
+	Percent int32 `json:"percent"`
+	// BakeSeconds is how long to wait at this step before checking the error rate and moving on
+	// Steps bake for at least 60 seconds so that request metrics for the new revision are available
+	BakeSeconds int `json:"bake_seconds,omitempty"`
+}
+
+func (s RolloutStep) BakeTime() time.Duration {
+	return time.Duration(s.BakeSeconds) * time.Second
+}
+
+func (s RolloutStep) String() string {
+	if s.BakeSeconds > 0 {
+		return fmt.Sprintf("%d%% (bake %s)", s.Percent, s.BakeTime())
+	}
+	return fmt.Sprintf("%d%%", s.Percent)
+}
+
+// normalize validates the schedule and fills in defaults
+func (c RolloutConfig) normalize() (RolloutConfig, error) {
+	if c.Tag == "" {
+		c.Tag = defaultRolloutTag
+	}
+	if c.MaxErrorRatePercent <= 0 {
+		c.MaxErrorRatePercent = degradedErrorRatePercent
+	}
+	steps := make([]RolloutStep, 0, len(c.Steps)+1)
+	var last int32
+	for _, step := range c.Steps {
+		if step.Percent <= last || step.Percent > 100 {
+			return c, fmt.Errorf("invalid rollout: step percentages must increase within 1..100, got %d after %d", step.Percent, last)
+		}
+		if step.BakeSeconds < 0 {
+			return c, fmt.Errorf("invalid rollout: bake_seconds must not be negative, got %d", step.BakeSeconds)
+		}
+		step.BakeSeconds = max(step.BakeSeconds, minRolloutBakeSeconds)
+		steps = append(steps, step)
+		last = step.Percent
+	}
+	if last < 100 {
+		steps = append(steps, RolloutStep{Percent: 100, BakeSeconds: minRolloutBakeSeconds})
+	}
+	c.Steps = steps
+	return c, nil
+}
+
+// rolloutService progressively shifts traffic to the revision created from svc's updated template
+//  1. Existing traffic is pinned to the revisions that serve it so that the new revision receives no traffic
+//  2. Once the new revision is ready, it is tagged so that it can be reached at its own URL
+//  3. Each step routes a share of traffic to the new revision, bakes, then checks its error rate
+//  4. After the last step, the service routes all traffic to the latest revision
+//
+// If a step fails, the error rate is breached, or ctx is cancelled, traffic is reverted to the previous revisions.
+func (d Deployer) rolloutService(ctx context.Context, client *run.ServicesClient, svc *runpb.Service, cfg RolloutConfig) (string, error) {
+	stdout, stderr := d.OsWriters.Stdout(), d.OsWriters.Stderr()
+
+	cfg, err := cfg.normalize()
+	if err != nil {
+		return "", err
+	}
+	latestReady := shortName(svc.GetLatestReadyRevision())
+	if latestReady == "" {
+		fmt.Fprintln(stdout, "Service has no serving revision, skipping progressive rollout")
+		return d.updateService(ctx, client, svc)
+	}
+	stable := pinTraffic(svc.GetTraffic(), latestReady, cfg.Tag)
+	steps := make([]string, 0, len(cfg.Steps))
+	for _, step := range cfg.Steps {
+		steps = append(steps, step.String())
+	}
+	fmt.Fprintf(stdout, "Rolling out new revision progressively: %s\n", strings.Join(steps, " -> "))
+
+	svc.Traffic = stable
+	op, err := client.UpdateService(ctx, &runpb.UpdateServiceRequest{Service: svc})
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintln(stdout, "Creating new revision with no traffic")
+	if svc, err = op.Wait(ctx); err != nil {
+		return "", d.unpinTraffic(client, stable, fmt.Errorf("error waiting for new revision to become ready: %w", err))
+	}
+	candidate := shortName(svc.GetLatestCreatedRevision())
+	if candidate == latestReady {
+		fmt.Fprintln(stdout, "Service template is unchanged, no new revision was created")
+		if _, err := d.updateTraffic(ctx, client, svc, completeTraffic(stable)); err != nil {
+			return "", fmt.Errorf("error routing traffic to latest revision: %w", err)
+		}
+		return op.Name(), nil
+	}
+	if ready := shortName(svc.GetLatestReadyRevision()); ready != candidate {
+		return "", d.unpinTraffic(client, stable, fmt.Errorf("revision %q did not become ready", candidate))
+	}
+
+	if svc, err = d.updateTraffic(ctx, client, svc, shiftTraffic(stable, candidate, cfg.Tag, 0)); err != nil {
+		return "", d.revertTraffic(client, stable, candidate, cfg.Tag, fmt.Errorf("error tagging revision %q: %w", candidate, err))
+	}
+	for _, ts := range svc.GetTrafficStatuses() {
+		if ts.GetTag() == cfg.Tag && ts.GetUri() != "" {
+			fmt.Fprintf(stdout, "Revision %q is available at %s\n", candidate, ts.GetUri())
+		}
+	}
+
+	checker := d.newRolloutChecker(ctx)
+	if checker == nil {
+		fmt.Fprintln(stderr, "Unable to read request metrics, traffic will be shifted without error rate checks")
+	} else {
+		defer checker.client.Close()
+	}
+	for _, step := range cfg.Steps {
+		fmt.Fprintf(stdout, "Routing %d%% of traffic to revision %q\n", step.Percent, candidate)
+		if svc, err = d.updateTraffic(ctx, client, svc, shiftTraffic(stable, candidate, cfg.Tag, step.Percent)); err != nil {
+			return "", d.revertTraffic(client, stable, candidate, cfg.Tag, fmt.Errorf("error routing traffic to revision %q: %w", candidate, err))
+		}
+		if bake := step.BakeTime(); bake > 0 {
+			fmt.Fprintf(stdout, "Baking for %s\n", bake)
+			select {
+			case <-time.After(bake):
+			case <-ctx.Done():
+				return "", d.revertTraffic(client, stable, candidate, cfg.Tag, fmt.Errorf("rollout cancelled: %w", ctx.Err()))
+			}
+		}
+		if checker != nil {
+			if err := d.verifyErrorRate(ctx, checker, candidate, cfg); err != nil {
+				return "", d.revertTraffic(client, stable, candidate, cfg.Tag, err)
+			}
+		}
+	}
+
+	// Route to LATEST so the service behaves like a regular deploy until the next rollout
+	if _, err := d.updateTraffic(ctx, client, svc, completeTraffic(stable)); err != nil {
+		return "", fmt.Errorf("error routing traffic to latest revision: %w", err)
+	}
+	fmt.Fprintf(stdout, "Revision %q is serving 100%% of traffic\n", candidate)
+	return op.Name(), nil
+}
+
+func (d Deployer) updateService(ctx context.Context, client *run.ServicesClient, svc *runpb.Service) (string, error) {
+	op, err := client.UpdateService(ctx, &runpb.UpdateServiceRequest{Service: svc})
+	if err != nil {
+		return "", err
+	}
+	return op.Name(), nil
+}
+
+func (d Deployer) updateTraffic(ctx context.Context, client *run.ServicesClient, svc *runpb.Service, traffic []*runpb.TrafficTarget) (*runpb.Service, error) {
+	svc.Traffic = traffic
+	op, err := client.UpdateService(ctx, &runpb.UpdateServiceRequest{Service: svc})
+	if err != nil {
+		return nil, err
+	}
+	return op.Wait(ctx)
+}
+
+// revertTraffic routes all traffic back to the stable revisions, leaving the new revision tagged for debugging
+// This runs on a fresh context so that a cancelled rollout is still reverted
+func (d Deployer) revertTraffic(client *run.ServicesClient, stable []*runpb.TrafficTarget, candidate, tag string, cause error) error {
+	fmt.Fprintf(d.OsWriters.Stdout(), "Reverting traffic away from revision %q\n", candidate)
+	ctx, cancel := context.WithTimeout(context.Background(), rolloutRevertTimeout)
+	defer cancel()
+
+	svc, err := client.GetService(ctx, &runpb.GetServiceRequest{Name: d.Infra.ServiceId})
+	if err != nil {
+		return errors.Join(cause, fmt.Errorf("error retrieving service to revert traffic: %w", err))
+	}
+	if _, err := d.updateTraffic(ctx, client, svc, shiftTraffic(stable, candidate, tag, 0)); err != nil {
+		return errors.Join(cause, fmt.Errorf("error reverting traffic: %w", err))
+	}
+	return fmt.Errorf("%w; traffic was reverted to the previous revisions", cause)
+}
+
+// unpinTraffic routes traffic back to the latest revision when a rollout stops before the new revision received any
+// Otherwise, the pinned revisions would keep serving all traffic after later deploys
+// This runs on a fresh context so that a cancelled rollout is still unpinned
+func (d Deployer) unpinTraffic(client *run.ServicesClient, stable []*runpb.TrafficTarget, cause error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), rolloutRevertTimeout)
+	defer cancel()
+
+	svc, err := client.GetService(ctx, &runpb.GetServiceRequest{Name: d.Infra.ServiceId})
+	if err != nil {
+		return errors.Join(cause, fmt.Errorf("error retrieving service to restore traffic: %w", err))
+	}
+	if _, err := d.updateTraffic(ctx, client, svc, completeTraffic(stable)); err != nil {
+		return errors.Join(cause, fmt.Errorf("error routing traffic to latest revision: %w", err))
+	}
+	return cause
+}
+
+// newRolloutChecker returns a metricsEnricher used to read the new revision's request metrics
+// Returns nil if metrics can't be queried
+func (d Deployer) newRolloutChecker(ctx context.Context) *metricsEnricher {
+	loc := d.Infra.Location()
+	if loc.ProjectId == "" {
+		return nil
+	}
+	client, err := NewMetricClient(ctx, d.Infra.Deployer)
+	if err != nil {
+		return nil
+	}
+	return &metricsEnricher{
+		client:      client,
+		projectName: "projects/" + loc.ProjectId,
+		serviceName: d.Infra.ServiceName(),
+		location:    loc.Region,
+		stderr:      d.OsWriters.Stderr(),
+	}
+}
+
+// verifyErrorRate checks the new revision's error rate after a step has baked
+// An inconclusive error rate (e.g. no traffic arrived yet) is checked again after another minimum bake;
+// if it's still inconclusive after rolloutInconclusiveRetries, the step fails
+func (d Deployer) verifyErrorRate(ctx context.Context, checker *metricsEnricher, candidate string, cfg RolloutConfig) error {
+	stdout := d.OsWriters.Stdout()
+	wait := time.Duration(minRolloutBakeSeconds) * time.Second
+	for attempt := 0; ; attempt++ {
+		rates := checker.requestRatesByRevision(ctx)
+		err := checkRolloutErrorRate(candidate, rates[candidate], cfg)
+		if !errors.Is(err, errInconclusiveErrorRate) {
+			return err
+		}
+		if attempt >= rolloutInconclusiveRetries {
+			return fmt.Errorf("unable to verify revision %q: %w", candidate, err)
+		}
+		fmt.Fprintf(stdout, "Unable to judge the error rate yet (%s), baking for another %s\n", err, wait)
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return fmt.Errorf("rollout cancelled: %w", ctx.Err())
+		}
+	}
+}
+
+// checkRolloutErrorRate fails if the revision's 5xx rate exceeds the rollout threshold
+// Returns errInconclusiveErrorRate if the revision has no request metrics or served fewer requests than MinRequestsPerSecond
+func checkRolloutErrorRate(revision string, rate *requestRate, cfg RolloutConfig) error {
+	if rate == nil || rate.total <= 0 {
+		return fmt.Errorf("%w: revision %q has no request metrics", errInconclusiveErrorRate, revision)
+	}
+	if rate.total < cfg.MinRequestsPerSecond {
+		return fmt.Errorf("%w: revision %q served %.2f requests/s, below the minimum of %.2f", errInconclusiveErrorRate, revision, rate.total, cfg.MinRequestsPerSecond)
+	}
+	if errRate := rate.ErrorRatePercent(); errRate > cfg.MaxErrorRatePercent {
+		return fmt.Errorf("revision %q error rate %.1f%% exceeds the rollout threshold of %.1f%%", revision, errRate, cfg.MaxErrorRatePercent)
+	}
+	return nil
+}
+
+// completeTraffic routes all traffic to the latest revision once a rollout succeeds
+// Revision tags from before the rollout are kept (without traffic) so their URLs keep working
+func completeTraffic(stable []*runpb.TrafficTarget) []*runpb.TrafficTarget {
+	traffic := []*runpb.TrafficTarget{{
+		Type:    runpb.TrafficTargetAllocationType_TRAFFIC_TARGET_ALLOCATION_TYPE_LATEST,
+		Percent: 100,
+	}}
+	for _, target := range stable {
+		if target.GetTag() == "" {
+			continue
+		}
+		traffic = append(traffic, &runpb.TrafficTarget{
+			Type:     runpb.TrafficTargetAllocationType_TRAFFIC_TARGET_ALLOCATION_TYPE_REVISION,
+			Revision: target.GetRevision(),
+			Tag:      target.GetTag(),
+		})
+	}
+	return traffic
+}
+
+// pinTraffic converts a traffic split into one that names revisions explicitly
+// Targets that follow LATEST are pinned to latestReady so that a new revision receives no traffic when it's created
+// The rollout tag is removed so that it can be assigned to the new revision
+func pinTraffic(targets []*runpb.TrafficTarget, latestReady, tag string) []*runpb.TrafficTarget {
+	byRevision := map[string]*runpb.TrafficTarget{}
+	pinned := make([]*runpb.TrafficTarget, 0, len(targets))
+	for _, target := range targets {
+		revision := target.GetRevision()
+		if target.GetType() == runpb.TrafficTargetAllocationType_TRAFFIC_TARGET_ALLOCATION_TYPE_LATEST || revision == "" {
+			revision = latestReady
+		}
+		targetTag := target.GetTag()
+		if targetTag == tag {
+			targetTag = ""
+		}
+		if targetTag == "" && target.GetPercent() == 0 {
+			continue
+		}
+		// Merge untagged targets for the same revision; Cloud Run rejects duplicate untagged revisions
+		if existing, ok := byRevision[revision]; ok && targetTag == "" {
+			existing.Percent += target.GetPercent()
+			continue
+		}
+		pt := &runpb.TrafficTarget{
+			Type:     runpb.TrafficTargetAllocationType_TRAFFIC_TARGET_ALLOCATION_TYPE_REVISION,
+			Revision: revision,
+			Percent:  target.GetPercent(),
+			Tag:      targetTag,
+		}
+		if targetTag == "" {
+			byRevision[revision] = pt
+		}
+		pinned = append(pinned, pt)
+	}
+	if len(pinned) == 0 {
+		pinned = append(pinned, &runpb.TrafficTarget{
+			Type:     runpb.TrafficTargetAllocationType_TRAFFIC_TARGET_ALLOCATION_TYPE_REVISION,
+			Revision: latestReady,
+			Percent:  100,
+		})
+	}
+	return pinned
+}
+
+// shiftTraffic sends percent of traffic to the tagged candidate revision
+// The remaining traffic is split across the stable targets in proportion to their original shares
+func shiftTraffic(stable []*runpb.TrafficTarget, candidate, tag string, percent int32) []*runpb.TrafficTarget {
+	var stableTotal int32
+	for _, target := range stable {
+		stableTotal += target.GetPercent()
+	}
+	if stableTotal <= 0 {
+		percent = 100
+	}
+
+	remaining := 100 - percent
+	result := make([]*runpb.TrafficTarget, 0, len(stable)+1)
+	var assigned, largestPercent int32
+	var largest *runpb.TrafficTarget
+	for _, target := range stable {
+		share := int32(0)
+		if stableTotal > 0 {
+			share = target.GetPercent() * remaining / stableTotal
+		}
+		st := &runpb.TrafficTarget{
+			Type:     runpb.TrafficTargetAllocationType_TRAFFIC_TARGET_ALLOCATION_TYPE_REVISION,
+			Revision: target.GetRevision(),
+			Percent:  share,
+			Tag:      target.GetTag(),
+		}
+		if remaining > 0 && target.GetPercent() > largestPercent {
+			largest, largestPercent = st, target.GetPercent()
+		}
+		assigned += share
+		result = append(result, st)
+	}
+	// Integer division leaves a rounding remainder; give it to the largest stable target
+	if largest != nil {
+		largest.Percent += remaining - assigned
+	}
+
+	// Untagged targets without traffic are dropped; Cloud Run only keeps zero-percent targets that carry a tag
+	filtered := make([]*runpb.TrafficTarget, 0, len(result)+1)
+	for _, target := range result {
+		if target.Percent > 0 || target.Tag != "" {
+			filtered = append(filtered, target)
+		}
+	}
+	return append(filtered, &runpb.TrafficTarget{
+		Type:     runpb.TrafficTargetAllocationType_TRAFFIC_TARGET_ALLOCATION_TYPE_REVISION,
+		Revision: candidate,
+		Percent:  percent,
+		Tag:      tag,
+	})
+}
@@ -0,0 +1,299 @@
+package cloudrun
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"cloud.google.com/go/longrunning/autogen/longrunningpb"
+	run "cloud.google.com/go/run/apiv2"
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/nullstone-io/deployment-sdk/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	allocLatest   = runpb.TrafficTargetAllocationType_TRAFFIC_TARGET_ALLOCATION_TYPE_LATEST
+	allocRevision = runpb.TrafficTargetAllocationType_TRAFFIC_TARGET_ALLOCATION_TYPE_REVISION
+)
+
+func TestRolloutConfigNormalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     RolloutConfig
+		want    RolloutConfig
+		wantErr string
+	}{
+		{
+			name: "defaults and final step",
+			cfg:  RolloutConfig{Steps: []RolloutStep{{Percent: 5, BakeSeconds: 300}, {Percent: 25, BakeSeconds: 300}}},
+			want: RolloutConfig{
+				Steps:               []RolloutStep{{Percent: 5, BakeSeconds: 300}, {Percent: 25, BakeSeconds: 300}, {Percent: 100, BakeSeconds: 60}},
+				Tag:                 "candidate",
+				MaxErrorRatePercent: 5,
+			},
+		},
+		{
+			name: "explicit settings",
+			cfg:  RolloutConfig{Steps: []RolloutStep{{Percent: 50}, {Percent: 100, BakeSeconds: 60}}, Tag: "canary", MaxErrorRatePercent: 1},
+			want: RolloutConfig{Steps: []RolloutStep{{Percent: 50, BakeSeconds: 60}, {Percent: 100, BakeSeconds: 60}}, Tag: "canary", MaxErrorRatePercent: 1},
+		},
+		{
+			name: "no steps",
+			cfg:  RolloutConfig{},
+			want: RolloutConfig{Steps: []RolloutStep{{Percent: 100, BakeSeconds: 60}}, Tag: "candidate", MaxErrorRatePercent: 5},
+		},
+		{
+			name:    "decreasing steps",
+			cfg:     RolloutConfig{Steps: []RolloutStep{{Percent: 25}, {Percent: 5}}},
+			wantErr: "invalid rollout: step percentages must increase within 1..100, got 5 after 25",
+		},
+		{
+			name:    "over 100",
+			cfg:     RolloutConfig{Steps: []RolloutStep{{Percent: 150}}},
+			wantErr: "invalid rollout: step percentages must increase within 1..100, got 150 after 0",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.cfg.normalize()
+			if test.wantErr != "" {
+				assert.EqualError(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestPinTraffic(t *testing.T) {
+	tests := []struct {
+		name    string
+		targets []*runpb.TrafficTarget
+		want    []*runpb.TrafficTarget
+	}{
+		{
+			name:    "latest pinned to latest ready",
+			targets: []*runpb.TrafficTarget{{Type: allocLatest, Percent: 100}},
+			want:    []*runpb.TrafficTarget{{Type: allocRevision, Revision: "svc-00002", Percent: 100}},
+		},
+		{
+			name: "split kept and previous candidate tag removed",
+			targets: []*runpb.TrafficTarget{
+				{Type: allocRevision, Revision: "svc-00001", Percent: 20},
+				{Type: allocLatest, Percent: 80},
+				{Type: allocRevision, Revision: "svc-00002", Tag: "candidate"},
+				{Type: allocRevision, Revision: "svc-00001", Tag: "blue"},
+			},
+			want: []*runpb.TrafficTarget{
+				{Type: allocRevision, Revision: "svc-00001", Percent: 20},
+				{Type: allocRevision, Revision: "svc-00002", Percent: 80},
+				{Type: allocRevision, Revision: "svc-00001", Tag: "blue"},
+			},
+		},
+		{
+			name: "untagged targets for the same revision are merged",
+			targets: []*runpb.TrafficTarget{
+				{Type: allocRevision, Revision: "svc-00002", Percent: 30},
+				{Type: allocLatest, Percent: 70},
+			},
+			want: []*runpb.TrafficTarget{{Type: allocRevision, Revision: "svc-00002", Percent: 100}},
+		},
+		{
+			name: "no traffic",
+			want: []*runpb.TrafficTarget{{Type: allocRevision, Revision: "svc-00002", Percent: 100}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, pinTraffic(test.targets, "svc-00002", "candidate"))
+		})
+	}
+}
+
+func TestShiftTraffic(t *testing.T) {
+	single := []*runpb.TrafficTarget{{Type: allocRevision, Revision: "svc-00001", Percent: 100}}
+	split := []*runpb.TrafficTarget{
+		{Type: allocRevision, Revision: "svc-00001", Percent: 67},
+		{Type: allocRevision, Revision: "svc-00002", Percent: 33},
+		{Type: allocRevision, Revision: "svc-00002", Tag: "blue"},
+	}
+
+	tests := []struct {
+		name    string
+		stable  []*runpb.TrafficTarget
+		percent int32
+		want    []*runpb.TrafficTarget
+	}{
+		{
+			name:    "tag only",
+			stable:  single,
+			percent: 0,
+			want: []*runpb.TrafficTarget{
+				{Type: allocRevision, Revision: "svc-00001", Percent: 100},
+				{Type: allocRevision, Revision: "svc-00003", Tag: "candidate"},
+			},
+		},
+		{
+			name:    "partial",
+			stable:  single,
+			percent: 5,
+			want: []*runpb.TrafficTarget{
+				{Type: allocRevision, Revision: "svc-00001", Percent: 95},
+				{Type: allocRevision, Revision: "svc-00003", Percent: 5, Tag: "candidate"},
+			},
+		},
+		{
+			name:    "split proportionally with remainder to largest",
+			stable:  split,
+			percent: 25,
+			want: []*runpb.TrafficTarget{
+				{Type: allocRevision, Revision: "svc-00001", Percent: 51},
+				{Type: allocRevision, Revision: "svc-00002", Percent: 24},
+				{Type: allocRevision, Revision: "svc-00002", Tag: "blue"},
+				{Type: allocRevision, Revision: "svc-00003", Percent: 25, Tag: "candidate"},
+			},
+		},
+		{
+			name:    "full keeps tagged targets",
+			stable:  split,
+			percent: 100,
+			want: []*runpb.TrafficTarget{
+				{Type: allocRevision, Revision: "svc-00002", Tag: "blue"},
+				{Type: allocRevision, Revision: "svc-00003", Percent: 100, Tag: "candidate"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := shiftTraffic(test.stable, "svc-00003", "candidate", test.percent)
+			assert.Equal(t, test.want, got)
+			var total int32
+			for _, target := range got {
+				total += target.Percent
+			}
+			assert.Equal(t, int32(100), total)
+		})
+	}
+}
+
+func TestCheckRolloutErrorRate(t *testing.T) {
+	cfg := RolloutConfig{MaxErrorRatePercent: 5, MinRequestsPerSecond: 1}
+	tests := []struct {
+		name             string
+		rate             *requestRate
+		wantErr          string
+		wantInconclusive bool
+	}{
+		{name: "no metrics", rate: nil, wantErr: `error rate is inconclusive: revision "svc-00003" has no request metrics`, wantInconclusive: true},
+		{name: "healthy", rate: &requestRate{total: 10, errors: 0.2}},
+		{name: "too little traffic to judge", rate: &requestRate{total: 0.5, errors: 0.5}, wantErr: `error rate is inconclusive: revision "svc-00003" served 0.50 requests/s, below the minimum of 1.00`, wantInconclusive: true},
+		{name: "breached", rate: &requestRate{total: 10, errors: 1}, wantErr: `revision "svc-00003" error rate 10.0% exceeds the rollout threshold of 5.0%`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := checkRolloutErrorRate("svc-00003", test.rate, cfg)
+			if test.wantErr != "" {
+				assert.EqualError(t, err, test.wantErr)
+				assert.Equal(t, test.wantInconclusive, errors.Is(err, errInconclusiveErrorRate))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCompleteTraffic(t *testing.T) {
+	stable := []*runpb.TrafficTarget{
+		{Type: allocRevision, Revision: "svc-00001", Percent: 20},
+		{Type: allocRevision, Revision: "svc-00002", Percent: 80},
+		{Type: allocRevision, Revision: "svc-00001", Tag: "blue"},
+	}
+	assert.Equal(t, []*runpb.TrafficTarget{
+		{Type: allocLatest, Percent: 100},
+		{Type: allocRevision, Revision: "svc-00001", Tag: "blue"},
+	}, completeTraffic(stable))
+}
+
+// fakeServicesServer is an in-memory Cloud Run service whose updates complete immediately without creating revisions
+type fakeServicesServer struct {
+	runpb.UnimplementedServicesServer
+	svc *runpb.Service
+}
+
+func (s *fakeServicesServer) GetService(ctx context.Context, req *runpb.GetServiceRequest) (*runpb.Service, error) {
+	return proto.Clone(s.svc).(*runpb.Service), nil
+}
+
+func (s *fakeServicesServer) UpdateService(ctx context.Context, req *runpb.UpdateServiceRequest) (*longrunningpb.Operation, error) {
+	s.svc = proto.Clone(req.GetService()).(*runpb.Service)
+	res, err := anypb.New(s.svc)
+	if err != nil {
+		return nil, err
+	}
+	return &longrunningpb.Operation{
+		Name:   "operations/update",
+		Done:   true,
+		Result: &longrunningpb.Operation_Response{Response: res},
+	}, nil
+}
+
+func newFakeServicesClient(t *testing.T, server *fakeServicesServer) *run.ServicesClient {
+	listener := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	runpb.RegisterServicesServer(srv, server)
+	go srv.Serve(listener)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	client, err := run.NewServicesClient(context.Background(), option.WithGRPCConn(conn))
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestRolloutService_UnchangedTemplate(t *testing.T) {
+	const name = "projects/p/locations/us-east1/services/svc"
+	server := &fakeServicesServer{svc: &runpb.Service{
+		Name:                  name,
+		LatestReadyRevision:   name + "/revisions/svc-00002",
+		LatestCreatedRevision: name + "/revisions/svc-00002",
+		Traffic: []*runpb.TrafficTarget{
+			{Type: allocLatest, Percent: 100},
+			{Type: allocRevision, Revision: "svc-00001", Tag: "blue"},
+		},
+	}}
+	client := newFakeServicesClient(t, server)
+	d := Deployer{OsWriters: logging.StandardOsWriters{}, Infra: Outputs{ServiceId: name}}
+
+	svc := proto.Clone(server.svc).(*runpb.Service)
+	ref, err := d.rolloutService(context.Background(), client, svc, RolloutConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, "operations/update", ref)
+
+	want := []*runpb.TrafficTarget{
+		{Type: allocLatest, Percent: 100},
+		{Type: allocRevision, Revision: "svc-00001", Tag: "blue"},
+	}
+	if assert.Len(t, server.svc.GetTraffic(), len(want)) {
+		for i, target := range want {
+			assert.True(t, proto.Equal(target, server.svc.GetTraffic()[i]), "traffic[%d] = %v", i, server.svc.GetTraffic()[i])
+		}
+	}
+}
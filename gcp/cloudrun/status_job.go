@@ -141,11 +141,16 @@ func (s Statuser) listExecutionTasks(ctx context.Context, executionName string)
 }
 
 func mapTask(t *runpb.Task) Task {
-	return Task{
+	task := Task{
 		Index:    t.GetIndex(),
 		State:    taskState(t),
 		Attempts: t.GetRetried() + 1,
 	}
+	if res := t.GetLastAttemptResult(); res != nil && t.GetCompletionTime() != nil {
+		code := res.GetExitCode()
+		task.ExitCode = &code
+	}
+	return task
 }
 
 func executionPhase(exec *runpb.Execution) JobExecutionPhase {
@@ -231,35 +231,7 @@ func synthesizeInstances(rev *Revision, active, idle int32) []Instance {
 // applyRequestMetrics populates per-revision request rate, error rate, and
 // latency percentiles, then rolls them up into the service-level RequestHealth.
 func (m metricsEnricher) applyRequestMetrics(ctx context.Context, svc *Service) {
-	// Request rate per revision and response-code class. ALIGN_RATE yields a
-	// per-second rate; summing the per-class rates gives total rps, and the 5xx
-	// share gives the error rate.
-	rateSeries := m.query(ctx, metricRequestCount,
-		monitoringpb.Aggregation_ALIGN_RATE,
-		monitoringpb.Aggregation_REDUCE_SUM,
-		[]string{"resource.label.revision_name", "metric.label.response_code_class"})
-
-	type reqAgg struct{ total, errors float64 }
-	byRev := map[string]*reqAgg{}
-	for _, ts := range rateSeries {
-		rev := ts.GetResource().GetLabels()["revision_name"]
-		if rev == "" {
-			continue
-		}
-		v, ok := latestValue(ts)
-		if !ok {
-			continue
-		}
-		a := byRev[rev]
-		if a == nil {
-			a = &reqAgg{}
-			byRev[rev] = a
-		}
-		a.total += v
-		if ts.GetMetric().GetLabels()["response_code_class"] == "5xx" {
-			a.errors += v
-		}
-	}
+	byRev := m.requestRatesByRevision(ctx)
 
 	// Latency percentiles. Aligning each distribution with ALIGN_DELTA and then
 	// reducing with REDUCE_PERCENTILE_* merges the underlying distributions and
@@ -272,11 +244,7 @@ func (m metricsEnricher) applyRequestMetrics(ctx context.Context, svc *Service)
 		rev := &svc.Revisions[i]
 		if a := byRev[rev.Name]; a != nil {
 			rev.RequestsPerSecond = ptr(a.total)
-			errRate := 0.0
-			if a.total > 0 {
-				errRate = a.errors / a.total * 100
-			}
-			rev.ErrorRatePercent = ptr(errRate)
+			rev.ErrorRatePercent = ptr(a.ErrorRatePercent())
 			totalRps += a.total
 			totalErrors += a.errors
 		}
@@ -304,6 +272,54 @@ func (m metricsEnricher) applyRequestMetrics(ctx context.Context, svc *Service)
 	svc.RequestHealth = &health
 }
 
+// requestRate is a revision's request rate and 5xx rate, both per second
+type requestRate struct{ total, errors float64 }
+
+// ErrorRatePercent returns the share of requests that returned 5xx
+func (r requestRate) ErrorRatePercent() float64 {
+	if r.total <= 0 {
+		return 0
+	}
+	return r.errors / r.total * 100
+}
+
+// requestRatesByRevision returns the latest request rate per revision.
+// Returns nil if the query failed (logged).
+func (m metricsEnricher) requestRatesByRevision(ctx context.Context) map[string]*requestRate {
+	// Request rate per revision and response-code class. ALIGN_RATE yields a
+	// per-second rate; summing the per-class rates gives total rps, and the 5xx
+	// share gives the error rate.
+	rateSeries := m.query(ctx, metricRequestCount,
+		monitoringpb.Aggregation_ALIGN_RATE,
+		monitoringpb.Aggregation_REDUCE_SUM,
+		[]string{"resource.label.revision_name", "metric.label.response_code_class"})
+	if rateSeries == nil {
+		return nil
+	}
+
+	byRev := map[string]*requestRate{}
+	for _, ts := range rateSeries {
+		rev := ts.GetResource().GetLabels()["revision_name"]
+		if rev == "" {
+			continue
+		}
+		v, ok := latestValue(ts)
+		if !ok {
+			continue
+		}
+		a := byRev[rev]
+		if a == nil {
+			a = &requestRate{}
+			byRev[rev] = a
+		}
+		a.total += v
+		if ts.GetMetric().GetLabels()["response_code_class"] == "5xx" {
+			a.errors += v
+		}
+	}
+	return byRev
+}
+
 func (m metricsEnricher) percentileByRevision(ctx context.Context, reducer monitoringpb.Aggregation_Reducer) map[string]float64 {
 	out := map[string]float64{}
 	for _, ts := range m.query(ctx, metricRequestLatency, monitoringpb.Aggregation_ALIGN_DELTA, reducer, []string{"resource.label.revision_name"}) {
@@ -113,6 +113,7 @@ func (s Statuser) mapRevision(rev *runpb.Revision, svc *runpb.Service, trafficBy
 	if container != nil {
 		img := docker.ParseImageUrl(container.GetImage())
 		out.AppVersion = img.Tag
+		out.Containers = revisionContainers(rev.GetContainers(), s.Infra.MainContainerName)
 		if limits := container.GetResources().GetLimits(); limits != nil {
 			out.Cpu = limits["cpu"]
 			out.Memory = limits["memory"]
@@ -217,6 +217,19 @@ func TestTaskState(t *testing.T) {
 	})
 }
 
+func TestMapTask(t *testing.T) {
+	now := timestamppb.New(time.Now())
+	t.Run("no exit code while running", func(t *testing.T) {
+		task := mapTask(&runpb.Task{Index: 2, StartTime: now, Retried: 1, LastAttemptResult: &runpb.TaskAttemptResult{ExitCode: 1}})
+		assert.Equal(t, Task{Index: 2, State: TaskStateRetrying, Attempts: 2}, task)
+	})
+	t.Run("exit code once completed", func(t *testing.T) {
+		code := int32(3)
+		task := mapTask(&runpb.Task{Index: 1, CompletionTime: now, LastAttemptResult: &runpb.TaskAttemptResult{ExitCode: code}})
+		assert.Equal(t, Task{Index: 1, State: TaskStateFailed, Attempts: 1, ExitCode: &code}, task)
+	})
+}
+
 func TestDeriveExecutionFailure(t *testing.T) {
 	t.Run("nil when not failed", func(t *testing.T) {
 		je := JobExecution{Phase: JobExecutionPhaseSucceeded}
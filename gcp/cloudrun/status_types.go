@@ -91,6 +91,8 @@ type Revision struct {
 	P95Ms             *float64     `json:"p95Ms,omitempty"`
 	ErrorRatePercent  *float64     `json:"errorRatePercent,omitempty"`
 	Failure           *Failure     `json:"failure,omitempty"`
+	// Containers lists each container's image when the revision runs sidecars
+	Containers []RevisionContainer `json:"containers,omitempty"`
 }
 
 type ServiceState string
@@ -121,6 +123,23 @@ type Service struct {
 	Revisions      []Revision     `json:"revisions"`
 }
 
+// WorkerPool is the status of a Cloud Run worker pool. Worker pools run
+// instances that don't receive requests, so there is no request health; the
+// instance split plays the role of the traffic split.
+type WorkerPool struct {
+	WorkerPoolName        string              `json:"workerPoolName"`
+	Generation            int64               `json:"generation,omitempty"`
+	State                 ServiceState        `json:"state"`
+	AppVersion            string              `json:"appVersion,omitempty"`
+	LatestReadyRevision   string              `json:"latestReadyRevision"`
+	LatestCreatedRevision string              `json:"latestCreatedRevision"`
+	InstanceCount         int32               `json:"instanceCount"`
+	InstanceSplits        []TrafficSplitEntry `json:"instanceSplits"`
+	Containers            []RevisionContainer `json:"containers,omitempty"`
+	LastDeployedAt        *time.Time          `json:"lastDeployedAt,omitempty"`
+	Failure               *Failure            `json:"failure,omitempty"`
+}
+
 type TaskState string
 
 const (
@@ -136,6 +155,8 @@ type Task struct {
 	Index    int32     `json:"index"`
 	State    TaskState `json:"state"`
 	Attempts int32     `json:"attempts"`
+	// ExitCode is the process exit code of the task's last attempt once the task has completed.
+	ExitCode *int32 `json:"exitCode,omitempty"`
 }
 
 type JobExecutionPhase string
@@ -171,13 +192,27 @@ type JobExecution struct {
 
 // Status is the full app status payload (the `data` field on the frontend
 // AppStatusResult). A service workspace populates Service; a job workspace
-// populates Executions.
+// populates Executions; a worker pool workspace populates WorkerPool.
 type Status struct {
 	Location    LocationInfo   `json:"location"`
 	ServiceName string         `json:"serviceName"`
 	JobName     string         `json:"jobName"`
 	Service     *Service       `json:"service,omitempty"`
 	Executions  []JobExecution `json:"executions"`
+	// WorkerPool is populated for a worker pool workspace
+	WorkerPool *WorkerPool `json:"workerPool,omitempty"`
+	// Regions is populated for a multi-region service; Service is the primary region
+	Regions []RegionalService `json:"regions,omitempty"`
+	// State is the least healthy state across Regions
+	State ServiceState `json:"state,omitempty"`
+}
+
+// RegionalService is the status of one region of a multi-region service.
+type RegionalService struct {
+	Location LocationInfo `json:"location"`
+	Service  *Service     `json:"service,omitempty"`
+	// Error is set when the region's status could not be retrieved
+	Error string `json:"error,omitempty"`
 }
 
 type TrafficSplitEntry struct {
@@ -191,9 +226,21 @@ type StatusOverview struct {
 	Location             LocationInfo        `json:"location"`
 	ServiceName          string              `json:"serviceName"`
 	JobName              string              `json:"jobName"`
+	WorkerPoolName       string              `json:"workerPoolName,omitempty"`
+	ServingRevisionCount int                 `json:"servingRevisionCount"`
+	TrafficSplit         []TrafficSplitEntry `json:"trafficSplit"`
+	// Regions is populated for a multi-region service; the fields above describe the primary region
+	Regions  []RegionalOverview `json:"regions,omitempty"`
+	versions []string
+}
+
+// RegionalOverview is the overview of one region of a multi-region service.
+type RegionalOverview struct {
+	Location             LocationInfo        `json:"location"`
+	State                ServiceState        `json:"state,omitempty"`
 	ServingRevisionCount int                 `json:"servingRevisionCount"`
 	TrafficSplit         []TrafficSplitEntry `json:"trafficSplit"`
-	versions             []string
+	Error                string              `json:"error,omitempty"`
 }
 
 func (s StatusOverview) GetDeploymentVersions() []string {
@@ -2,6 +2,8 @@ package cloudrun
 
 import (
 	"context"
+	"fmt"
+	"slices"
 
 	"github.com/nullstone-io/deployment-sdk/app"
 	"github.com/nullstone-io/deployment-sdk/logging"
@@ -35,34 +37,34 @@ type Statuser struct {
 
 func (s Statuser) StatusOverview(ctx context.Context) (app.StatusOverviewResult, error) {
 	ov := StatusOverview{
-		Location:     s.Infra.Location(),
-		ServiceName:  s.Infra.ServiceName(),
-		JobName:      s.Infra.JobName(),
-		TrafficSplit: make([]TrafficSplitEntry, 0),
+		Location:       s.Infra.Location(),
+		ServiceName:    s.Infra.ServiceName(),
+		JobName:        s.Infra.JobName(),
+		WorkerPoolName: s.Infra.WorkerPoolName(),
+		TrafficSplit:   make([]TrafficSplitEntry, 0),
 	}
 
 	if s.Infra.ServiceId != "" {
+		if serviceIds := s.Infra.RegionalServiceIds(); len(serviceIds) > 1 {
+			return s.regionalStatusOverview(ctx, ov, serviceIds)
+		}
 		svc, err := s.statusService(ctx)
 		if err != nil {
 			return ov, err
 		}
-		versions := make([]string, 0)
-		for _, rev := range svc.Revisions {
-			if rev.TrafficPercent <= 0 && rev.Role != RevisionRoleLatest {
-				continue
-			}
-			ov.TrafficSplit = append(ov.TrafficSplit, TrafficSplitEntry{
-				RevisionName:   rev.Name,
-				TrafficPercent: rev.TrafficPercent,
-			})
-			if rev.TrafficPercent > 0 {
-				ov.ServingRevisionCount++
-			}
-			if rev.AppVersion != "" {
-				versions = append(versions, rev.AppVersion)
-			}
+		ov.ServingRevisionCount, ov.TrafficSplit, ov.versions = serviceOverview(svc)
+		return ov, nil
+	}
+
+	if s.Infra.JobId == "" && s.Infra.WorkerPoolId != "" {
+		pool, err := s.statusWorkerPool(ctx)
+		if err != nil {
+			return ov, err
+		}
+		ov.TrafficSplit = pool.InstanceSplits
+		if pool.AppVersion != "" {
+			ov.versions = []string{pool.AppVersion}
 		}
-		ov.versions = versions
 		return ov, nil
 	}
 
@@ -85,6 +87,15 @@ func (s Statuser) Status(ctx context.Context) (any, error) {
 	}
 
 	if s.Infra.ServiceId != "" {
+		if serviceIds := s.Infra.RegionalServiceIds(); len(serviceIds) > 1 {
+			out.Regions = s.statusRegions(ctx, serviceIds, true)
+			out.State = AggregateServiceState(out.Regions)
+			out.Service = out.Regions[0].Service
+			if out.Service == nil {
+				return nil, fmt.Errorf("error retrieving primary region status: %s", out.Regions[0].Error)
+			}
+			return out, nil
+		}
 		svc, err := s.statusService(ctx)
 		if err != nil {
 			return nil, err
@@ -96,6 +107,15 @@ func (s Statuser) Status(ctx context.Context) (any, error) {
 		return out, nil
 	}
 
+	if s.Infra.JobId == "" && s.Infra.WorkerPoolId != "" {
+		pool, err := s.statusWorkerPool(ctx)
+		if err != nil {
+			return nil, err
+		}
+		out.WorkerPool = pool
+		return out, nil
+	}
+
 	execs, err := s.statusJob(ctx)
 	if err != nil {
 		return nil, err
@@ -103,3 +123,53 @@ func (s Statuser) Status(ctx context.Context) (any, error) {
 	out.Executions = execs
 	return out, nil
 }
+
+func (s Statuser) regionalStatusOverview(ctx context.Context, ov StatusOverview, serviceIds []string) (StatusOverview, error) {
+	regions := s.statusRegions(ctx, serviceIds, false)
+	primary := regions[0].Service
+	if primary == nil {
+		return ov, fmt.Errorf("error retrieving primary region status: %s", regions[0].Error)
+	}
+	ov.ServingRevisionCount, ov.TrafficSplit, _ = serviceOverview(primary)
+
+	versions := make([]string, 0)
+	for _, region := range regions {
+		ro := RegionalOverview{Location: region.Location, TrafficSplit: make([]TrafficSplitEntry, 0), Error: region.Error}
+		if region.Service != nil {
+			var regionVersions []string
+			ro.State = region.Service.State
+			ro.ServingRevisionCount, ro.TrafficSplit, regionVersions = serviceOverview(region.Service)
+			for _, v := range regionVersions {
+				if !slices.Contains(versions, v) {
+					versions = append(versions, v)
+				}
+			}
+		}
+		ov.Regions = append(ov.Regions, ro)
+	}
+	ov.versions = versions
+	return ov, nil
+}
+
+// serviceOverview summarizes the revisions that serve traffic (or are the latest) in a service
+func serviceOverview(svc *Service) (int, []TrafficSplitEntry, []string) {
+	servingCount := 0
+	split := make([]TrafficSplitEntry, 0)
+	versions := make([]string, 0)
+	for _, rev := range svc.Revisions {
+		if rev.TrafficPercent <= 0 && rev.Role != RevisionRoleLatest {
+			continue
+		}
+		split = append(split, TrafficSplitEntry{
+			RevisionName:   rev.Name,
+			TrafficPercent: rev.TrafficPercent,
+		})
+		if rev.TrafficPercent > 0 {
+			servingCount++
+		}
+		if rev.AppVersion != "" {
+			versions = append(versions, rev.AppVersion)
+		}
+	}
+	return servingCount, split, versions
+}
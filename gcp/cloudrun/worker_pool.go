@@ -0,0 +1,133 @@
+package cloudrun
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/nullstone-io/deployment-sdk/app"
+	"github.com/nullstone-io/deployment-sdk/docker"
+	"github.com/nullstone-io/deployment-sdk/logging"
+)
+
+func (d Deployer) deployWorkerPool(ctx context.Context, meta app.DeployMetadata) (string, error) {
+	stdout, _ := d.OsWriters.Stdout(), d.OsWriters.Stderr()
+
+	client, err := NewWorkerPoolsClient(ctx, d.Infra.Deployer)
+	if err != nil {
+		return "", fmt.Errorf("error initializing cloud run client: %w", err)
+	}
+	defer client.Close()
+
+	pool, err := client.GetWorkerPool(ctx, &runpb.GetWorkerPoolRequest{Name: d.Infra.WorkerPoolId})
+	if err != nil {
+		return "", fmt.Errorf("error retrieving worker pool: %w", err)
+	} else if pool == nil {
+		return "", fmt.Errorf("cloud run worker pool %q not found", d.Infra.WorkerPoolId)
+	}
+
+	if err := d.updateContainers(pool.GetTemplate().GetContainers(), meta, "worker pool"); err != nil {
+		return "", err
+	}
+
+	op, err := client.UpdateWorkerPool(ctx, &runpb.UpdateWorkerPoolRequest{WorkerPool: pool})
+	if err != nil {
+		return "", fmt.Errorf("error updating worker pool: %w", err)
+	}
+	fmt.Fprintln(stdout, "Updated worker pool successfully")
+	return op.Name(), nil
+}
+
+var _ app.DeployStatusGetter = &WorkerPoolDeployLogger{}
+
+// WorkerPoolDeployLogger reports the progress of a worker pool update operation to app.PollingDeployWatcher
+type WorkerPoolDeployLogger struct {
+	OsWriters logging.OsWriters
+	Details   app.Details
+	Infra     Outputs
+}
+
+func (d *WorkerPoolDeployLogger) Close() {}
+
+func (d *WorkerPoolDeployLogger) GetDeployStatus(ctx context.Context, reference string) (app.RolloutStatus, error) {
+	if reference == "" {
+		return app.RolloutStatusUnknown, nil
+	}
+
+	client, err := NewWorkerPoolsClient(ctx, d.Infra.Deployer)
+	if err != nil {
+		return app.RolloutStatusUnknown, err
+	}
+	defer client.Close()
+
+	op := client.UpdateWorkerPoolOperation(reference)
+	pool, err := op.Poll(ctx)
+	if op.Done() {
+		if err != nil {
+			return app.RolloutStatusFailed, err
+		}
+		fmt.Fprintf(d.OsWriters.Stdout(), "Worker pool %q is running revision %q\n", d.Infra.WorkerPoolName(), shortName(pool.GetLatestReadyRevision()))
+		return app.RolloutStatusComplete, nil
+	}
+	return app.RolloutStatusInProgress, nil
+}
+
+func (s Statuser) statusWorkerPool(ctx context.Context) (*WorkerPool, error) {
+	client, err := NewWorkerPoolsClient(ctx, s.Infra.Deployer)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing cloud run worker pools client: %w", err)
+	}
+	defer client.Close()
+
+	pool, err := client.GetWorkerPool(ctx, &runpb.GetWorkerPoolRequest{Name: s.Infra.WorkerPoolId})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving worker pool: %w", err)
+	} else if pool == nil {
+		return nil, fmt.Errorf("cloud run worker pool %q not found", s.Infra.WorkerPoolName())
+	}
+	return mapWorkerPool(pool, s.Infra.MainContainerName), nil
+}
+
+func mapWorkerPool(pool *runpb.WorkerPool, mainContainerName string) *WorkerPool {
+	out := &WorkerPool{
+		WorkerPoolName:        shortName(pool.GetName()),
+		Generation:            pool.GetGeneration(),
+		State:                 ServiceStateHealthy,
+		LatestReadyRevision:   shortName(pool.GetLatestReadyRevision()),
+		LatestCreatedRevision: shortName(pool.GetLatestCreatedRevision()),
+		InstanceSplits:        make([]TrafficSplitEntry, 0),
+		LastDeployedAt:        tsToTime(pool.GetUpdateTime()),
+	}
+	out.InstanceCount = pool.GetScaling().GetManualInstanceCount()
+	for _, split := range pool.GetInstanceSplitStatuses() {
+		out.InstanceSplits = append(out.InstanceSplits, TrafficSplitEntry{
+			RevisionName:   split.GetRevision(),
+			TrafficPercent: split.GetPercent(),
+		})
+	}
+
+	containers := pool.GetTemplate().GetContainers()
+	_, container := GetContainerByName(containers, mainContainerName)
+	if container == nil && len(containers) > 0 {
+		container = containers[0]
+	}
+	if container != nil {
+		out.AppVersion = docker.ParseImageUrl(container.GetImage()).Tag
+	}
+	out.Containers = revisionContainers(containers, mainContainerName)
+
+	switch tc := pool.GetTerminalCondition(); {
+	case tc.GetState() == runpb.Condition_CONDITION_FAILED:
+		out.State = ServiceStateFailing
+		out.Failure = &Failure{
+			Code:    FailureContainerFailedToStart,
+			Title:   "Worker pool failed to start",
+			Message: tc.GetMessage(),
+		}
+	case pool.GetReconciling():
+		out.State = ServiceStateScaling
+	case out.InstanceCount == 0:
+		out.State = ServiceStateCold
+	}
+	return out
+}
@@ -0,0 +1,183 @@
+package composer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nullstone-io/deployment-sdk/gcp"
+	"golang.org/x/oauth2"
+)
+
+const airflowPageSize = 100
+
+// ImportError is a DAG file that Airflow failed to import, as reported by the Airflow REST API
+type ImportError struct {
+	Id         int       `json:"import_error_id"`
+	Timestamp  time.Time `json:"timestamp"`
+	Filename   string    `json:"filename"`
+	StackTrace string    `json:"stack_trace"`
+}
+
+// DagFile returns the filename relative to the DAG folder (e.g. /home/airflow/gcs/dags/etl/daily.py -> etl/daily.py)
+func (e ImportError) DagFile() string {
+	return dagFile(e.Filename)
+}
+
+// Summary returns the last line of the stack trace, which holds the exception that was raised
+func (e ImportError) Summary() string {
+	lines := strings.Split(strings.TrimSpace(e.StackTrace), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+// Dag is a DAG known to Airflow
+type Dag struct {
+	DagId    string `json:"dag_id"`
+	IsPaused bool   `json:"is_paused"`
+	IsActive bool   `json:"is_active"`
+	Fileloc  string `json:"fileloc"`
+}
+
+// DagRun is a run of a DAG
+type DagRun struct {
+	DagId   string `json:"dag_id"`
+	RunId   string `json:"dag_run_id"`
+	State   string `json:"state"`
+	RunType string `json:"run_type"`
+	// ExecutionDate is the logical date of the run
+	ExecutionDate *time.Time `json:"execution_date"`
+	StartDate     *time.Time `json:"start_date"`
+	EndDate       *time.Time `json:"end_date"`
+}
+
+// DagFile returns the file that defines the DAG, relative to the DAG folder
+func (d Dag) DagFile() string {
+	return dagFile(d.Fileloc)
+}
+
+// dagFile returns a path on an Airflow worker relative to the DAG folder, which Composer mounts at /home/airflow/gcs/dags
+func dagFile(path string) string {
+	if _, rel, found := strings.Cut(path, "/gcs/dags/"); found {
+		return rel
+	}
+	return path
+}
+
+// AirflowClient calls the stable REST API of a Composer environment's Airflow web server
+type AirflowClient struct {
+	BaseUrl    *url.URL
+	HttpClient *http.Client
+}
+
+// NewAirflowClient creates an AirflowClient for the Airflow web server at airflowUri (Environment.Config.AirflowUri)
+// Composer authenticates REST API calls with the caller's Google credentials
+func NewAirflowClient(ctx context.Context, airflowUri string, account gcp.ServiceAccount) (*AirflowClient, error) {
+	baseUrl, err := url.Parse(strings.TrimSuffix(airflowUri, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid airflow uri %q: %w", airflowUri, err)
+	}
+	tokenSource, err := account.TokenSource(ctx, GcpScopes...)
+	if err != nil {
+		return nil, err
+	}
+	return &AirflowClient{
+		BaseUrl:    baseUrl,
+		HttpClient: oauth2.NewClient(ctx, tokenSource),
+	}, nil
+}
+
+// ListImportErrors retrieves every DAG import error currently reported by Airflow
+func (c *AirflowClient) ListImportErrors(ctx context.Context) ([]ImportError, error) {
+	all := make([]ImportError, 0)
+	for offset := 0; ; offset += airflowPageSize {
+		query := url.Values{}
+		query.Set("limit", strconv.Itoa(airflowPageSize))
+		query.Set("offset", strconv.Itoa(offset))
+		u := c.BaseUrl.JoinPath("api", "v1", "importErrors")
+		u.RawQuery = query.Encode()
+
+		var page struct {
+			ImportErrors []ImportError `json:"import_errors"`
+			TotalEntries int           `json:"total_entries"`
+		}
+		if err := c.get(ctx, u, &page); err != nil {
+			return nil, err
+		}
+		all = append(all, page.ImportErrors...)
+		if len(page.ImportErrors) < airflowPageSize || len(all) >= page.TotalEntries {
+			return all, nil
+		}
+	}
+}
+
+// ListDags retrieves every DAG known to Airflow
+func (c *AirflowClient) ListDags(ctx context.Context) ([]Dag, error) {
+	all := make([]Dag, 0)
+	for offset := 0; ; offset += airflowPageSize {
+		query := url.Values{}
+		query.Set("limit", strconv.Itoa(airflowPageSize))
+		query.Set("offset", strconv.Itoa(offset))
+		u := c.BaseUrl.JoinPath("api", "v1", "dags")
+		u.RawQuery = query.Encode()
+
+		var page struct {
+			Dags         []Dag `json:"dags"`
+			TotalEntries int   `json:"total_entries"`
+		}
+		if err := c.get(ctx, u, &page); err != nil {
+			return nil, err
+		}
+		all = append(all, page.Dags...)
+		if len(page.Dags) < airflowPageSize || len(all) >= page.TotalEntries {
+			return all, nil
+		}
+	}
+}
+
+// ListDagRuns retrieves up to limit of the most recent runs of a DAG, newest first
+func (c *AirflowClient) ListDagRuns(ctx context.Context, dagId string, limit int) ([]DagRun, error) {
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(limit))
+	query.Set("order_by", "-execution_date")
+	u := c.BaseUrl.JoinPath("api", "v1", "dags", dagId, "dagRuns")
+	u.RawQuery = query.Encode()
+
+	var page struct {
+		DagRuns []DagRun `json:"dag_runs"`
+	}
+	if err := c.get(ctx, u, &page); err != nil {
+		return nil, err
+	}
+	if page.DagRuns == nil {
+		return make([]DagRun, 0), nil
+	}
+	return page.DagRuns, nil
+}
+
+func (c *AirflowClient) get(ctx context.Context, u *url.URL, result any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	res, err := c.HttpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("airflow api returned %s: %s", res.Status, strings.TrimSpace(string(body)))
+	}
+	if err := json.NewDecoder(res.Body).Decode(result); err != nil {
+		return fmt.Errorf("error decoding airflow api response: %w", err)
+	}
+	return nil
+}
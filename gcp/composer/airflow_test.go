@@ -0,0 +1,153 @@
+package composer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAirflowClient_ListImportErrors(t *testing.T) {
+	total := 150
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/importErrors" {
+			http.NotFound(w, r)
+			return
+		}
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		page := make([]map[string]any, 0)
+		for i := offset; i < total && i < offset+limit; i++ {
+			page = append(page, map[string]any{
+				"import_error_id": i,
+				"timestamp":       "2025-03-01T12:00:00.000000+00:00",
+				"filename":        fmt.Sprintf("/home/airflow/gcs/dags/dag_%d.py", i),
+				"stack_trace":     "Traceback (most recent call last):\n  File \"x.py\"\nModuleNotFoundError: No module named 'foo'\n",
+			})
+		}
+		json.NewEncoder(w).Encode(map[string]any{"import_errors": page, "total_entries": total})
+	}))
+	defer server.Close()
+
+	baseUrl, _ := url.Parse(server.URL)
+	client := &AirflowClient{BaseUrl: baseUrl, HttpClient: server.Client()}
+	got, err := client.ListImportErrors(context.Background())
+	require.NoError(t, err)
+	require.Len(t, got, total)
+	assert.Equal(t, "dag_149.py", got[149].DagFile())
+	assert.Equal(t, "ModuleNotFoundError: No module named 'foo'", got[0].Summary())
+	assert.Equal(t, time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC), got[0].Timestamp.UTC())
+}
+
+func TestAirflowClient_ListImportErrors_Forbidden(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "caller lacks the Airflow User role", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	baseUrl, _ := url.Parse(server.URL)
+	client := &AirflowClient{BaseUrl: baseUrl, HttpClient: server.Client()}
+	_, err := client.ListImportErrors(context.Background())
+	assert.EqualError(t, err, "airflow api returned 403 Forbidden: caller lacks the Airflow User role")
+}
+
+func TestAirflowClient_DagsAndRuns(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/dags":
+			json.NewEncoder(w).Encode(map[string]any{
+				"dags":          []map[string]any{{"dag_id": "etl", "is_paused": false, "is_active": true, "fileloc": "/home/airflow/gcs/dags/etl.py"}},
+				"total_entries": 1,
+			})
+		case "/api/v1/dags/etl/dagRuns":
+			assert.Equal(t, "-execution_date", r.URL.Query().Get("order_by"))
+			assert.Equal(t, "10", r.URL.Query().Get("limit"))
+			json.NewEncoder(w).Encode(map[string]any{
+				"dag_runs": []map[string]any{{
+					"dag_id":         "etl",
+					"dag_run_id":     "scheduled__2025-03-01T00:00:00+00:00",
+					"state":          "running",
+					"run_type":       "scheduled",
+					"execution_date": "2025-03-01T00:00:00+00:00",
+					"start_date":     "2025-03-01T00:00:05.123456+00:00",
+					"end_date":       nil,
+				}},
+				"total_entries": 1,
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	baseUrl, _ := url.Parse(server.URL)
+	client := &AirflowClient{BaseUrl: baseUrl, HttpClient: server.Client()}
+
+	dags, err := client.ListDags(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []Dag{{DagId: "etl", IsActive: true, Fileloc: "/home/airflow/gcs/dags/etl.py"}}, dags)
+
+	runs, err := client.ListDagRuns(context.Background(), "etl", 10)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, "running", runs[0].State)
+	require.NotNil(t, runs[0].StartDate)
+	assert.Nil(t, runs[0].EndDate)
+}
+
+func TestPartitionImportErrors(t *testing.T) {
+	broken := ImportError{Id: 1, Filename: "/home/airflow/gcs/dags/etl/daily.py", StackTrace: "SyntaxError: invalid syntax"}
+	known := ImportError{Id: 2, Filename: "/home/airflow/gcs/dags/etl/hourly.py", StackTrace: "ModuleNotFoundError: No module named 'foo'\n"}
+	notPushed := ImportError{Id: 3, Filename: "/home/airflow/gcs/dags/etl/removed.py", StackTrace: "SyntaxError"}
+	otherApp := ImportError{Id: 4, Filename: "/home/airflow/gcs/dags/billing/daily.py", StackTrace: "SyntaxError"}
+	importErrors := []ImportError{broken, known, notPushed, otherApp}
+
+	tests := []struct {
+		name      string
+		manifest  *pushManifest
+		wantFresh []ImportError
+		wantStale []ImportError
+	}{
+		{
+			name: "errors not in the snapshot are fresh",
+			manifest: &pushManifest{
+				Files:         []string{"etl/daily.py", "etl/hourly.py"},
+				SnapshotTaken: true,
+				ImportErrors:  []importErrorKey{{File: "etl/hourly.py", StackTrace: "ModuleNotFoundError: No module named 'foo'"}},
+			},
+			wantFresh: []ImportError{broken},
+			wantStale: []ImportError{known},
+		},
+		{
+			name:      "no snapshot",
+			manifest:  &pushManifest{Files: []string{"etl/daily.py", "etl/hourly.py"}},
+			wantStale: []ImportError{broken, known},
+		},
+		{
+			name:      "no manifest",
+			wantStale: []ImportError{broken, known, notPushed},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fresh, stale := partitionImportErrors(importErrors, "dags/etl", test.manifest)
+			assert.Equal(t, test.wantFresh, fresh)
+			assert.Equal(t, test.wantStale, stale)
+		})
+	}
+}
+
+func TestPushManifestKeys(t *testing.T) {
+	assert.Equal(t, ".nullstone/dags/etl/push-manifest.json", pushManifestKey("dags/etl"))
+	assert.Equal(t, "etl/daily.py", dagFileForObject("dags/etl/daily.py"))
+	assert.Equal(t, "daily.py", dagFileForObject("dags/daily.py"))
+}
@@ -0,0 +1,464 @@
+package composer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	DagProblemSyntax          = "syntax"
+	DagProblemForbiddenImport = "forbidden-import"
+	DagProblemDuplicateDagId  = "duplicate-dag-id"
+
+	pythonSyntaxCheckTimeout = time.Minute
+)
+
+// DefaultForbiddenDagImports lists modules that are never allowed at the top level of a DAG file
+// airflow.contrib was removed in Airflow 2; importing it breaks the whole file
+var DefaultForbiddenDagImports = []string{"airflow.contrib"}
+
+// pythonSyntaxCheck parses every file passed as an argument and prints one JSON line per syntax error
+// It exits with code 3 under Python 2, whose grammar would reject valid Python 3 DAGs
+const pythonSyntaxCheck = `
+import ast, json, sys
+if sys.version_info[0] < 3:
+    sys.exit(3)
+for path in sys.argv[1:]:
+    try:
+        with open(path, "rb") as f:
+            ast.parse(f.read(), filename=path)
+    except SyntaxError as e:
+        print(json.dumps({"file": path, "line": e.lineno or 0, "message": e.msg}))
+    except ValueError as e:
+        print(json.dumps({"file": path, "line": 0, "message": str(e)}))
+`
+
+// DagProblem is a problem found in a DAG file that would break the Airflow scheduler
+type DagProblem struct {
+	File    string
+	Line    int
+	Kind    string
+	Message string
+}
+
+func (p DagProblem) String() string {
+	if p.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", p.File, p.Line, p.Message)
+	}
+	return fmt.Sprintf("%s: %s", p.File, p.Message)
+}
+
+// DagValidation is the result of validating the DAG files in a source directory
+type DagValidation struct {
+	// Files is the number of Python files that were validated
+	Files int
+	// SyntaxChecker describes how syntax was checked (a local python interpreter or the static check)
+	SyntaxChecker string
+	Problems      []DagProblem
+}
+
+// ValidateDags checks the Python files among filepaths (relative to baseDir) before they are pushed:
+//   - syntax errors, using a local python3 if available, otherwise a static check for unterminated strings and unbalanced brackets
+//   - module-level imports of forbiddenImports (or a submodule of one)
+//   - DAG ids defined more than once; only DAG ids written as string literals are detected
+//
+// An error is returned only if the files could not be read.
+func ValidateDags(ctx context.Context, baseDir string, filepaths []string, forbiddenImports []string) (DagValidation, error) {
+	result := DagValidation{Problems: make([]DagProblem, 0)}
+	pyFiles := make([]string, 0)
+	for _, rel := range filepaths {
+		if strings.EqualFold(filepath.Ext(rel), ".py") {
+			pyFiles = append(pyFiles, rel)
+		}
+	}
+	sort.Strings(pyFiles)
+	result.Files = len(pyFiles)
+	if len(pyFiles) == 0 {
+		return result, nil
+	}
+
+	sources := make(map[string][]byte, len(pyFiles))
+	for _, rel := range pyFiles {
+		src, err := os.ReadFile(filepath.Join(baseDir, rel))
+		if err != nil {
+			return result, fmt.Errorf("error reading %q: %w", rel, err)
+		}
+		sources[rel] = src
+	}
+
+	syntaxProblems, checker, err := pythonSyntaxProblems(ctx, baseDir, pyFiles)
+	if err != nil {
+		checker = fmt.Sprintf("static check (python unavailable: %s)", err)
+		syntaxProblems = make([]DagProblem, 0)
+		for _, rel := range pyFiles {
+			if problem := staticSyntaxProblem(rel, sources[rel]); problem != nil {
+				syntaxProblems = append(syntaxProblems, *problem)
+			}
+		}
+	}
+	result.SyntaxChecker = checker
+	result.Problems = append(result.Problems, syntaxProblems...)
+
+	dagIds := map[string][]DagProblem{}
+	for _, rel := range pyFiles {
+		src := sources[rel]
+		result.Problems = append(result.Problems, forbiddenImportProblems(rel, src, forbiddenImports)...)
+		for _, def := range findDagIds(src) {
+			dagIds[def.id] = append(dagIds[def.id], DagProblem{File: rel, Line: def.line, Kind: DagProblemDuplicateDagId})
+		}
+	}
+	result.Problems = append(result.Problems, duplicateDagIdProblems(dagIds)...)
+	return result, nil
+}
+
+// pythonSyntaxProblems checks syntax with the local python interpreter
+// Returns an error if no usable python 3 interpreter is available
+func pythonSyntaxProblems(ctx context.Context, baseDir string, pyFiles []string) ([]DagProblem, string, error) {
+	python, err := exec.LookPath("python3")
+	if err != nil {
+		if python, err = exec.LookPath("python"); err != nil {
+			return nil, "", errors.New("python3 not found on PATH")
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, pythonSyntaxCheckTimeout)
+	defer cancel()
+	args := []string{"-I", "-c", pythonSyntaxCheck}
+	byPath := map[string]string{}
+	for _, rel := range pyFiles {
+		full := filepath.Join(baseDir, rel)
+		byPath[full] = rel
+		args = append(args, full)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, python, args...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, "", fmt.Errorf("%s: %s", err, msg)
+		}
+		return nil, "", err
+	}
+
+	problems := make([]DagProblem, 0)
+	scanner := bufio.NewScanner(&stdout)
+	for scanner.Scan() {
+		var e struct {
+			File    string `json:"file"`
+			Line    int    `json:"line"`
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, "", fmt.Errorf("unexpected output from %s: %s", python, scanner.Text())
+		}
+		file := byPath[e.File]
+		if file == "" {
+			file = e.File
+		}
+		problems = append(problems, DagProblem{File: file, Line: e.Line, Kind: DagProblemSyntax, Message: e.Message})
+	}
+	return problems, python, nil
+}
+
+// staticSyntaxProblem is the fallback syntax check when python is unavailable
+// It only finds structural errors: unterminated strings and unbalanced or mismatched brackets
+func staticSyntaxProblem(file string, src []byte) *DagProblem {
+	scan := scanPython(src)
+	if scan.problem == "" {
+		return nil
+	}
+	return &DagProblem{File: file, Line: scan.problemLine, Kind: DagProblemSyntax, Message: scan.problem}
+}
+
+type pythonScan struct {
+	// topLevelLines are the (1-based) lines that start an unindented statement
+	topLevelLines []int
+	// problem is the first structural syntax error found
+	problem     string
+	problemLine int
+}
+
+// scanPython walks Python source, tracking comments, string literals, and bracket nesting
+func scanPython(src []byte) pythonScan {
+	var result pythonScan
+	type bracket struct {
+		char byte
+		line int
+	}
+	closers := map[byte]byte{')': '(', ']': '[', '}': '{'}
+	var stack []bracket
+	line := 1
+	lineStart := true
+	continued := false
+	fail := func(l int, msg string) pythonScan {
+		result.problem, result.problemLine = msg, l
+		return result
+	}
+
+	for i := 0; i < len(src); {
+		if lineStart {
+			lineStart = false
+			j := i
+			for j < len(src) && (src[j] == ' ' || src[j] == '\t' || src[j] == '\f') {
+				j++
+			}
+			blank := j >= len(src) || src[j] == '\n' || src[j] == '\r' || src[j] == '#'
+			if !blank && j == i && len(stack) == 0 && !continued {
+				result.topLevelLines = append(result.topLevelLines, line)
+			}
+			if !blank {
+				continued = false
+			}
+			i = j
+			continue
+		}
+
+		c := src[i]
+		switch {
+		case c == '#':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case c == '\\' && (hasPrefixAt(src, i+1, "\n") || hasPrefixAt(src, i+1, "\r\n")):
+			continued = true
+			i = bytes.IndexByte(src[i:], '\n') + i + 1
+			line++
+			lineStart = true
+		case c == '\n':
+			line++
+			i++
+			lineStart = true
+		case c == '"' || c == '\'':
+			start := line
+			triple := hasPrefixAt(src, i, strings.Repeat(string(c), 3))
+			if triple {
+				i += 3
+			} else {
+				i++
+			}
+			closed := false
+			for i < len(src) {
+				ch := src[i]
+				if ch == '\\' && i+1 < len(src) {
+					if src[i+1] == '\n' {
+						line++
+					}
+					i += 2
+					continue
+				}
+				if ch == '\n' {
+					if !triple {
+						return fail(start, "unterminated string literal")
+					}
+					line++
+				}
+				if ch == c && (!triple || hasPrefixAt(src, i, strings.Repeat(string(c), 3))) {
+					if triple {
+						i += 3
+					} else {
+						i++
+					}
+					closed = true
+					break
+				}
+				i++
+			}
+			if !closed {
+				if triple {
+					return fail(start, "unterminated triple-quoted string literal")
+				}
+				return fail(start, "unterminated string literal")
+			}
+		case c == '(' || c == '[' || c == '{':
+			stack = append(stack, bracket{char: c, line: line})
+			i++
+		case c == ')' || c == ']' || c == '}':
+			if len(stack) == 0 {
+				return fail(line, fmt.Sprintf("unmatched '%c'", c))
+			}
+			top := stack[len(stack)-1]
+			if top.char != closers[c] {
+				if top.line == line {
+					return fail(line, fmt.Sprintf("closing parenthesis '%c' does not match opening parenthesis '%c'", c, top.char))
+				}
+				return fail(line, fmt.Sprintf("closing parenthesis '%c' does not match opening parenthesis '%c' on line %d", c, top.char, top.line))
+			}
+			stack = stack[:len(stack)-1]
+			i++
+		default:
+			i++
+		}
+	}
+	if len(stack) > 0 {
+		top := stack[len(stack)-1]
+		return fail(top.line, fmt.Sprintf("'%c' was never closed", top.char))
+	}
+	return result
+}
+
+func hasPrefixAt(src []byte, i int, prefix string) bool {
+	return i <= len(src) && bytes.HasPrefix(src[i:], []byte(prefix))
+}
+
+// forbiddenImportProblems reports module-level (unindented) imports of a forbidden module or one of its submodules
+// Airflow executes these on every parse of the file, so a failing or slow import breaks or stalls the scheduler
+func forbiddenImportProblems(file string, src []byte, forbidden []string) []DagProblem {
+	problems := make([]DagProblem, 0)
+	if len(forbidden) == 0 {
+		return problems
+	}
+	scan := scanPython(src)
+	lines := strings.Split(string(src), "\n")
+	for _, lineNo := range scan.topLevelLines {
+		if lineNo > len(lines) {
+			continue
+		}
+		for _, module := range importedModules(lines[lineNo-1]) {
+			for _, f := range forbidden {
+				if module == f || strings.HasPrefix(module, f+".") {
+					problems = append(problems, DagProblem{
+						File:    file,
+						Line:    lineNo,
+						Kind:    DagProblemForbiddenImport,
+						Message: fmt.Sprintf("module-level import of forbidden module %q", module),
+					})
+				}
+			}
+		}
+	}
+	return problems
+}
+
+// importedModules returns the modules imported by an `import` or `from ... import` statement
+func importedModules(stmt string) []string {
+	stmt, _, _ = strings.Cut(stmt, "#")
+	stmt = strings.TrimSpace(stmt)
+	if rest, ok := strings.CutPrefix(stmt, "from "); ok {
+		module, _, _ := strings.Cut(strings.TrimSpace(rest), " ")
+		if module == "" || strings.HasPrefix(module, ".") {
+			return nil
+		}
+		return []string{module}
+	}
+	rest, ok := strings.CutPrefix(stmt, "import ")
+	if !ok {
+		return nil
+	}
+	rest, _, _ = strings.Cut(rest, ";")
+	modules := make([]string, 0)
+	for _, part := range strings.Split(rest, ",") {
+		module, _, _ := strings.Cut(strings.TrimSpace(strings.Trim(strings.TrimSpace(part), "()\\")), " ")
+		if module != "" {
+			modules = append(modules, module)
+		}
+	}
+	return modules
+}
+
+var (
+	dagCallPattern        = regexp.MustCompile(`(@dag\b|\bDAG)\s*\(`)
+	dagBareDecorator      = regexp.MustCompile(`(?m)^[ \t]*@dag[ \t]*(#.*)?$`)
+	dagPositionalIdRegexp = regexp.MustCompile(`^\s*["']([^"'\n]+)["']\s*(,|$)`)
+	dagIdKeywordRegexp    = regexp.MustCompile(`\bdag_id\s*=\s*["']([^"'\n]+)["']`)
+	defNameRegexp         = regexp.MustCompile(`^\s*(?:async\s+)?def\s+(\w+)`)
+)
+
+type dagIdDef struct {
+	id   string
+	line int
+}
+
+// findDagIds finds DAG ids defined with `DAG(...)` or the `@dag` decorator
+// A decorated function without an explicit dag_id uses the function name as its DAG id
+func findDagIds(src []byte) []dagIdDef {
+	text := string(src)
+	defs := make([]dagIdDef, 0)
+	lineOf := func(offset int) int { return strings.Count(text[:offset], "\n") + 1 }
+
+	for _, loc := range dagCallPattern.FindAllStringSubmatchIndex(text, -1) {
+		if isCommentedOut(text, loc[0]) {
+			continue
+		}
+		args, end := callArgs(text, loc[1])
+		id := ""
+		if m := dagPositionalIdRegexp.FindStringSubmatch(args); m != nil {
+			id = m[1]
+		} else if m := dagIdKeywordRegexp.FindStringSubmatch(args); m != nil {
+			id = m[1]
+		} else if text[loc[2]:loc[3]] == "@dag" {
+			if m := defNameRegexp.FindStringSubmatch(text[end:]); m != nil {
+				id = m[1]
+			}
+		}
+		if id != "" {
+			defs = append(defs, dagIdDef{id: id, line: lineOf(loc[0])})
+		}
+	}
+	for _, loc := range dagBareDecorator.FindAllStringIndex(text, -1) {
+		if m := defNameRegexp.FindStringSubmatch(text[loc[1]:]); m != nil {
+			defs = append(defs, dagIdDef{id: m[1], line: lineOf(loc[0])})
+		}
+	}
+	return defs
+}
+
+// callArgs returns the text between the opening parenthesis (ending at start) and its matching closing parenthesis
+func callArgs(text string, start int) (string, int) {
+	depth := 1
+	for i := start; i < len(text); i++ {
+		switch text[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return text[start:i], i + 1
+			}
+		}
+	}
+	return text[start:], len(text)
+}
+
+func isCommentedOut(text string, offset int) bool {
+	lineStart := strings.LastIndexByte(text[:offset], '\n') + 1
+	return strings.Contains(text[lineStart:offset], "#")
+}
+
+// duplicateDagIdProblems reports every definition of a DAG id that is defined more than once
+// Airflow keeps only one of them, so the others silently disappear
+func duplicateDagIdProblems(dagIds map[string][]DagProblem) []DagProblem {
+	ids := make([]string, 0, len(dagIds))
+	for id, defs := range dagIds {
+		if len(defs) > 1 {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	problems := make([]DagProblem, 0)
+	for _, id := range ids {
+		defs := dagIds[id]
+		for i, def := range defs {
+			others := make([]string, 0, len(defs)-1)
+			for j, other := range defs {
+				if j != i {
+					others = append(others, fmt.Sprintf("%s:%d", other.File, other.Line))
+				}
+			}
+			def.Message = fmt.Sprintf("DAG id %q is also defined at %s", id, strings.Join(others, ", "))
+			problems = append(problems, def)
+		}
+	}
+	return problems
+}
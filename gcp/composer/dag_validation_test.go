@@ -0,0 +1,163 @@
+package composer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticSyntaxProblem(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		wantLine int
+		wantMsg  string
+	}{
+		{
+			name: "valid",
+			src:  "from airflow import DAG\n\nwith DAG(\n    'etl',  # comment with ) and '\n    tags=[\"a\"],\n) as dag:\n    pass\n",
+		},
+		{
+			name: "brackets and quotes inside strings",
+			src:  "s = '(['\nt = \"\"\"\n  ]) unbalanced in a docstring\n\"\"\"\nu = 'it\\'s'\n",
+		},
+		{
+			name:     "never closed",
+			src:      "x = dict(\n    a=1,\n",
+			wantLine: 1,
+			wantMsg:  "'(' was never closed",
+		},
+		{
+			name:     "mismatched",
+			src:      "x = [\n    1,\n)\n",
+			wantLine: 3,
+			wantMsg:  "closing parenthesis ')' does not match opening parenthesis '[' on line 1",
+		},
+		{
+			name:     "unmatched",
+			src:      "x = 1)\n",
+			wantLine: 1,
+			wantMsg:  "unmatched ')'",
+		},
+		{
+			name:     "unterminated string",
+			src:      "a = 1\nb = 'abc\nc = 2\n",
+			wantLine: 2,
+			wantMsg:  "unterminated string literal",
+		},
+		{
+			name:     "unterminated docstring",
+			src:      "\"\"\"docs\n\nx = 1\n",
+			wantLine: 1,
+			wantMsg:  "unterminated triple-quoted string literal",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := staticSyntaxProblem("dag.py", []byte(test.src))
+			if test.wantMsg == "" {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			assert.Equal(t, DagProblem{File: "dag.py", Line: test.wantLine, Kind: DagProblemSyntax, Message: test.wantMsg}, *got)
+		})
+	}
+}
+
+func TestForbiddenImportProblems(t *testing.T) {
+	src := `import os, pandas as pd
+from airflow.contrib.operators import bigquery_operator
+from . import helpers
+x = (
+import_thing)
+
+def task():
+    import tensorflow
+"""
+import tensorflow
+"""
+import tensorflow.keras  # heavy
+`
+	got := forbiddenImportProblems("dag.py", []byte(src), []string{"airflow.contrib", "pandas", "tensorflow"})
+	assert.Equal(t, []DagProblem{
+		{File: "dag.py", Line: 1, Kind: DagProblemForbiddenImport, Message: `module-level import of forbidden module "pandas"`},
+		{File: "dag.py", Line: 2, Kind: DagProblemForbiddenImport, Message: `module-level import of forbidden module "airflow.contrib.operators"`},
+		{File: "dag.py", Line: 12, Kind: DagProblemForbiddenImport, Message: `module-level import of forbidden module "tensorflow.keras"`},
+	}, got)
+}
+
+func TestFindDagIds(t *testing.T) {
+	src := `from airflow import DAG
+from airflow.decorators import dag
+
+with DAG("positional", schedule=None) as d1:
+    pass
+
+d2 = models.DAG(
+    default_args=dict(owner="data"),
+    dag_id='keyword',
+)
+
+@dag(schedule="@daily")
+def decorated():
+    pass
+
+@dag
+def bare():
+    pass
+
+@dag(dag_id="explicit")
+def ignored_name():
+    pass
+
+# DAG("commented")
+trigger = TriggerDagRunOperator(task_id="t", trigger_dag_id="other")
+dynamic = DAG(dag_id=f"etl_{name}")
+`
+	got := findDagIds([]byte(src))
+	assert.ElementsMatch(t, []dagIdDef{
+		{id: "positional", line: 4},
+		{id: "keyword", line: 7},
+		{id: "decorated", line: 12},
+		{id: "bare", line: 16},
+		{id: "explicit", line: 20},
+	}, got)
+}
+
+func TestValidateDags(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"etl.py":            "from airflow import DAG\nwith DAG('etl') as dag:\n    pass\n",
+		"reports/daily.py":  "from airflow import DAG\nwith DAG(dag_id='etl') as dag:\n    pass\n",
+		"legacy.py":         "from airflow.contrib.sensors import gcs_sensor\n",
+		"broken.py":         "x = [\n",
+		"sql/query.sql":     "select (\n",
+		"reports/README.md": "# ((",
+	}
+	filepaths := make([]string, 0)
+	for rel, content := range files {
+		full := filepath.Join(dir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0755))
+		require.NoError(t, os.WriteFile(full, []byte(content), 0644))
+		filepaths = append(filepaths, rel)
+	}
+
+	got, err := ValidateDags(context.Background(), dir, filepaths, DefaultForbiddenDagImports)
+	require.NoError(t, err)
+	assert.Equal(t, 4, got.Files)
+	assert.NotEmpty(t, got.SyntaxChecker)
+
+	kinds := map[string][]string{}
+	for _, p := range got.Problems {
+		kinds[p.Kind] = append(kinds[p.Kind], p.File)
+	}
+	assert.Equal(t, []string{"broken.py"}, kinds[DagProblemSyntax])
+	assert.Equal(t, []string{"legacy.py"}, kinds[DagProblemForbiddenImport])
+	assert.Equal(t, []string{"etl.py", "reports/daily.py"}, kinds[DagProblemDuplicateDagId])
+}
@@ -4,11 +4,21 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	service "cloud.google.com/go/orchestration/airflow/service/apiv1"
+	"cloud.google.com/go/orchestration/airflow/service/apiv1/servicepb"
+	"cloud.google.com/go/storage"
 	"github.com/nullstone-io/deployment-sdk/app"
 	"github.com/nullstone-io/deployment-sdk/logging"
 	"github.com/nullstone-io/deployment-sdk/outputs"
+	"google.golang.org/api/option"
+)
+
+const (
+	defaultDagParseWait = 2 * time.Minute
+	importErrorsDelay   = 15 * time.Second
 )
 
 func NewDeployWatcher(ctx context.Context, osWriters logging.OsWriters, source outputs.RetrieverSource, appDetails app.Details) (app.DeployWatcher, error) {
@@ -25,7 +35,8 @@ func NewDeployWatcher(ctx context.Context, osWriters logging.OsWriters, source o
 	}, nil
 }
 
-// DeployWatcher monitors the long-running operation produced by a Composer environment update.
+// DeployWatcher monitors the long-running operation produced by a Composer environment update,
+// then waits for Airflow to parse the pushed DAG files and reports any import errors.
 type DeployWatcher struct {
 	OsWriters logging.OsWriters
 	Details   app.Details
@@ -33,9 +44,9 @@ type DeployWatcher struct {
 }
 
 func (w DeployWatcher) Watch(ctx context.Context, reference string, isFirstDeploy bool) error {
-	stdout, stderr := w.OsWriters.Stdout(), w.OsWriters.Stderr()
+	stdout := w.OsWriters.Stdout()
 
-	if reference == "" {
+	if reference == "" && w.dagParseWait() <= 0 {
 		fmt.Fprintf(stdout, "This deployment does not have to wait for any resource to become healthy.\n")
 		return nil
 	}
@@ -46,6 +57,17 @@ func (w DeployWatcher) Watch(ctx context.Context, reference string, isFirstDeplo
 	}
 	defer client.Close()
 
+	if reference != "" {
+		if err := w.waitForUpdate(ctx, client, reference); err != nil {
+			return err
+		}
+	}
+	return w.watchImportErrors(ctx, client)
+}
+
+func (w DeployWatcher) waitForUpdate(ctx context.Context, client *service.EnvironmentsClient, reference string) error {
+	stdout, stderr := w.OsWriters.Stdout(), w.OsWriters.Stderr()
+
 	fmt.Fprintf(stdout, "Waiting for the Composer environment to apply the updated configuration...\n")
 
 	// Composer environment updates are slow (often 10-25 minutes), so we allow a generous timeout.
@@ -69,13 +91,7 @@ func (w DeployWatcher) Watch(ctx context.Context, reference string, isFirstDeplo
 
 		select {
 		case <-ctx.Done():
-			if cerr := ctx.Err(); cerr != nil {
-				if errors.Is(cerr, context.DeadlineExceeded) {
-					return app.ErrTimeout
-				}
-				return &app.CancelError{Reason: cerr.Error()}
-			}
-			return &app.CancelError{}
+			return cancelError(ctx)
 		case <-t1:
 			return app.ErrTimeout
 		case <-time.After(delay):
@@ -83,3 +99,108 @@ func (w DeployWatcher) Watch(ctx context.Context, reference string, isFirstDeplo
 		}
 	}
 }
+
+// watchImportErrors waits for Airflow to parse the pushed DAG files and fails the deployment if any of them fail to import.
+// Only import errors for files this app pushed fail it, and only if Airflow did not already report them before the push
+// (see pushManifest); other import errors for this app's DAG files are reported as warnings.
+// This check is best-effort: if the Airflow REST API can't be reached, the deployment is not failed.
+func (w DeployWatcher) watchImportErrors(ctx context.Context, client *service.EnvironmentsClient) error {
+	stdout, stderr := w.OsWriters.Stdout(), w.OsWriters.Stderr()
+	wait := w.dagParseWait()
+	if wait <= 0 {
+		return nil
+	}
+
+	prefix := dagObjectPrefix(w.Infra)
+	manifest, err := w.readPushManifest(ctx)
+	if err != nil {
+		fmt.Fprintf(stderr, "Unable to determine which DAG files were pushed; import errors will be reported as warnings: %s\n", err)
+	} else if manifest == nil {
+		fmt.Fprintln(stderr, "No push manifest was found for this app; import errors will be reported as warnings")
+	}
+
+	env, err := client.GetEnvironment(ctx, &servicepb.GetEnvironmentRequest{Name: environmentResourceName(w.Infra)})
+	if err != nil {
+		fmt.Fprintf(stderr, "Unable to check for DAG import errors: error getting Composer environment: %s\n", err)
+		return nil
+	}
+	airflowUri := env.GetConfig().GetAirflowUri()
+	if airflowUri == "" {
+		fmt.Fprintln(stderr, "Unable to check for DAG import errors: the Composer environment has no Airflow web server")
+		return nil
+	}
+	airflow, err := NewAirflowClient(ctx, airflowUri, w.Infra.Deployer)
+	if err != nil {
+		fmt.Fprintf(stderr, "Unable to check for DAG import errors: %s\n", err)
+		return nil
+	}
+
+	fmt.Fprintf(stdout, "Waiting %s for Airflow to parse DAGs...\n", wait)
+	deadline := time.After(wait)
+	for {
+		importErrors, err := airflow.ListImportErrors(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return cancelError(ctx)
+			}
+			fmt.Fprintf(stderr, "Unable to check for DAG import errors: %s\n", err)
+			return nil
+		}
+		fresh, stale := partitionImportErrors(importErrors, prefix, manifest)
+		if len(fresh) > 0 {
+			for _, ie := range fresh {
+				fmt.Fprintf(stderr, "DAG import error in %s: %s\n", ie.DagFile(), ie.Summary())
+				fmt.Fprintf(stderr, "    %s\n", strings.ReplaceAll(strings.TrimSpace(ie.StackTrace), "\n", "\n    "))
+			}
+			fmt.Fprintf(stderr, "Deployment failed: Airflow reported import errors for %d DAG file(s)\n", len(fresh))
+			return app.ErrFailed
+		}
+
+		select {
+		case <-ctx.Done():
+			return cancelError(ctx)
+		case <-deadline:
+			for _, ie := range stale {
+				fmt.Fprintf(stderr, "DAG import error in %s not attributed to this deployment: %s\n", ie.DagFile(), ie.Summary())
+			}
+			fmt.Fprintln(stdout, "Airflow reported no new DAG import errors")
+			return nil
+		case <-time.After(importErrorsDelay):
+		}
+	}
+}
+
+func (w DeployWatcher) dagParseWait() time.Duration {
+	if w.Infra.DagParseWaitSeconds == 0 {
+		return defaultDagParseWait
+	}
+	return time.Duration(w.Infra.DagParseWaitSeconds) * time.Second
+}
+
+// readPushManifest reads the manifest written by the last push of this app's DAG files, or nil if there is none
+func (w DeployWatcher) readPushManifest(ctx context.Context) (*pushManifest, error) {
+	bucket := dagBucket(w.Infra)
+	if bucket == "" {
+		return nil, errors.New("this app is missing the DAG bucket output (dag_gcs_bucket/dag_gcs_prefix)")
+	}
+	tokenSource, err := w.Infra.Pusher.TokenSource(ctx, ReadScopes...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating token source from service account: %w", err)
+	}
+	client, err := storage.NewClient(ctx, option.WithTokenSource(tokenSource))
+	if err != nil {
+		return nil, fmt.Errorf("error creating google storage client: %w", err)
+	}
+	defer client.Close()
+	return readPushManifest(ctx, client.Bucket(bucket), dagObjectPrefix(w.Infra))
+}
+
+func cancelError(ctx context.Context) error {
+	if cerr := ctx.Err(); cerr != nil {
+		if errors.Is(cerr, context.DeadlineExceeded) {
+			return app.ErrTimeout
+		}
+		return &app.CancelError{Reason: cerr.Error()}
+	}
+	return &app.CancelError{}
+}
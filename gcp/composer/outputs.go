@@ -21,6 +21,13 @@ type Outputs struct {
 	Deployer gcp.ServiceAccount `ns:"deployer"`
 	// Pusher syncs DAG files to the Composer-managed GCS bucket.
 	Pusher gcp.ServiceAccount `ns:"pusher"`
+
+	// ForbiddenDagImports lists modules that DAG files may not import at the module level (in addition to DefaultForbiddenDagImports).
+	ForbiddenDagImports []string `ns:"forbidden_dag_imports,optional"`
+	// SkipDagValidation disables the checks run on DAG files before they are pushed.
+	SkipDagValidation bool `ns:"skip_dag_validation,optional"`
+	// DagParseWaitSeconds is how long the deploy watcher waits for Airflow to report DAG import errors (default 120, negative disables).
+	DagParseWaitSeconds int `ns:"dag_parse_wait_seconds,optional"`
 }
 
 func (o *Outputs) InitializeCreds(source outputs.RetrieverSource, ws *types.Workspace) {
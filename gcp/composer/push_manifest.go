@@ -0,0 +1,158 @@
+package composer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/orchestration/airflow/service/apiv1/servicepb"
+	"cloud.google.com/go/storage"
+)
+
+// dagFolderObjectPrefix is the folder of the environment bucket that Composer mounts as the Airflow DAG folder
+const dagFolderObjectPrefix = "dags"
+
+// pushManifest records what a push uploaded so that the deploy watcher can attribute DAG import errors to it
+// It is stored in the environment bucket outside the folders that Composer syncs to Airflow (see pushManifestKey)
+type pushManifest struct {
+	PushedAt time.Time `json:"pushedAt"`
+	// Files are the pushed DAG files relative to the DAG folder (comparable with ImportError.DagFile)
+	Files []string `json:"files"`
+	// SnapshotTaken is false if the import errors could not be retrieved from Airflow before the push
+	SnapshotTaken bool `json:"snapshotTaken"`
+	// ImportErrors are the import errors Airflow reported for the pushed files before the push
+	ImportErrors []importErrorKey `json:"importErrors"`
+}
+
+// importErrorKey identifies an import error independently of when Airflow last reparsed the file
+type importErrorKey struct {
+	File       string `json:"file"`
+	StackTrace string `json:"stackTrace"`
+}
+
+func keyOfImportError(ie ImportError) importErrorKey {
+	return importErrorKey{File: ie.DagFile(), StackTrace: strings.TrimSpace(ie.StackTrace)}
+}
+
+// pushManifestKey returns the object key of the push manifest for the DAG prefix (e.g. dags/etl -> .nullstone/dags/etl/push-manifest.json)
+func pushManifestKey(prefix string) string {
+	return path.Join(".nullstone", prefix, "push-manifest.json")
+}
+
+// dagFileForObject returns the path of an object in the environment bucket relative to the DAG folder
+// (e.g. dags/etl/daily.py -> etl/daily.py)
+func dagFileForObject(objectKey string) string {
+	return strings.TrimPrefix(objectKey, dagFolderObjectPrefix+"/")
+}
+
+// appDagFolder returns the app's folder relative to the DAG folder (e.g. dags/etl -> etl)
+// Returns "" if the app deploys to the root of the DAG folder
+func appDagFolder(prefix string) string {
+	folder := dagFileForObject(prefix)
+	if folder == dagFolderObjectPrefix {
+		return ""
+	}
+	return folder
+}
+
+// inAppDagFolder reports whether a file relative to the DAG folder belongs to the app's folder (see appDagFolder)
+func inAppDagFolder(file, appFolder string) bool {
+	return appFolder == "" || strings.HasPrefix(file, appFolder+"/")
+}
+
+func writePushManifest(ctx context.Context, bkt *storage.BucketHandle, prefix string, manifest pushManifest) error {
+	raw, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	writer := bkt.Object(pushManifestKey(prefix)).NewWriter(ctx)
+	writer.ContentType = "application/json"
+	if _, err := writer.Write(raw); err != nil {
+		writer.Close()
+		return fmt.Errorf("error writing push manifest: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("error writing push manifest: %w", err)
+	}
+	return nil
+}
+
+// readPushManifest returns the manifest of the last push to the DAG prefix, or nil if there is none
+func readPushManifest(ctx context.Context, bkt *storage.BucketHandle, prefix string) (*pushManifest, error) {
+	reader, err := bkt.Object(pushManifestKey(prefix)).NewReader(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading push manifest: %w", err)
+	}
+	defer reader.Close()
+	var manifest pushManifest
+	if err := json.NewDecoder(reader).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("error decoding push manifest: %w", err)
+	}
+	return &manifest, nil
+}
+
+// snapshotImportErrors retrieves the import errors Airflow currently reports for files
+func snapshotImportErrors(ctx context.Context, infra Outputs, files []string) ([]importErrorKey, error) {
+	client, err := NewEnvironmentsClient(ctx, infra.Pusher)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Composer client: %w", err)
+	}
+	defer client.Close()
+	env, err := client.GetEnvironment(ctx, &servicepb.GetEnvironmentRequest{Name: environmentResourceName(infra)})
+	if err != nil {
+		return nil, fmt.Errorf("error getting Composer environment: %w", err)
+	}
+	airflowUri := env.GetConfig().GetAirflowUri()
+	if airflowUri == "" {
+		return nil, errors.New("the Composer environment has no Airflow web server")
+	}
+	airflow, err := NewAirflowClient(ctx, airflowUri, infra.Pusher)
+	if err != nil {
+		return nil, err
+	}
+	importErrors, err := airflow.ListImportErrors(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]importErrorKey, 0)
+	for _, ie := range importErrors {
+		if slices.Contains(files, ie.DagFile()) {
+			keys = append(keys, keyOfImportError(ie))
+		}
+	}
+	return keys, nil
+}
+
+// partitionImportErrors splits the import errors for this app's DAG files into those introduced by the push (fresh)
+// and those that already existed before it (stale)
+// Import errors for files outside the app's DAG prefix or that were not pushed are dropped.
+// If there is no manifest, or no snapshot was taken before the push, errors cannot be attributed to the push and are all stale.
+func partitionImportErrors(importErrors []ImportError, prefix string, manifest *pushManifest) (fresh []ImportError, stale []ImportError) {
+	appFolder := appDagFolder(prefix)
+	for _, ie := range importErrors {
+		file := ie.DagFile()
+		if !inAppDagFolder(file, appFolder) {
+			continue
+		}
+		if manifest == nil {
+			stale = append(stale, ie)
+			continue
+		}
+		if !slices.Contains(manifest.Files, file) {
+			continue
+		}
+		if !manifest.SnapshotTaken || slices.Contains(manifest.ImportErrors, keyOfImportError(ie)) {
+			stale = append(stale, ie)
+		} else {
+			fresh = append(fresh, ie)
+		}
+	}
+	return fresh, stale
+}
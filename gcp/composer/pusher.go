@@ -9,6 +9,7 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"cloud.google.com/go/storage"
 	"github.com/nullstone-io/deployment-sdk/app"
@@ -19,7 +20,10 @@ import (
 	"google.golang.org/api/option"
 )
 
-var ReadWriteScopes = []string{"https://www.googleapis.com/auth/devstorage.read_write"}
+var (
+	ReadScopes      = []string{"https://www.googleapis.com/auth/devstorage.read_only"}
+	ReadWriteScopes = []string{"https://www.googleapis.com/auth/devstorage.read_write"}
+)
 
 func NewPusher(ctx context.Context, osWriters logging.OsWriters, source outputs.RetrieverSource, appDetails app.Details) (app.Pusher, error) {
 	outs, err := outputs.Retrieve[Outputs](ctx, source, appDetails.Workspace, appDetails.WorkspaceConfig)
@@ -71,6 +75,13 @@ func (p Pusher) Push(ctx context.Context, source, version string) error {
 		return fmt.Errorf("error scanning source: %w", err)
 	}
 
+	// A DAG file that fails to parse breaks the scheduler, so nothing is uploaded unless every DAG file is valid.
+	if !p.Infra.SkipDagValidation {
+		if err := p.validateDags(ctx, source, filepaths); err != nil {
+			return err
+		}
+	}
+
 	client, err := p.newStorageClient(ctx)
 	if err != nil {
 		return err
@@ -78,6 +89,18 @@ func (p Pusher) Push(ctx context.Context, source, version string) error {
 	defer client.Close()
 	bkt := client.Bucket(bucket)
 
+	// Record the import errors Airflow reports before the push so the deploy watcher only fails on errors this push introduced.
+	manifest := pushManifest{PushedAt: time.Now(), Files: make([]string, 0, len(filepaths))}
+	for _, rel := range filepaths {
+		manifest.Files = append(manifest.Files, dagFileForObject(path.Join(prefix, filepath.ToSlash(rel))))
+	}
+	if snapshot, err := snapshotImportErrors(ctx, p.Infra, manifest.Files); err != nil {
+		fmt.Fprintf(stderr, "Unable to record DAG import errors before the push; new import errors will be reported as warnings: %s\n", err)
+	} else {
+		manifest.SnapshotTaken = true
+		manifest.ImportErrors = snapshot
+	}
+
 	fmt.Fprintf(stderr, "Syncing %s to gs://%s/%s...\n", source, bucket, prefix)
 
 	// Upload every local file, recording the object keys we expect to exist in the destination.
@@ -95,11 +118,35 @@ func (p Pusher) Push(ctx context.Context, source, version string) error {
 	if err := p.deleteUnmatched(ctx, bkt, prefix, desired); err != nil {
 		return err
 	}
+	if err := writePushManifest(ctx, bkt, prefix, manifest); err != nil {
+		return err
+	}
 
 	fmt.Fprintln(stderr, "Sync complete")
 	return nil
 }
 
+func (p Pusher) validateDags(ctx context.Context, source string, filepaths []string) error {
+	stderr := p.OsWriters.Stderr()
+
+	forbidden := append(append([]string{}, DefaultForbiddenDagImports...), p.Infra.ForbiddenDagImports...)
+	result, err := ValidateDags(ctx, source, filepaths, forbidden)
+	if err != nil {
+		return fmt.Errorf("error validating DAG files: %w", err)
+	}
+	if result.Files == 0 {
+		return nil
+	}
+	fmt.Fprintf(stderr, "Validated %d DAG file(s) (syntax check: %s)\n", result.Files, result.SyntaxChecker)
+	if len(result.Problems) == 0 {
+		return nil
+	}
+	for _, problem := range result.Problems {
+		fmt.Fprintf(stderr, "  %s\n", problem)
+	}
+	return fmt.Errorf("DAG validation found %d problem(s); nothing was uploaded", len(result.Problems))
+}
+
 func (p Pusher) deleteUnmatched(ctx context.Context, bkt *storage.BucketHandle, prefix string, desired map[string]struct{}) error {
 	stderr := p.OsWriters.Stderr()
 
@@ -0,0 +1,81 @@
+package composer
+
+import "time"
+
+type EnvironmentState string
+
+const (
+	EnvironmentStateCreating EnvironmentState = "Creating"
+	EnvironmentStateRunning  EnvironmentState = "Running"
+	EnvironmentStateUpdating EnvironmentState = "Updating"
+	EnvironmentStateDeleting EnvironmentState = "Deleting"
+	EnvironmentStateError    EnvironmentState = "Error"
+	EnvironmentStateUnknown  EnvironmentState = "Unknown"
+)
+
+type Status struct {
+	EnvironmentName string           `json:"environmentName"`
+	ProjectId       string           `json:"projectId"`
+	Region          string           `json:"region"`
+	State           EnvironmentState `json:"state"`
+	// ImageVersion is the Composer and Airflow version (e.g. composer-2.9.7-airflow-2.9.3)
+	ImageVersion string     `json:"imageVersion"`
+	AirflowUri   string     `json:"airflowUri"`
+	UpdatedAt    *time.Time `json:"updatedAt,omitempty"`
+	// AppVersion is the deployed version, read from the environment's NULLSTONE_VERSION env variable
+	AppVersion string `json:"appVersion,omitempty"`
+	// DagFiles lists the files under the DAG bucket prefix
+	DagFiles     []DagFileStatus     `json:"dagFiles"`
+	Dags         []DagStatus         `json:"dags"`
+	ImportErrors []ImportErrorStatus `json:"importErrors"`
+	// AirflowError explains why Dags and ImportErrors are empty when the Airflow REST API could not be queried
+	AirflowError string `json:"airflowError,omitempty"`
+}
+
+type DagFileStatus struct {
+	Path      string     `json:"path"`
+	Size      int64      `json:"size"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+type DagStatus struct {
+	DagId    string `json:"dagId"`
+	File     string `json:"file"`
+	IsPaused bool   `json:"isPaused"`
+	IsActive bool   `json:"isActive"`
+	// LastRunState is the state of the most recent run (empty if the DAG never ran)
+	LastRunState string         `json:"lastRunState,omitempty"`
+	RecentRuns   []DagRunStatus `json:"recentRuns"`
+}
+
+type DagRunStatus struct {
+	RunId         string     `json:"runId"`
+	State         string     `json:"state"`
+	RunType       string     `json:"runType"`
+	ExecutionDate *time.Time `json:"executionDate,omitempty"`
+	StartDate     *time.Time `json:"startDate,omitempty"`
+	EndDate       *time.Time `json:"endDate,omitempty"`
+}
+
+type ImportErrorStatus struct {
+	File       string    `json:"file"`
+	Summary    string    `json:"summary"`
+	StackTrace string    `json:"stackTrace"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// StatusOverview is the lightweight `overview` field on the frontend
+// AppStatusResult and implements app.StatusOverviewResult.
+type StatusOverview struct {
+	EnvironmentName string           `json:"environmentName"`
+	State           EnvironmentState `json:"state"`
+	ImageVersion    string           `json:"imageVersion"`
+	AppVersion      string           `json:"appVersion,omitempty"`
+}
+
+func (s StatusOverview) GetDeploymentVersions() []string {
+	if s.AppVersion == "" {
+		return make([]string, 0)
+	}
+	return []string{s.AppVersion}
+}
@@ -0,0 +1,283 @@
+package composer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"cloud.google.com/go/orchestration/airflow/service/apiv1/servicepb"
+	"cloud.google.com/go/storage"
+	"github.com/nullstone-io/deployment-sdk/app"
+	"github.com/nullstone-io/deployment-sdk/logging"
+	"github.com/nullstone-io/deployment-sdk/outputs"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+)
+
+const (
+	// maxRunsPerDag caps the recent runs reported for each DAG
+	maxRunsPerDag = 5
+)
+
+var (
+	_ app.StatusOverviewResult = StatusOverview{}
+	_ app.Statuser             = Statuser{}
+)
+
+func NewStatuser(ctx context.Context, osWriters logging.OsWriters, source outputs.RetrieverSource, appDetails app.Details) (app.Statuser, error) {
+	outs, err := outputs.Retrieve[Outputs](ctx, source, appDetails.Workspace, appDetails.WorkspaceConfig)
+	if err != nil {
+		return nil, err
+	}
+	outs.InitializeCreds(source, appDetails.Workspace)
+
+	return Statuser{
+		OsWriters: osWriters,
+		Details:   appDetails,
+		Infra:     outs,
+	}, nil
+}
+
+type Statuser struct {
+	OsWriters logging.OsWriters
+	Details   app.Details
+	Infra     Outputs
+}
+
+func (s Statuser) StatusOverview(ctx context.Context) (app.StatusOverviewResult, error) {
+	ov := StatusOverview{EnvironmentName: s.Infra.EnvironmentName}
+	env, err := s.getEnvironment(ctx)
+	if err != nil {
+		return ov, err
+	}
+	status := mapEnvironment(env, s.Infra)
+	ov.State = status.State
+	ov.ImageVersion = status.ImageVersion
+	ov.AppVersion = status.AppVersion
+	return ov, nil
+}
+
+func (s Statuser) Status(ctx context.Context) (any, error) {
+	env, err := s.getEnvironment(ctx)
+	if err != nil {
+		return nil, err
+	}
+	status := mapEnvironment(env, s.Infra)
+
+	// Best-effort: DAG files from the bucket and DAGs, runs, and import errors from Airflow.
+	// Failures are logged, not fatal.
+	stderr := s.OsWriters.Stderr()
+	if files, err := s.listDagFiles(ctx); err != nil {
+		fmt.Fprintf(stderr, "composer: unable to list DAG files: %s\n", err)
+	} else {
+		status.DagFiles = files
+	}
+	if err := s.applyAirflowStatus(ctx, &status); err != nil {
+		fmt.Fprintf(stderr, "composer: unable to query Airflow: %s\n", err)
+		status.AirflowError = err.Error()
+	}
+	return status, nil
+}
+
+func (s Statuser) getEnvironment(ctx context.Context) (*servicepb.Environment, error) {
+	client, err := NewEnvironmentsClient(ctx, s.Infra.Deployer)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Composer client: %w", err)
+	}
+	defer client.Close()
+
+	env, err := client.GetEnvironment(ctx, &servicepb.GetEnvironmentRequest{Name: environmentResourceName(s.Infra)})
+	if err != nil {
+		return nil, fmt.Errorf("error getting Composer environment: %w", err)
+	}
+	return env, nil
+}
+
+// listDagFiles lists the files under the DAG bucket prefix
+func (s Statuser) listDagFiles(ctx context.Context) ([]DagFileStatus, error) {
+	bucket := dagBucket(s.Infra)
+	if bucket == "" {
+		return nil, errors.New("this app is missing the DAG bucket output (dag_gcs_bucket/dag_gcs_prefix)")
+	}
+	prefix := dagObjectPrefix(s.Infra)
+
+	tokenSource, err := s.Infra.Pusher.TokenSource(ctx, ReadScopes...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating token source from service account: %w", err)
+	}
+	client, err := storage.NewClient(ctx, option.WithTokenSource(tokenSource))
+	if err != nil {
+		return nil, fmt.Errorf("error creating google storage client: %w", err)
+	}
+	defer client.Close()
+
+	listPrefix := prefix
+	if listPrefix != "" {
+		listPrefix += "/"
+	}
+	files := make([]DagFileStatus, 0)
+	it := client.Bucket(bucket).Objects(ctx, &storage.Query{Prefix: listPrefix})
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error listing objects: %w", err)
+		}
+		// Skip "directory" placeholder objects.
+		if strings.HasSuffix(attrs.Name, "/") {
+			continue
+		}
+		file := DagFileStatus{Path: strings.TrimPrefix(attrs.Name, listPrefix), Size: attrs.Size}
+		if !attrs.Updated.IsZero() {
+			updated := attrs.Updated
+			file.UpdatedAt = &updated
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// applyAirflowStatus populates the DAGs, their recent runs, and the import errors from the Airflow REST API
+// Airflow reports on the whole environment; only the DAGs and import errors for files in the app's DAG prefix are kept
+func (s Statuser) applyAirflowStatus(ctx context.Context, status *Status) error {
+	if status.AirflowUri == "" {
+		return errors.New("the Composer environment has no Airflow web server")
+	}
+	client, err := NewAirflowClient(ctx, status.AirflowUri, s.Infra.Deployer)
+	if err != nil {
+		return err
+	}
+
+	appFolder := appDagFolder(dagObjectPrefix(s.Infra))
+	importErrors, err := client.ListImportErrors(ctx)
+	if err != nil {
+		return fmt.Errorf("error listing import errors: %w", err)
+	}
+	importErrors = filterAppImportErrors(importErrors, appFolder)
+	dags, err := client.ListDags(ctx)
+	if err != nil {
+		return fmt.Errorf("error listing DAGs: %w", err)
+	}
+	dags = filterAppDags(dags, appFolder)
+	// Runs are retrieved per DAG so that a frequently scheduled DAG doesn't crowd out the others
+	runs := make([]DagRun, 0)
+	for _, dag := range dags {
+		dagRuns, err := client.ListDagRuns(ctx, dag.DagId, maxRunsPerDag)
+		if err != nil {
+			return fmt.Errorf("error listing runs of DAG %q: %w", dag.DagId, err)
+		}
+		runs = append(runs, dagRuns...)
+	}
+
+	for _, ie := range importErrors {
+		status.ImportErrors = append(status.ImportErrors, ImportErrorStatus{
+			File:       ie.DagFile(),
+			Summary:    ie.Summary(),
+			StackTrace: ie.StackTrace,
+			Timestamp:  ie.Timestamp,
+		})
+	}
+	status.Dags = buildDagStatuses(dags, runs, maxRunsPerDag)
+	return nil
+}
+
+func mapEnvironment(env *servicepb.Environment, infra Outputs) Status {
+	status := Status{
+		EnvironmentName: infra.EnvironmentName,
+		ProjectId:       infra.ProjectId,
+		Region:          infra.Region,
+		State:           mapEnvironmentState(env.GetState()),
+		ImageVersion:    env.GetConfig().GetSoftwareConfig().GetImageVersion(),
+		AirflowUri:      env.GetConfig().GetAirflowUri(),
+		AppVersion:      env.GetConfig().GetSoftwareConfig().GetEnvVariables()["NULLSTONE_VERSION"],
+		DagFiles:        make([]DagFileStatus, 0),
+		Dags:            make([]DagStatus, 0),
+		ImportErrors:    make([]ImportErrorStatus, 0),
+	}
+	if ts := env.GetUpdateTime(); ts != nil {
+		t := ts.AsTime()
+		status.UpdatedAt = &t
+	}
+	return status
+}
+
+func mapEnvironmentState(state servicepb.Environment_State) EnvironmentState {
+	switch state {
+	case servicepb.Environment_CREATING:
+		return EnvironmentStateCreating
+	case servicepb.Environment_RUNNING:
+		return EnvironmentStateRunning
+	case servicepb.Environment_UPDATING:
+		return EnvironmentStateUpdating
+	case servicepb.Environment_DELETING:
+		return EnvironmentStateDeleting
+	case servicepb.Environment_ERROR:
+		return EnvironmentStateError
+	default:
+		return EnvironmentStateUnknown
+	}
+}
+
+// filterAppDags returns the DAGs defined in files in the app's folder (see appDagFolder)
+func filterAppDags(dags []Dag, appFolder string) []Dag {
+	result := make([]Dag, 0, len(dags))
+	for _, dag := range dags {
+		if inAppDagFolder(dag.DagFile(), appFolder) {
+			result = append(result, dag)
+		}
+	}
+	return result
+}
+
+// filterAppImportErrors returns the import errors for files in the app's folder (see appDagFolder)
+func filterAppImportErrors(importErrors []ImportError, appFolder string) []ImportError {
+	result := make([]ImportError, 0, len(importErrors))
+	for _, ie := range importErrors {
+		if inAppDagFolder(ie.DagFile(), appFolder) {
+			result = append(result, ie)
+		}
+	}
+	return result
+}
+
+// buildDagStatuses joins each DAG with up to maxRuns of its most recent runs
+// Each DAG's runs must be ordered newest first; DAGs are sorted by id
+func buildDagStatuses(dags []Dag, runs []DagRun, maxRuns int) []DagStatus {
+	runsByDag := map[string][]DagRunStatus{}
+	for _, run := range runs {
+		if len(runsByDag[run.DagId]) >= maxRuns {
+			continue
+		}
+		runsByDag[run.DagId] = append(runsByDag[run.DagId], DagRunStatus{
+			RunId:         run.RunId,
+			State:         run.State,
+			RunType:       run.RunType,
+			ExecutionDate: run.ExecutionDate,
+			StartDate:     run.StartDate,
+			EndDate:       run.EndDate,
+		})
+	}
+
+	result := make([]DagStatus, 0, len(dags))
+	for _, dag := range dags {
+		ds := DagStatus{
+			DagId:      dag.DagId,
+			File:       dag.DagFile(),
+			IsPaused:   dag.IsPaused,
+			IsActive:   dag.IsActive,
+			RecentRuns: runsByDag[dag.DagId],
+		}
+		if ds.RecentRuns == nil {
+			ds.RecentRuns = make([]DagRunStatus, 0)
+		} else {
+			ds.LastRunState = ds.RecentRuns[0].State
+		}
+		result = append(result, ds)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].DagId < result[j].DagId })
+	return result
+}
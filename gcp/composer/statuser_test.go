@@ -0,0 +1,96 @@
+package composer
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/orchestration/airflow/service/apiv1/servicepb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapEnvironment(t *testing.T) {
+	infra := Outputs{ProjectId: "acme", Region: "us-central1", EnvironmentName: "airflow"}
+	env := &servicepb.Environment{
+		State: servicepb.Environment_UPDATING,
+		Config: &servicepb.EnvironmentConfig{
+			AirflowUri: "https://abc-dot-us-central1.composer.googleusercontent.com",
+			SoftwareConfig: &servicepb.SoftwareConfig{
+				ImageVersion: "composer-2.9.7-airflow-2.9.3",
+				EnvVariables: map[string]string{"NULLSTONE_VERSION": "v12"},
+			},
+		},
+	}
+	got := mapEnvironment(env, infra)
+	assert.Equal(t, EnvironmentStateUpdating, got.State)
+	assert.Equal(t, "composer-2.9.7-airflow-2.9.3", got.ImageVersion)
+	assert.Equal(t, "https://abc-dot-us-central1.composer.googleusercontent.com", got.AirflowUri)
+	assert.Equal(t, "v12", got.AppVersion)
+	assert.Empty(t, got.Dags)
+	assert.NotNil(t, got.Dags)
+
+	assert.Equal(t, EnvironmentStateUnknown, mapEnvironment(&servicepb.Environment{}, infra).State)
+}
+
+func TestBuildDagStatuses(t *testing.T) {
+	at := func(minutes int) *time.Time {
+		v := time.Date(2025, 3, 1, 12, minutes, 0, 0, time.UTC)
+		return &v
+	}
+	dags := []Dag{
+		{DagId: "reports", Fileloc: "/home/airflow/gcs/dags/reports/daily.py", IsActive: true},
+		{DagId: "etl", Fileloc: "/home/airflow/gcs/dags/etl.py", IsActive: true},
+		{DagId: "archive", Fileloc: "/home/airflow/gcs/dags/archive.py", IsPaused: true},
+	}
+	runs := []DagRun{
+		{DagId: "etl", RunId: "scheduled__3", State: "failed", ExecutionDate: at(30)},
+		{DagId: "reports", RunId: "manual__1", State: "running", ExecutionDate: at(20)},
+		{DagId: "etl", RunId: "scheduled__2", State: "success", ExecutionDate: at(20)},
+		{DagId: "etl", RunId: "scheduled__1", State: "success", ExecutionDate: at(10)},
+	}
+
+	got := buildDagStatuses(dags, runs, 2)
+	require.Len(t, got, 3)
+	assert.Equal(t, []string{"archive", "etl", "reports"}, []string{got[0].DagId, got[1].DagId, got[2].DagId})
+
+	assert.Equal(t, "archive.py", got[0].File)
+	assert.True(t, got[0].IsPaused)
+	assert.Empty(t, got[0].LastRunState)
+	assert.NotNil(t, got[0].RecentRuns)
+
+	assert.Equal(t, "failed", got[1].LastRunState)
+	assert.Equal(t, []DagRunStatus{
+		{RunId: "scheduled__3", State: "failed", ExecutionDate: at(30)},
+		{RunId: "scheduled__2", State: "success", ExecutionDate: at(20)},
+	}, got[1].RecentRuns)
+
+	assert.Equal(t, "reports/daily.py", got[2].File)
+	assert.Equal(t, "running", got[2].LastRunState)
+}
+
+func TestFilterAppDags(t *testing.T) {
+	dags := []Dag{
+		{DagId: "etl_daily", Fileloc: "/home/airflow/gcs/dags/etl/daily.py"},
+		{DagId: "etl_hourly", Fileloc: "/home/airflow/gcs/dags/etl/jobs/hourly.py"},
+		{DagId: "billing", Fileloc: "/home/airflow/gcs/dags/billing/daily.py"},
+		{DagId: "etl_legacy", Fileloc: "/home/airflow/gcs/dags/etl_legacy.py"},
+	}
+	ids := func(dags []Dag) []string {
+		result := make([]string, 0, len(dags))
+		for _, dag := range dags {
+			result = append(result, dag.DagId)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"etl_daily", "etl_hourly"}, ids(filterAppDags(dags, appDagFolder("dags/etl"))))
+	assert.Equal(t, []string{"etl_daily", "etl_hourly", "billing", "etl_legacy"}, ids(filterAppDags(dags, appDagFolder("dags"))))
+}
+
+func TestFilterAppImportErrors(t *testing.T) {
+	etl := ImportError{Id: 1, Filename: "/home/airflow/gcs/dags/etl/daily.py"}
+	billing := ImportError{Id: 2, Filename: "/home/airflow/gcs/dags/billing/daily.py"}
+
+	assert.Equal(t, []ImportError{etl}, filterAppImportErrors([]ImportError{etl, billing}, appDagFolder("dags/etl")))
+	assert.Equal(t, []ImportError{etl, billing}, filterAppImportErrors([]ImportError{etl, billing}, appDagFolder("dags")))
+}
@@ -0,0 +1,34 @@
+package gcs
+
+import (
+	"context"
+
+	"github.com/nullstone-io/deployment-sdk/app"
+	"github.com/nullstone-io/deployment-sdk/gcp/cloudcdn"
+	"github.com/nullstone-io/deployment-sdk/logging"
+	"github.com/nullstone-io/deployment-sdk/outputs"
+	"github.com/nullstone-io/deployment-sdk/staticsite"
+)
+
+func NewStatuser(ctx context.Context, osWriters logging.OsWriters, source outputs.RetrieverSource, appDetails app.Details) (app.Statuser, error) {
+	outs, err := outputs.Retrieve[Outputs](ctx, source, appDetails.Workspace, appDetails.WorkspaceConfig)
+	if err != nil {
+		return nil, err
+	}
+	outs.InitializeCreds(source, appDetails.Workspace)
+
+	cdnOutputs := cloudcdn.Outputs{
+		ProjectId:            outs.ProjectId,
+		Deployer:             outs.Deployer,
+		CdnUrlMapNames:       outs.CdnUrlMapNames,
+		ArtifactsKeyTemplate: outs.ArtifactsKeyTemplate,
+	}
+	return staticsite.Statuser{
+		OsWriters: osWriters,
+		Details:   appDetails,
+		GetCdnStatusesFn: func(ctx context.Context) ([]staticsite.CdnStatus, error) {
+			return cloudcdn.GetCdnStatuses(ctx, cdnOutputs)
+		},
+		ListArtifactVersionsFn: DirPusher{OsWriters: osWriters, Infra: outs, AppDetails: appDetails}.ListArtifactVersions,
+	}, nil
+}
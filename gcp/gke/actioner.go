@@ -21,8 +21,10 @@ func NewActioner(ctx context.Context, source outputs.RetrieverSource, blockDetai
 	outs.Deployer.RemoteTokenSourcer = creds.NewTokenSourcer(source, ws.StackId, ws.BlockId, ws.EnvId, types.AutomationPurposePerformAction, "deployer")
 
 	return k8s.Actioner{
-		Namespace: outs.ServiceNamespace,
-		AppName:   blockDetails.Block.Name,
+		Namespace:         outs.ServiceNamespace,
+		AppName:           blockDetails.Block.Name,
+		MaxReplicas:       outs.MaxScale,
+		MainContainerName: outs.MainContainerName,
 		NewConfigFn: func(ctx context.Context) (*rest.Config, error) {
 			return CreateKubeConfig(ctx, outs.ClusterNamespace, outs.Deployer)
 		},
@@ -9,6 +9,8 @@ import (
 	"github.com/nullstone-io/deployment-sdk/k8s"
 	"github.com/nullstone-io/deployment-sdk/logging"
 	"github.com/nullstone-io/deployment-sdk/outputs"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
 )
 
 func NewDeployer(ctx context.Context, osWriters logging.OsWriters, source outputs.RetrieverSource, appDetails app.Details) (app.Deployer, error) {
@@ -37,6 +39,7 @@ func (d Deployer) Print() {
 	fmt.Fprintf(stdout, "	cluster_endpoint:    %s\n", d.Infra.ClusterNamespace.ClusterEndpoint)
 	fmt.Fprintf(stdout, "	service_namespace:   %s\n", d.Infra.ServiceNamespace)
 	fmt.Fprintf(stdout, "	service_name:        %s\n", d.Infra.ServiceName)
+	fmt.Fprintf(stdout, "	workload_kind:       %s\n", d.Infra.WorkloadKind)
 	fmt.Fprintf(stdout, "	job_definition_name: %s\n", d.Infra.JobDefinitionName)
 	fmt.Fprintf(stdout, "	image_repo_url:      %s\n", d.Infra.ImageRepoUrl)
 }
@@ -44,21 +47,34 @@ func (d Deployer) Print() {
 func (d Deployer) Deploy(ctx context.Context, meta app.DeployMetadata) (string, error) {
 	d.Print()
 
+	workloadKind, err := k8s.ParseWorkloadKind(d.Infra.WorkloadKind)
+	if err != nil {
+		return "", err
+	}
 	deployer := k8s.Deployer{
 		K8sNamespace:      d.Infra.ServiceNamespace,
 		AppName:           d.Details.App.Name,
 		MainContainerName: d.Infra.MainContainerName,
 		ServiceName:       d.Infra.ServiceName,
+		WorkloadKind:      workloadKind,
 		JobDefinitionName: d.Infra.JobDefinitionName,
+		SkipPreflight:     d.Infra.SkipPreflightChecks,
 		OsWriters:         d.OsWriters,
 	}
 	if valid, err := deployer.Validate(meta); !valid {
 		return "", err
 	}
-	kubeClient, err := CreateKubeClient(ctx, d.Infra.ClusterNamespace, d.Infra.Deployer)
+	cfg, err := CreateKubeConfig(ctx, d.Infra.ClusterNamespace, d.Infra.Deployer)
+	if err != nil {
+		return "", fmt.Errorf("error creating kube config: %w", err)
+	}
+	kubeClient, err := kubernetes.NewForConfig(cfg)
 	if err != nil {
 		return "", fmt.Errorf("error creating kubernetes client: %w", err)
 	}
+	if deployer.DynamicClient, err = dynamic.NewForConfig(cfg); err != nil {
+		return "", fmt.Errorf("error creating kubernetes dynamic client: %w", err)
+	}
 
 	return deployer.Deploy(ctx, kubeClient, meta)
 }
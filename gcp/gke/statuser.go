@@ -16,6 +16,10 @@ func NewStatuser(ctx context.Context, osWriters logging.OsWriters, source output
 		return nil, err
 	}
 	outs.InitializeCreds(source, appDetails.Workspace)
+	workloadKind, err := k8s.ParseWorkloadKind(outs.WorkloadKind)
+	if err != nil {
+		return nil, err
+	}
 
 	return &k8s.Statuser{
 		OsWriters: osWriters,
@@ -26,6 +30,7 @@ func NewStatuser(ctx context.Context, osWriters logging.OsWriters, source output
 			ClusterName: outs.ClusterNamespace.ClusterName,
 		},
 		AppNamespace: outs.ServiceNamespace,
+		WorkloadKind: workloadKind,
 		AppName:      appDetails.App.Name,
 		NewConfigFn: func(ctx context.Context) (*rest.Config, error) {
 			return CreateKubeConfig(ctx, outs.ClusterNamespace, outs.Deployer)
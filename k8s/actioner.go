@@ -3,10 +3,13 @@ package k8s
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/nullstone-io/deployment-sdk/k8s/logs"
+	"github.com/nullstone-io/deployment-sdk/logging"
 	"github.com/nullstone-io/deployment-sdk/workspace"
 	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -18,6 +21,22 @@ const (
 	ActionRestartDeployment = "restart-deployment"
 	ActionRerunJob          = "rerun-job"
 	ActionKillPod           = "kill-pod"
+	ActionScale             = "scale"
+	ActionPause             = "pause"
+	ActionResume            = "resume"
+	ActionExecCommand       = "exec-command"
+	ActionPortForward       = "port-forward"
+	ActionStopPortForward   = "stop-port-forward"
+
+	// PausedReplicasAnnotation remembers a deployment's replica count from before a pause
+	PausedReplicasAnnotation = "nullstone.io/paused-replicas"
+
+	// defaultExecCommandTimeout bounds how long exec-command waits for the command to finish
+	defaultExecCommandTimeout = 5 * time.Minute
+	// maxExecCommandOutput caps how much command output is returned in the action result
+	maxExecCommandOutput = 64 * 1024
+	// defaultPortForwardDuration bounds how long port-forward keeps forwarding
+	defaultPortForwardDuration = time.Hour
 )
 
 type RestartDeploymentInput struct {
@@ -46,10 +65,84 @@ type KillPodResult struct {
 	Pod string `json:"pod"`
 }
 
+type ScaleInput struct {
+	DeploymentName string `json:"deploymentName"`
+	// Replicas is required; a nil value is rejected rather than scaling the deployment to zero.
+	Replicas *int32 `json:"replicas"`
+}
+
+type ScaleResult struct {
+	Deployment       string `json:"deployment"`
+	PreviousReplicas int32  `json:"previousReplicas"`
+	Replicas         int32  `json:"replicas"`
+}
+
+type PauseInput struct {
+	DeploymentName string `json:"deploymentName"`
+}
+
+type PauseResult struct {
+	Deployment       string `json:"deployment"`
+	PreviousReplicas int32  `json:"previousReplicas"`
+}
+
+type ResumeInput struct {
+	DeploymentName string `json:"deploymentName"`
+	// Replicas overrides the replica count remembered from the pause.
+	Replicas *int32 `json:"replicas,omitempty"`
+}
+
+type ResumeResult struct {
+	Deployment string `json:"deployment"`
+	Replicas   int32  `json:"replicas"`
+}
+
+type ExecCommandInput struct {
+	Command []string `json:"command"`
+	// PodName selects the pod to run the command in; if empty, a running and ready pod is used
+	PodName   string `json:"podName,omitempty"`
+	Container string `json:"container,omitempty"`
+	// TimeoutSeconds bounds how long the command may run (default 5m)
+	TimeoutSeconds int `json:"timeoutSeconds,omitempty"`
+}
+
+type ExecCommandResult struct {
+	ExecResult
+	Output string `json:"output"`
+	// Truncated is true when the command produced more output than is returned.
+	Truncated bool `json:"truncated"`
+}
+
+type PortForwardInput struct {
+	// Ports are port mappings: "8080:80", "80" (same local port), or ":80" (random local port)
+	Ports []string `json:"ports"`
+	// PodName selects the pod to forward to; if empty, a running and ready pod is used
+	PodName   string   `json:"podName,omitempty"`
+	Addresses []string `json:"addresses,omitempty"`
+	// DurationSeconds bounds how long ports are forwarded (default 1h)
+	DurationSeconds int `json:"durationSeconds,omitempty"`
+}
+
+type PortForwardResult struct {
+	// SessionId identifies the port forward for stop-port-forward
+	SessionId string          `json:"sessionId"`
+	Pod       string          `json:"pod"`
+	Ports     []ForwardedPort `json:"ports"`
+	ExpiresAt time.Time       `json:"expiresAt"`
+}
+
+type StopPortForwardInput struct {
+	SessionId string `json:"sessionId"`
+}
+
 type Actioner struct {
 	Namespace   string
 	AppName     string
 	NewConfigFn logs.NewConfiger
+	// MaxReplicas limits how many replicas the scale/resume actions may request (0 = no limit)
+	MaxReplicas int32
+	// MainContainerName is the container that exec connects to when one is not specified
+	MainContainerName string
 }
 
 func (a Actioner) PerformAction(ctx context.Context, options workspace.ActionOptions) (*workspace.ActionResult, error) {
@@ -60,6 +153,18 @@ func (a Actioner) PerformAction(ctx context.Context, options workspace.ActionOpt
 		return a.rerunJob(ctx, options.Input)
 	case ActionKillPod:
 		return a.killPod(ctx, options.Input)
+	case ActionScale:
+		return a.scale(ctx, options.Input)
+	case ActionPause:
+		return a.pause(ctx, options.Input)
+	case ActionResume:
+		return a.resume(ctx, options.Input)
+	case ActionExecCommand:
+		return a.execCommand(ctx, options.Input)
+	case ActionPortForward:
+		return a.portForward(ctx, options.Input)
+	case ActionStopPortForward:
+		return a.stopPortForward(options.Input)
 	default:
 		return nil, workspace.ActionNotSupportedError{
 			InnerErr: fmt.Errorf("unknown k8s action %q", options.Action),
@@ -219,3 +324,298 @@ func (a Actioner) killPod(ctx context.Context, input json.RawMessage) (*workspac
 		Data:    data,
 	}, nil
 }
+
+// scale sets the replica count of a deployment through its scale subresource.
+// The replica count is limited by MaxReplicas when set.
+func (a Actioner) scale(ctx context.Context, input json.RawMessage) (*workspace.ActionResult, error) {
+	var in ScaleInput
+	if len(input) > 0 {
+		if err := json.Unmarshal(input, &in); err != nil {
+			return nil, fmt.Errorf("invalid input for %s: %w", ActionScale, err)
+		}
+	}
+	if in.DeploymentName == "" {
+		return nil, fmt.Errorf("%s requires deploymentName", ActionScale)
+	}
+	if in.Replicas == nil {
+		return nil, fmt.Errorf("invalid input for %s: replicas is required", ActionScale)
+	}
+	replicas := *in.Replicas
+	if err := a.checkReplicaLimit(replicas); err != nil {
+		return nil, err
+	}
+
+	client, err := a.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deployments := client.AppsV1().Deployments(a.Namespace)
+	scale, err := deployments.GetScale(ctx, in.DeploymentName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving scale for deployment %q: %w", in.DeploymentName, err)
+	}
+	previous := scale.Spec.Replicas
+	scale.Spec.Replicas = replicas
+	if _, err := deployments.UpdateScale(ctx, in.DeploymentName, scale, metav1.UpdateOptions{}); err != nil {
+		return nil, fmt.Errorf("error scaling deployment %q: %w", in.DeploymentName, err)
+	}
+
+	data, err := json.Marshal(ScaleResult{
+		Deployment:       in.DeploymentName,
+		PreviousReplicas: previous,
+		Replicas:         replicas,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &workspace.ActionResult{
+		Status:  "completed",
+		Message: fmt.Sprintf("scaled deployment %q from %d to %d replicas", in.DeploymentName, previous, replicas),
+		Data:    data,
+	}, nil
+}
+
+// pause scales a deployment to zero replicas.
+// The prior replica count is stored in an annotation on the deployment so that resume can restore it.
+// NOTE: A HorizontalPodAutoscaler targeting this deployment will not scale it back up while replicas is 0.
+func (a Actioner) pause(ctx context.Context, input json.RawMessage) (*workspace.ActionResult, error) {
+	var in PauseInput
+	if len(input) > 0 {
+		if err := json.Unmarshal(input, &in); err != nil {
+			return nil, fmt.Errorf("invalid input for %s: %w", ActionPause, err)
+		}
+	}
+	if in.DeploymentName == "" {
+		return nil, fmt.Errorf("%s requires deploymentName", ActionPause)
+	}
+
+	client, err := a.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deployments := client.AppsV1().Deployments(a.Namespace)
+	existing, err := deployments.Get(ctx, in.DeploymentName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving deployment %q: %w", in.DeploymentName, err)
+	}
+	previous := int32(1)
+	if existing.Spec.Replicas != nil {
+		previous = *existing.Spec.Replicas
+	}
+	if previous == 0 {
+		return nil, fmt.Errorf("deployment %q is already scaled to zero", in.DeploymentName)
+	}
+
+	// The annotation and replica count are changed in a single patch so a failed pause never loses the prior count
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:"%d"}},"spec":{"replicas":0}}`, PausedReplicasAnnotation, previous)
+	if _, err := deployments.Patch(ctx, in.DeploymentName, apitypes.MergePatchType, []byte(patch), metav1.PatchOptions{}); err != nil {
+		return nil, fmt.Errorf("error pausing deployment %q: %w", in.DeploymentName, err)
+	}
+
+	data, err := json.Marshal(PauseResult{
+		Deployment:       in.DeploymentName,
+		PreviousReplicas: previous,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &workspace.ActionResult{
+		Status:  "completed",
+		Message: fmt.Sprintf("paused deployment %q (previously %d replicas)", in.DeploymentName, previous),
+		Data:    data,
+	}, nil
+}
+
+// resume restores the replica count that was recorded when the deployment was paused.
+func (a Actioner) resume(ctx context.Context, input json.RawMessage) (*workspace.ActionResult, error) {
+	var in ResumeInput
+	if len(input) > 0 {
+		if err := json.Unmarshal(input, &in); err != nil {
+			return nil, fmt.Errorf("invalid input for %s: %w", ActionResume, err)
+		}
+	}
+	if in.DeploymentName == "" {
+		return nil, fmt.Errorf("%s requires deploymentName", ActionResume)
+	}
+
+	client, err := a.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deployments := client.AppsV1().Deployments(a.Namespace)
+	existing, err := deployments.Get(ctx, in.DeploymentName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving deployment %q: %w", in.DeploymentName, err)
+	}
+	replicas := in.Replicas
+	if replicas == nil {
+		if raw, ok := existing.Annotations[PausedReplicasAnnotation]; ok {
+			if count, err := strconv.ParseInt(raw, 10, 32); err == nil {
+				paused := int32(count)
+				replicas = &paused
+			}
+		}
+	}
+	if replicas == nil {
+		return nil, fmt.Errorf("deployment %q was not paused, specify replicas to resume", in.DeploymentName)
+	}
+	if err := a.checkReplicaLimit(*replicas); err != nil {
+		return nil, err
+	}
+
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:null}},"spec":{"replicas":%d}}`, PausedReplicasAnnotation, *replicas)
+	if _, err := deployments.Patch(ctx, in.DeploymentName, apitypes.MergePatchType, []byte(patch), metav1.PatchOptions{}); err != nil {
+		return nil, fmt.Errorf("error resuming deployment %q: %w", in.DeploymentName, err)
+	}
+
+	data, err := json.Marshal(ResumeResult{
+		Deployment: in.DeploymentName,
+		Replicas:   *replicas,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &workspace.ActionResult{
+		Status:  "completed",
+		Message: fmt.Sprintf("resumed deployment %q with %d replicas", in.DeploymentName, *replicas),
+		Data:    data,
+	}, nil
+}
+
+// execCommand runs a one-shot command in a running pod and returns its combined output.
+// Interactive sessions are not possible through an action; use Exec directly to stream stdio.
+func (a Actioner) execCommand(ctx context.Context, input json.RawMessage) (*workspace.ActionResult, error) {
+	var in ExecCommandInput
+	if len(input) > 0 {
+		if err := json.Unmarshal(input, &in); err != nil {
+			return nil, fmt.Errorf("invalid input for %s: %w", ActionExecCommand, err)
+		}
+	}
+	if len(in.Command) == 0 {
+		return nil, fmt.Errorf("%s requires command", ActionExecCommand)
+	}
+
+	timeout := defaultExecCommandTimeout
+	if in.TimeoutSeconds > 0 {
+		timeout = time.Duration(in.TimeoutSeconds) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	output := &logging.LimitedBuffer{Limit: maxExecCommandOutput}
+	res, err := a.Exec(ctx, ExecOptions{
+		ExecTarget: ExecTarget{PodName: in.PodName, Container: in.Container},
+		Command:    in.Command,
+		Stdout:     output,
+		Stderr:     output,
+	})
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, fmt.Errorf("command timed out after %s", timeout)
+		}
+		return nil, err
+	}
+
+	data, err := json.Marshal(ExecCommandResult{
+		ExecResult: *res,
+		Output:     output.String(),
+		Truncated:  output.Truncated(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &workspace.ActionResult{
+		Status:  "completed",
+		Message: fmt.Sprintf("executed command in pod %q (container %q)", res.Pod, res.Container),
+		Data:    data,
+	}, nil
+}
+
+// portForward starts forwarding local ports to a running pod and returns as soon as the ports are bound.
+// Forwarding continues in the background until the duration elapses or stop-port-forward is performed with the returned session id.
+func (a Actioner) portForward(ctx context.Context, input json.RawMessage) (*workspace.ActionResult, error) {
+	var in PortForwardInput
+	if len(input) > 0 {
+		if err := json.Unmarshal(input, &in); err != nil {
+			return nil, fmt.Errorf("invalid input for %s: %w", ActionPortForward, err)
+		}
+	}
+	if len(in.Ports) == 0 {
+		return nil, fmt.Errorf("%s requires ports", ActionPortForward)
+	}
+
+	duration := defaultPortForwardDuration
+	if in.DurationSeconds > 0 {
+		duration = time.Duration(in.DurationSeconds) * time.Second
+	}
+	// The session outlives the action, so it must not be stopped when the caller's context ends
+	sessionCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), duration)
+	session, err := a.PortForward(sessionCtx, PortForwardOptions{
+		PodName:   in.PodName,
+		Ports:     in.Ports,
+		Addresses: in.Addresses,
+	})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	id := portForwardSessions.add(session)
+	go func() {
+		session.Wait()
+		portForwardSessions.remove(id)
+		cancel()
+	}()
+
+	data, err := json.Marshal(PortForwardResult{
+		SessionId: id,
+		Pod:       session.Pod,
+		Ports:     session.Ports,
+		ExpiresAt: time.Now().Add(duration),
+	})
+	if err != nil {
+		session.Close()
+		return nil, err
+	}
+	return &workspace.ActionResult{
+		Status:  "started",
+		Message: fmt.Sprintf("forwarding %d port(s) to pod %q for %s", len(session.Ports), session.Pod, duration),
+		Data:    data,
+	}, nil
+}
+
+// stopPortForward stops a port forward started by port-forward
+func (a Actioner) stopPortForward(input json.RawMessage) (*workspace.ActionResult, error) {
+	var in StopPortForwardInput
+	if len(input) > 0 {
+		if err := json.Unmarshal(input, &in); err != nil {
+			return nil, fmt.Errorf("invalid input for %s: %w", ActionStopPortForward, err)
+		}
+	}
+	if in.SessionId == "" {
+		return nil, fmt.Errorf("%s requires sessionId", ActionStopPortForward)
+	}
+	session := portForwardSessions.get(in.SessionId)
+	if session == nil {
+		return nil, fmt.Errorf("port forward session %q does not exist or has already stopped", in.SessionId)
+	}
+	if err := session.Close(); err != nil {
+		return nil, fmt.Errorf("error forwarding ports to pod %q: %w", session.Pod, err)
+	}
+	return &workspace.ActionResult{
+		Status:  "completed",
+		Message: fmt.Sprintf("stopped forwarding ports to pod %q", session.Pod),
+	}, nil
+}
+
+func (a Actioner) checkReplicaLimit(replicas int32) error {
+	if replicas < 0 {
+		return fmt.Errorf("replicas must not be negative, got %d", replicas)
+	}
+	if a.MaxReplicas > 0 && replicas > a.MaxReplicas {
+		return fmt.Errorf("replicas %d exceeds the max scale limit of %d", replicas, a.MaxReplicas)
+	}
+	return nil
+}
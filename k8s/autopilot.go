@@ -0,0 +1,100 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/colorstring"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AutopilotResourceAdjustmentAnnotation is set by GKE Autopilot on pods whose resource requests and limits it changed at admission
+const AutopilotResourceAdjustmentAnnotation = "autopilot.gke.io/resource-adjustment"
+
+type autopilotResourceAdjustment struct {
+	Output struct {
+		Containers []autopilotContainerResources `json:"containers"`
+	} `json:"output"`
+	Modified bool `json:"modified"`
+}
+
+type autopilotContainerResources struct {
+	Name     string            `json:"name"`
+	Requests map[string]string `json:"requests"`
+	Limits   map[string]string `json:"limits"`
+}
+
+// CheckResourceAdjustment explains how GKE Autopilot adjusted a pod's resources
+// Returns "" if the pod was not adjusted
+//
+// Autopilot raises requests to its minimums and sets limits equal to requests; the adjustment doesn't fail the rollout,
+// but it explains OOMs (memory limit lower than expected) and surprise scheduling (requests higher than expected)
+func CheckResourceAdjustment(pod corev1.Pod) string {
+	raw, ok := pod.Annotations[AutopilotResourceAdjustmentAnnotation]
+	if !ok {
+		return ""
+	}
+	var adjustment autopilotResourceAdjustment
+	if err := json.Unmarshal([]byte(raw), &adjustment); err != nil || !adjustment.Modified {
+		return ""
+	}
+	containers := make([]string, 0, len(adjustment.Output.Containers))
+	for _, c := range adjustment.Output.Containers {
+		containers = append(containers, fmt.Sprintf("%s (requests %s; limits %s)", c.Name, formatResources(c.Requests), formatResources(c.Limits)))
+	}
+	msg := fmt.Sprintf("GKE Autopilot adjusted the resources of pod %s", pod.Name)
+	if len(containers) > 0 {
+		msg += ": " + strings.Join(containers, ", ")
+	}
+	return msg + ". Autopilot sets limits equal to requests; set requests explicitly so each container's limits match what it needs."
+}
+
+func formatResources(resources map[string]string) string {
+	if len(resources) == 0 {
+		return "none"
+	}
+	names := make([]string, 0, len(resources))
+	for name := range resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	pairs := make([]string, 0, len(names))
+	for _, name := range names {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", name, resources[name]))
+	}
+	return strings.Join(pairs, " ")
+}
+
+// warnResourceAdjustments prints a warning if GKE Autopilot adjusted the resources of the new pods
+// Pods of the same revision share a template, so only the first adjusted pod is reported
+func (w *DeployWatcher) warnResourceAdjustments(start *time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	pods, err := w.client.CoreV1().Pods(w.AppNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("nullstone.io/app=%s", w.AppName),
+	})
+	if err != nil {
+		return
+	}
+	for _, pod := range pods.Items {
+		if start != nil && pod.CreationTimestamp.Time.Before(*start) {
+			continue
+		}
+		if msg := CheckResourceAdjustment(pod); msg != "" {
+			colorstring.Fprintln(w.OsWriters.Stdout(), DeployEvent{
+				Timestamp: time.Now(),
+				Type:      EventTypeWarning,
+				Reason:    "ResourceAdjusted",
+				Object:    fmt.Sprintf("pod/%s", pod.Name),
+				Message:   msg,
+			}.String())
+			return
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCheckResourceAdjustment(t *testing.T) {
+	pod := func(annotations map[string]string) corev1.Pod {
+		return corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api-7d9f8-x2k4p", Annotations: annotations}}
+	}
+
+	tests := []struct {
+		name string
+		pod  corev1.Pod
+		want string
+	}{
+		{
+			name: "no annotation",
+			pod:  pod(nil),
+			want: "",
+		},
+		{
+			name: "adjusted",
+			pod: pod(map[string]string{
+				AutopilotResourceAdjustmentAnnotation: `{"input":{"containers":[{"name":"app"}]},"output":{"containers":[{"limits":{"cpu":"500m","ephemeral-storage":"1Gi","memory":"2Gi"},"requests":{"cpu":"500m","ephemeral-storage":"1Gi","memory":"2Gi"},"name":"app"}]},"modified":true}`,
+			}),
+			want: "GKE Autopilot adjusted the resources of pod api-7d9f8-x2k4p: app (requests cpu=500m ephemeral-storage=1Gi memory=2Gi; limits cpu=500m ephemeral-storage=1Gi memory=2Gi). Autopilot sets limits equal to requests; set requests explicitly so each container's limits match what it needs.",
+		},
+		{
+			name: "not modified",
+			pod: pod(map[string]string{
+				AutopilotResourceAdjustmentAnnotation: `{"input":{"containers":[{"requests":{"cpu":"1","memory":"4Gi"},"name":"app"}]},"output":{"containers":[{"requests":{"cpu":"1","memory":"4Gi"},"name":"app"}]},"modified":false}`,
+			}),
+			want: "",
+		},
+		{
+			name: "malformed annotation",
+			pod:  pod(map[string]string{AutopilotResourceAdjustmentAnnotation: "{"}),
+			want: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, CheckResourceAdjustment(test.pod))
+		})
+	}
+}
@@ -4,16 +4,43 @@ import (
 	"fmt"
 	"github.com/nullstone-io/deployment-sdk/app"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
 	deploymentutil "k8s.io/kubectl/pkg/util/deployment"
 )
 
 // CheckDeployment maps the Deployment status to a friendly app.RolloutStatus
+// A ReplicaFailure condition (quota, LimitRange, admission webhook, PodSecurity) fails the rollout immediately
+// instead of waiting for the progress deadline
 func CheckDeployment(deployment *appsv1.Deployment) (app.RolloutStatus, error) {
+	return CheckAutoscaledDeployment(deployment, nil)
+}
+
+// CheckAutoscaledDeployment is CheckDeployment for a Deployment that may be scaled by a HorizontalPodAutoscaler
+// When hpa is set, the rollout is judged against the autoscaler's desired replica count instead of spec.replicas:
+// a deploy that resets spec.replicas or races a scale-out is complete once the autoscaler's desired count is updated and available
+func CheckAutoscaledDeployment(deployment *appsv1.Deployment, hpa *autoscalingv2.HorizontalPodAutoscaler) (app.RolloutStatus, error) {
 	if deployment.Generation <= deployment.Status.ObservedGeneration {
 		cond := deploymentutil.GetDeploymentCondition(deployment.Status, appsv1.DeploymentProgressing)
 		if cond != nil && cond.Reason == deploymentutil.TimedOutReason {
 			return app.RolloutStatusFailed, fmt.Errorf("deployment failed because of timeout (exceeding its deadline)")
 		}
+		cond = deploymentutil.GetDeploymentCondition(deployment.Status, appsv1.DeploymentReplicaFailure)
+		if cond != nil && cond.Status == corev1.ConditionTrue {
+			return app.RolloutStatusFailed, fmt.Errorf("deployment failed because new pods could not be created: %s", cond.Message)
+		}
+		if desired, ok := AutoscalerDesiredReplicas(hpa); ok {
+			if deployment.Status.UpdatedReplicas < desired {
+				return app.RolloutStatusInProgress, nil
+			}
+			if deployment.Status.Replicas > deployment.Status.UpdatedReplicas {
+				return app.RolloutStatusInProgress, nil
+			}
+			if deployment.Status.AvailableReplicas < desired {
+				return app.RolloutStatusInProgress, nil
+			}
+			return app.RolloutStatusComplete, nil
+		}
 		if deployment.Spec.Replicas != nil && deployment.Status.UpdatedReplicas < *deployment.Spec.Replicas {
 			return app.RolloutStatusInProgress, nil
 		}
@@ -5,6 +5,8 @@ import (
 	"github.com/nullstone-io/deployment-sdk/app"
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	deploymentutil "k8s.io/kubectl/pkg/util/deployment"
 	"testing"
@@ -43,6 +45,55 @@ func TestCheckDeployment(t *testing.T) {
 			want: app.RolloutStatusFailed,
 			err:  fmt.Errorf("deployment failed because of timeout (exceeding its deadline)"),
 		},
+		{
+			name: "replica failure",
+			deployment: v1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{RevisionAnnotation: "2"},
+				},
+				Spec: v1.DeploymentSpec{
+					Replicas: &two,
+				},
+				Status: v1.DeploymentStatus{
+					Conditions: []v1.DeploymentCondition{
+						{
+							Type:   v1.DeploymentProgressing,
+							Status: corev1.ConditionTrue,
+							Reason: "ReplicaSetUpdated",
+						},
+						{
+							Type:    v1.DeploymentReplicaFailure,
+							Status:  corev1.ConditionTrue,
+							Reason:  "FailedCreate",
+							Message: `pods "api-6b8f-x" is forbidden: exceeded quota: compute, requested: cpu=2, used: cpu=9, limited: cpu=10`,
+						},
+					},
+				},
+			},
+			want: app.RolloutStatusFailed,
+			err:  fmt.Errorf(`deployment failed because new pods could not be created: pods "api-6b8f-x" is forbidden: exceeded quota: compute, requested: cpu=2, used: cpu=9, limited: cpu=10`),
+		},
+		{
+			name: "replica failure resolved",
+			deployment: v1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{RevisionAnnotation: "2"},
+				},
+				Spec: v1.DeploymentSpec{
+					Replicas: &two,
+				},
+				Status: v1.DeploymentStatus{
+					UpdatedReplicas: 1,
+					Conditions: []v1.DeploymentCondition{
+						{
+							Type:   v1.DeploymentReplicaFailure,
+							Status: corev1.ConditionFalse,
+						},
+					},
+				},
+			},
+			want: app.RolloutStatusInProgress,
+		},
 		{
 			name: "not fully available",
 			deployment: v1.Deployment{
@@ -85,3 +136,68 @@ func TestCheckDeployment(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckAutoscaledDeployment(t *testing.T) {
+	two, three, ten := int32(2), int32(3), int32(10)
+	deployment := func(replicas *int32, status v1.DeploymentStatus) v1.Deployment {
+		return v1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{RevisionAnnotation: "2"},
+			},
+			Spec:   v1.DeploymentSpec{Replicas: replicas},
+			Status: status,
+		}
+	}
+	hpa := func(desired int32) *autoscalingv2.HorizontalPodAutoscaler {
+		return &autoscalingv2.HorizontalPodAutoscaler{
+			Spec:   autoscalingv2.HorizontalPodAutoscalerSpec{MinReplicas: &two, MaxReplicas: 5},
+			Status: autoscalingv2.HorizontalPodAutoscalerStatus{DesiredReplicas: desired},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		deployment v1.Deployment
+		hpa        *autoscalingv2.HorizontalPodAutoscaler
+		want       app.RolloutStatus
+	}{
+		{
+			name:       "deploy reset replicas above autoscaler",
+			deployment: deployment(&ten, v1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 3}),
+			hpa:        hpa(3),
+			want:       app.RolloutStatusComplete,
+		},
+		{
+			name:       "scale-out not available",
+			deployment: deployment(&three, v1.DeploymentStatus{Replicas: 5, UpdatedReplicas: 5, AvailableReplicas: 3}),
+			hpa:        hpa(5),
+			want:       app.RolloutStatusInProgress,
+		},
+		{
+			name:       "old replicas remain",
+			deployment: deployment(&three, v1.DeploymentStatus{Replicas: 4, UpdatedReplicas: 3, AvailableReplicas: 4}),
+			hpa:        hpa(3),
+			want:       app.RolloutStatusInProgress,
+		},
+		{
+			name:       "desired clamped to max",
+			deployment: deployment(&ten, v1.DeploymentStatus{Replicas: 5, UpdatedReplicas: 5, AvailableReplicas: 5}),
+			hpa:        hpa(8),
+			want:       app.RolloutStatusComplete,
+		},
+		{
+			name:       "autoscaler has not computed a scale",
+			deployment: deployment(&two, v1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 1, AvailableReplicas: 2}),
+			hpa:        hpa(0),
+			want:       app.RolloutStatusInProgress,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := CheckAutoscaledDeployment(&test.deployment, test.hpa)
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
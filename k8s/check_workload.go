@@ -0,0 +1,127 @@
+package k8s
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/nullstone-io/deployment-sdk/app"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// CheckStatefulSet maps the StatefulSet status to a friendly app.RolloutStatus
+// This mirrors `kubectl rollout status statefulset`:
+// A partitioned rolling update is complete once every ordinal at or above the partition runs the update revision
+func CheckStatefulSet(sts *appsv1.StatefulSet) (app.RolloutStatus, error) {
+	if sts.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		// Pods are only replaced when they are deleted manually, there is no rollout to wait on
+		return app.RolloutStatusComplete, nil
+	}
+	if sts.Status.ObservedGeneration == 0 || sts.Generation > sts.Status.ObservedGeneration {
+		return app.RolloutStatusPending, nil
+	}
+	if sts.Spec.Replicas != nil && sts.Status.ReadyReplicas < *sts.Spec.Replicas {
+		return app.RolloutStatusInProgress, nil
+	}
+	if partition := statefulSetPartition(sts); partition > 0 && sts.Spec.Replicas != nil {
+		if sts.Status.UpdatedReplicas < *sts.Spec.Replicas-partition {
+			return app.RolloutStatusInProgress, nil
+		}
+		return app.RolloutStatusComplete, nil
+	}
+	if sts.Status.UpdateRevision != sts.Status.CurrentRevision {
+		return app.RolloutStatusInProgress, nil
+	}
+	return app.RolloutStatusComplete, nil
+}
+
+func statefulSetPartition(sts *appsv1.StatefulSet) int32 {
+	if ru := sts.Spec.UpdateStrategy.RollingUpdate; ru != nil && ru.Partition != nil {
+		return *ru.Partition
+	}
+	return 0
+}
+
+// CheckDaemonSet maps the DaemonSet status to a friendly app.RolloutStatus
+// This mirrors `kubectl rollout status daemonset`
+func CheckDaemonSet(ds *appsv1.DaemonSet) (app.RolloutStatus, error) {
+	if ds.Spec.UpdateStrategy.Type == appsv1.OnDeleteDaemonSetStrategyType {
+		// Pods are only replaced when they are deleted manually, there is no rollout to wait on
+		return app.RolloutStatusComplete, nil
+	}
+	if ds.Generation > ds.Status.ObservedGeneration {
+		return app.RolloutStatusPending, nil
+	}
+	if ds.Status.UpdatedNumberScheduled < ds.Status.DesiredNumberScheduled {
+		return app.RolloutStatusInProgress, nil
+	}
+	if ds.Status.NumberAvailable < ds.Status.DesiredNumberScheduled {
+		return app.RolloutStatusInProgress, nil
+	}
+	return app.RolloutStatusComplete, nil
+}
+
+// RolloutState is the subset of an Argo Rollout's status used to track a rollout
+type RolloutState struct {
+	Generation         int64
+	ObservedGeneration int64
+	// Phase is one of Healthy, Progressing, Paused, Degraded
+	Phase   string
+	Message string
+	// Aborted is true when the rollout was aborted (manually or by a failed analysis)
+	Aborted           bool
+	CurrentPodHash    string
+	StableRS          string
+	Replicas          int64
+	UpdatedReplicas   int64
+	ReadyReplicas     int64
+	AvailableReplicas int64
+}
+
+// RolloutStateFromUnstructured reads the rollout progress out of an argoproj.io/v1alpha1 Rollout
+func RolloutStateFromUnstructured(obj *unstructured.Unstructured) RolloutState {
+	state := RolloutState{Generation: obj.GetGeneration()}
+	// Argo Rollouts reports observedGeneration as a string
+	if val, found, _ := unstructured.NestedFieldNoCopy(obj.Object, "status", "observedGeneration"); found {
+		switch v := val.(type) {
+		case string:
+			state.ObservedGeneration, _ = strconv.ParseInt(v, 10, 64)
+		case int64:
+			state.ObservedGeneration = v
+		case float64:
+			state.ObservedGeneration = int64(v)
+		}
+	}
+	state.Phase, _, _ = unstructured.NestedString(obj.Object, "status", "phase")
+	state.Message, _, _ = unstructured.NestedString(obj.Object, "status", "message")
+	state.Aborted, _, _ = unstructured.NestedBool(obj.Object, "status", "abort")
+	state.CurrentPodHash, _, _ = unstructured.NestedString(obj.Object, "status", "currentPodHash")
+	state.StableRS, _, _ = unstructured.NestedString(obj.Object, "status", "stableRS")
+	state.Replicas = 1
+	if replicas, found, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas"); found {
+		state.Replicas = replicas
+	}
+	state.UpdatedReplicas, _, _ = unstructured.NestedInt64(obj.Object, "status", "updatedReplicas")
+	state.ReadyReplicas, _, _ = unstructured.NestedInt64(obj.Object, "status", "readyReplicas")
+	state.AvailableReplicas, _, _ = unstructured.NestedInt64(obj.Object, "status", "availableReplicas")
+	return state
+}
+
+// CheckRollout maps the Argo Rollout status to a friendly app.RolloutStatus
+// A Paused rollout is still in progress; it is waiting on a pause step or a manual promotion
+func CheckRollout(state RolloutState) (app.RolloutStatus, error) {
+	if state.ObservedGeneration < state.Generation {
+		return app.RolloutStatusPending, nil
+	}
+	if state.Aborted || state.Phase == "Degraded" {
+		msg := state.Message
+		if msg == "" {
+			msg = "rollout is degraded"
+		}
+		return app.RolloutStatusFailed, fmt.Errorf("rollout failed: %s", msg)
+	}
+	if state.Phase == "Healthy" {
+		return app.RolloutStatusComplete, nil
+	}
+	return app.RolloutStatusInProgress, nil
+}
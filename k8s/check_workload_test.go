@@ -0,0 +1,232 @@
+package k8s
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/nullstone-io/deployment-sdk/app"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestCheckStatefulSet(t *testing.T) {
+	three := int32(3)
+	two := int32(2)
+
+	tests := []struct {
+		name string
+		sts  v1.StatefulSet
+		want app.RolloutStatus
+	}{
+		{
+			name: "not observed",
+			sts: v1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Generation: 3},
+				Spec:       v1.StatefulSetSpec{Replicas: &three},
+				Status:     v1.StatefulSetStatus{ObservedGeneration: 2},
+			},
+			want: app.RolloutStatusPending,
+		},
+		{
+			name: "pods not ready",
+			sts: v1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Generation: 3},
+				Spec:       v1.StatefulSetSpec{Replicas: &three},
+				Status:     v1.StatefulSetStatus{ObservedGeneration: 3, ReadyReplicas: 2, CurrentRevision: "app-1", UpdateRevision: "app-2"},
+			},
+			want: app.RolloutStatusInProgress,
+		},
+		{
+			name: "revision not rolled out",
+			sts: v1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Generation: 3},
+				Spec:       v1.StatefulSetSpec{Replicas: &three},
+				Status:     v1.StatefulSetStatus{ObservedGeneration: 3, ReadyReplicas: 3, UpdatedReplicas: 2, CurrentRevision: "app-1", UpdateRevision: "app-2"},
+			},
+			want: app.RolloutStatusInProgress,
+		},
+		{
+			name: "partitioned rollout complete",
+			sts: v1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Generation: 3},
+				Spec: v1.StatefulSetSpec{
+					Replicas: &three,
+					UpdateStrategy: v1.StatefulSetUpdateStrategy{
+						Type:          v1.RollingUpdateStatefulSetStrategyType,
+						RollingUpdate: &v1.RollingUpdateStatefulSetStrategy{Partition: &two},
+					},
+				},
+				Status: v1.StatefulSetStatus{ObservedGeneration: 3, ReadyReplicas: 3, UpdatedReplicas: 1, CurrentRevision: "app-1", UpdateRevision: "app-2"},
+			},
+			want: app.RolloutStatusComplete,
+		},
+		{
+			name: "partitioned rollout in progress",
+			sts: v1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Generation: 3},
+				Spec: v1.StatefulSetSpec{
+					Replicas: &three,
+					UpdateStrategy: v1.StatefulSetUpdateStrategy{
+						Type:          v1.RollingUpdateStatefulSetStrategyType,
+						RollingUpdate: &v1.RollingUpdateStatefulSetStrategy{Partition: &two},
+					},
+				},
+				Status: v1.StatefulSetStatus{ObservedGeneration: 3, ReadyReplicas: 3, UpdatedReplicas: 0, CurrentRevision: "app-1", UpdateRevision: "app-2"},
+			},
+			want: app.RolloutStatusInProgress,
+		},
+		{
+			name: "completed",
+			sts: v1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Generation: 3},
+				Spec:       v1.StatefulSetSpec{Replicas: &three},
+				Status:     v1.StatefulSetStatus{ObservedGeneration: 3, ReadyReplicas: 3, UpdatedReplicas: 3, CurrentRevision: "app-2", UpdateRevision: "app-2"},
+			},
+			want: app.RolloutStatusComplete,
+		},
+		{
+			name: "on delete",
+			sts: v1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Generation: 3},
+				Spec: v1.StatefulSetSpec{
+					Replicas:       &three,
+					UpdateStrategy: v1.StatefulSetUpdateStrategy{Type: v1.OnDeleteStatefulSetStrategyType},
+				},
+			},
+			want: app.RolloutStatusComplete,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := CheckStatefulSet(&test.sts)
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestCheckDaemonSet(t *testing.T) {
+	tests := []struct {
+		name string
+		ds   v1.DaemonSet
+		want app.RolloutStatus
+	}{
+		{
+			name: "not observed",
+			ds: v1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Status:     v1.DaemonSetStatus{ObservedGeneration: 1},
+			},
+			want: app.RolloutStatusPending,
+		},
+		{
+			name: "not all nodes updated",
+			ds: v1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Status:     v1.DaemonSetStatus{ObservedGeneration: 2, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 2, NumberAvailable: 3},
+			},
+			want: app.RolloutStatusInProgress,
+		},
+		{
+			name: "not all nodes available",
+			ds: v1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Status:     v1.DaemonSetStatus{ObservedGeneration: 2, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberAvailable: 2},
+			},
+			want: app.RolloutStatusInProgress,
+		},
+		{
+			name: "completed",
+			ds: v1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Status:     v1.DaemonSetStatus{ObservedGeneration: 2, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberAvailable: 3},
+			},
+			want: app.RolloutStatusComplete,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := CheckDaemonSet(&test.ds)
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestCheckRollout(t *testing.T) {
+	rollout := func(generation int64, status map[string]any) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "argoproj.io/v1alpha1",
+			"kind":       "Rollout",
+			"metadata":   map[string]any{"name": "api"},
+			"status":     status,
+		}}
+		obj.SetGeneration(generation)
+		return obj
+	}
+
+	tests := []struct {
+		name    string
+		rollout *unstructured.Unstructured
+		want    app.RolloutStatus
+		err     error
+	}{
+		{
+			name:    "not observed",
+			rollout: rollout(4, map[string]any{"observedGeneration": "3", "phase": "Healthy"}),
+			want:    app.RolloutStatusPending,
+		},
+		{
+			name:    "paused canary",
+			rollout: rollout(4, map[string]any{"observedGeneration": "4", "phase": "Paused", "message": "CanaryPauseStep"}),
+			want:    app.RolloutStatusInProgress,
+		},
+		{
+			name:    "degraded",
+			rollout: rollout(4, map[string]any{"observedGeneration": "4", "phase": "Degraded", "message": "ProgressDeadlineExceeded: ReplicaSet \"api-6b8f\" has timed out progressing."}),
+			want:    app.RolloutStatusFailed,
+			err:     fmt.Errorf("rollout failed: ProgressDeadlineExceeded: ReplicaSet \"api-6b8f\" has timed out progressing."),
+		},
+		{
+			name:    "aborted",
+			rollout: rollout(4, map[string]any{"observedGeneration": "4", "phase": "Degraded", "abort": true}),
+			want:    app.RolloutStatusFailed,
+			err:     fmt.Errorf("rollout failed: rollout is degraded"),
+		},
+		{
+			name:    "healthy",
+			rollout: rollout(4, map[string]any{"observedGeneration": "4", "phase": "Healthy"}),
+			want:    app.RolloutStatusComplete,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := CheckRollout(RolloutStateFromUnstructured(test.rollout))
+			assert.Equal(t, test.err, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestParseWorkloadKind(t *testing.T) {
+	tests := map[string]WorkloadKind{
+		"":            WorkloadKindDeployment,
+		"Deployment":  WorkloadKindDeployment,
+		"statefulset": WorkloadKindStatefulSet,
+		"DaemonSet":   WorkloadKindDaemonSet,
+		"rollout":     WorkloadKindRollout,
+	}
+	for input, want := range tests {
+		got, err := ParseWorkloadKind(input)
+		assert.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+
+	_, err := ParseWorkloadKind("ReplicaSet")
+	assert.Error(t, err)
+}
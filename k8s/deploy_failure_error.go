@@ -0,0 +1,78 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nullstone-io/deployment-sdk/k8s/failures"
+)
+
+const (
+	// maxReportedRootCauses limits how many root causes are attached to a DeployFailureError
+	maxReportedRootCauses = 3
+)
+
+var (
+	_ error = &DeployFailureError{}
+)
+
+// DeployFailureError is returned by DeployWatcher when a rollout fails or times out
+// It carries the ranked root causes observed during the rollout (most likely cause first)
+// Unwrap returns the underlying error so `errors.Is(err, app.ErrFailed)` and `errors.Is(err, app.ErrTimeout)` still work
+type DeployFailureError struct {
+	Cause      error                `json:"-"`
+	RootCauses []failures.RootCause `json:"rootCauses"`
+	// TotalPods is the number of pods created for the new revision
+	TotalPods int `json:"totalPods"`
+}
+
+// NewDeployFailureError ranks the failures in report and attaches the top root causes to cause
+// If the report is empty, cause is returned unchanged
+func NewDeployFailureError(cause error, report *failures.Report, totalPods int) error {
+	if report == nil || report.Len() == 0 {
+		return cause
+	}
+	if affected := len(report.Pods()); totalPods < affected {
+		totalPods = affected
+	}
+	rootCauses := report.RootCauses()
+	if len(rootCauses) > maxReportedRootCauses {
+		rootCauses = rootCauses[:maxReportedRootCauses]
+	}
+	return &DeployFailureError{
+		Cause:      cause,
+		RootCauses: rootCauses,
+		TotalPods:  totalPods,
+	}
+}
+
+// Error renders the top root cause (e.g. "ImagePullBackOff/NotFound on 3/3 new pods: Image tag or repository does not exist")
+func (e *DeployFailureError) Error() string {
+	if len(e.RootCauses) == 0 {
+		return e.Cause.Error()
+	}
+	msg := e.RootCauses[0].Describe(e.TotalPods)
+	if more := len(e.RootCauses) - 1; more > 0 {
+		msg = fmt.Sprintf("%s (and %d more)", msg, more)
+	}
+	return msg
+}
+
+func (e *DeployFailureError) Unwrap() error {
+	return e.Cause
+}
+
+// Report renders every root cause with its remediation, one per block
+func (e *DeployFailureError) Report() string {
+	sb := strings.Builder{}
+	for i, rc := range e.RootCauses {
+		fmt.Fprintf(&sb, "%d. %s\n", i+1, rc.Describe(e.TotalPods))
+		if msg := rc.Failure.Signals.EventMessage; msg != "" {
+			fmt.Fprintf(&sb, "   Evidence: %s\n", msg)
+		}
+		if rc.Failure.Remediation != "" {
+			fmt.Fprintf(&sb, "   Remediation: %s\n", rc.Failure.Remediation)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,55 @@
+package k8s
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nullstone-io/deployment-sdk/app"
+	"github.com/nullstone-io/deployment-sdk/k8s/failures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDeployFailureError(t *testing.T) {
+	notFound := func(pod string) failures.Failure {
+		return failures.Failure{
+			Name:        "ImagePullBackOff/NotFound",
+			Category:    failures.CategoryImage,
+			Summary:     "Image tag or repository does not exist",
+			Remediation: "Verify the image reference; ensure the tag is pushed.",
+			Object:      failures.ObjectRef{Kind: "Pod", Name: pod},
+			Signals:     failures.Signals{EventMessage: `manifest for api:v1.2.3 not found`},
+		}
+	}
+
+	t.Run("empty report returns cause", func(t *testing.T) {
+		err := NewDeployFailureError(app.ErrTimeout, failures.NewReport(), 3)
+		assert.Equal(t, app.ErrTimeout, err)
+	})
+
+	t.Run("ranked root causes", func(t *testing.T) {
+		report := failures.NewReport()
+		report.Add(failures.Failure{Name: "ProgressDeadlineExceeded", Category: failures.CategoryRollout, Object: failures.ObjectRef{Kind: "Deployment", Name: "api"}})
+		for _, pod := range []string{"api-1", "api-2", "api-3"} {
+			report.Add(notFound(pod))
+		}
+		err := NewDeployFailureError(rolloutError{err: errors.New("deployment failed because of timeout (exceeding its deadline)")}, report, 3)
+
+		var dfe *DeployFailureError
+		require.ErrorAs(t, err, &dfe)
+		assert.ErrorIs(t, err, app.ErrFailed)
+		assert.Equal(t, "ImagePullBackOff/NotFound on 3/3 new pods: Image tag or repository does not exist (and 1 more)", err.Error())
+		assert.Equal(t, 3, dfe.TotalPods)
+		assert.Contains(t, dfe.Report(), "Evidence: manifest for api:v1.2.3 not found")
+		assert.Contains(t, dfe.Report(), "Remediation: Verify the image reference; ensure the tag is pushed.")
+	})
+
+	t.Run("total pods covers pods observed in events", func(t *testing.T) {
+		report := failures.NewReport()
+		report.Add(notFound("api-1"))
+		report.Add(notFound("api-2"))
+		err := NewDeployFailureError(app.ErrTimeout, report, 0)
+		assert.ErrorIs(t, err, app.ErrTimeout)
+		assert.Equal(t, "ImagePullBackOff/NotFound on 2/2 new pods: Image tag or repository does not exist", err.Error())
+	})
+}
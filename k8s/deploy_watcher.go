@@ -12,6 +12,7 @@ import (
 	"github.com/mitchellh/colorstring"
 	"github.com/nullstone-io/deployment-sdk/app"
 	"github.com/nullstone-io/deployment-sdk/k8s/failures"
+	"github.com/nullstone-io/deployment-sdk/k8s/logs"
 	"github.com/nullstone-io/deployment-sdk/logging"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -19,6 +20,7 @@ import (
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 )
 
 const (
@@ -29,21 +31,42 @@ var (
 	_ app.DeployWatcher = &DeployWatcher{}
 
 	watchDefaultTimeout = 15 * time.Minute
+	// maxPreviousLogPods limits how many restarted pods have their previous logs printed when a rollout fails
+	maxPreviousLogPods = 3
 )
 
 // DeployWatcher is responsible for watching a kubernetes deployment
-// It detects completion/cancellation by watching the Deployment object
-// While waiting, all events for the Deployment, Service, and Pods are logged
+// It detects completion/cancellation by watching the app's workload (Deployment, StatefulSet, DaemonSet, or Argo Rollout)
+// While waiting, all events for the workload, Service, and Pods are logged
+// Once the workload is rolled out, it waits for the Ingresses and Gateway API HTTPRoutes in front of the app's Service to be ready
+// If the rollout fails or times out, the failures observed along the way are ranked and returned as a *DeployFailureError
 type DeployWatcher struct {
 	OsWriters    logging.OsWriters
 	Details      app.Details
 	AppNamespace string
 	AppName      string
+	// WorkloadKind is the kind of object named AppName; an empty value means a Deployment
+	WorkloadKind WorkloadKind
 	NewConfigFn  NewConfiger
 	Timeout      time.Duration
+	// SkipRouteChecks completes the deployment once the workload rolls out without waiting for Ingresses and HTTPRoutes
+	// Use this for ingress controllers that never publish a load balancer address
+	SkipRouteChecks bool
+	// Failures classifies the events and pod states observed during the rollout
+	// Provider watchers set this to a catalog with their discriminators (see failures.Catalog.WithProvider)
+	// If nil, failures.Default() is used
+	Failures *failures.Catalog
 
+	config  *rest.Config
 	client  *kubernetes.Clientset
+	dynamic *dynamic.DynamicClient
 	tracker *AppObjectsTracker
+	// report accumulates classified events; it is only written by streamEvents until `flushed` is closed
+	report *failures.Report
+	// startedAt is the creation time of the revision being rolled out
+	startedAt *time.Time
+	// rejected receives the first failure that prevents the controller from creating pods (see failsFast)
+	rejected chan failures.Failure
 }
 
 func (w *DeployWatcher) Watch(ctx context.Context, reference string, isFirstDeploy bool) error {
@@ -66,22 +89,94 @@ func (w *DeployWatcher) Watch(ctx context.Context, reference string, isFirstDepl
 		fmt.Fprintln(stdout, "Invalid deployment reference. Expected a deployment generation.")
 		return app.ErrFailed
 	}
+	if w.WorkloadKind == "" {
+		w.WorkloadKind = WorkloadKindDeployment
+	}
 	if err := w.init(ctx); err != nil {
 		return err
 	}
 
 	sw := NewServiceWatcher(w.client, w.AppNamespace, w.AppName, w.OsWriters)
 
+	w.rejected = make(chan failures.Failure, 1)
 	started := make(chan *time.Time)
 	ended := make(chan struct{})
 	flushed := make(chan struct{})
 	go w.streamEvents(ctx, started, ended, flushed)
 	defer sw.Stream()()
-	err = w.monitorDeployment(ctx, generation, started, ended)
+	err = w.monitorWorkload(ctx, generation, started, ended)
 	<-flushed
+	w.warnResourceAdjustments(w.startedAt)
+	if errors.Is(err, app.ErrFailed) || errors.Is(err, app.ErrTimeout) {
+		return w.diagnose(err)
+	}
 	return err
 }
 
+// diagnose combines the failures observed during the rollout with a snapshot of the new pods
+// The ranked root causes are printed and attached to the returned error
+func (w *DeployWatcher) diagnose(cause error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	totalPods := w.collectPodFailures(ctx, w.report, w.startedAt)
+	w.collectWorkloadFailures(ctx, w.report)
+	w.printPreviousLogs(w.startedAt)
+	err := NewDeployFailureError(cause, w.report, totalPods)
+	var dfe *DeployFailureError
+	if errors.As(err, &dfe) {
+		stdout := w.OsWriters.Stdout()
+		fmt.Fprintln(stdout, "Root causes:")
+		fmt.Fprint(stdout, dfe.Report())
+	}
+	return err
+}
+
+// printPreviousLogs prints the logs of the last terminated instance of each restarted container in the new pods
+// A crash-looping container usually exits before its logs can be streamed, so this is the only place its final output is visible
+func (w *DeployWatcher) printPreviousLogs(start *time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	pods, err := w.client.CoreV1().Pods(w.AppNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("nullstone.io/app=%s", w.AppName),
+	})
+	if err != nil {
+		return
+	}
+	buffer := &logs.SimpleLogBuffer{Emitter: app.NewWriterLogEmitter(w.OsWriters.Stdout())}
+	source := logs.StreamSource{Config: w.config, GetTimeout: 5 * time.Second}
+	printed := 0
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if start != nil && pod.CreationTimestamp.Time.Before(*start) {
+			continue
+		}
+		restarted := false
+		for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+			if status.RestartCount == 0 {
+				continue
+			}
+			if !restarted {
+				if printed >= maxPreviousLogPods {
+					return
+				}
+				printed++
+				restarted = true
+			}
+			fmt.Fprintf(w.OsWriters.Stdout(), "Logs from restarted container %s/%s:\n", pod.Name, status.Name)
+			streamer := &logs.ContainerStreamer{
+				Namespace:     w.AppNamespace,
+				WorkloadName:  w.AppName,
+				Pod:           pod,
+				ContainerName: status.Name,
+				LogSource:     source,
+			}
+			streamer.Stream(ctx, app.LogStreamOptions{StartTime: start, WatchInterval: -1, Previous: true}, buffer)
+		}
+	}
+}
+
 func (w *DeployWatcher) init(ctx context.Context) error {
 	cfg, err := w.NewConfigFn(ctx)
 	if err != nil {
@@ -91,16 +186,19 @@ func (w *DeployWatcher) init(ctx context.Context) error {
 	if err != nil {
 		return w.newInitError("There was an error initializing kubernetes client", err)
 	}
+	w.config = cfg
 	w.client = client
 	dyn, err := dynamic.NewForConfig(cfg)
 	if err != nil {
 		return w.newInitError("There was an error initializing kubernetes dynamic client", err)
 	}
+	w.dynamic = dyn
 	disc, err := discovery.NewDiscoveryClientForConfig(cfg)
 	if err != nil {
 		return w.newInitError("There was an error initializing kubernetes discovery client", err)
 	}
 	w.tracker = NewObjectTracker(w.AppName, dyn, disc, w.OsWriters)
+	w.report = failures.NewReport()
 	return nil
 }
 
@@ -108,9 +206,9 @@ func (w *DeployWatcher) newInitError(msg string, err error) app.LogInitError {
 	return app.NewLogInitError("k8s", fmt.Sprintf("%s/%s", w.AppNamespace, w.AppName), msg, err)
 }
 
-// monitorDeployment polls Kubernetes for updates on the deployment
-// This will run until the deployment completes, fails, or times out
-func (w *DeployWatcher) monitorDeployment(ctx context.Context, generation int64, started chan *time.Time, ended chan struct{}) error {
+// monitorWorkload polls Kubernetes for updates on the app's workload
+// This will run until the rollout completes, fails, or times out
+func (w *DeployWatcher) monitorWorkload(ctx context.Context, generation int64, started chan *time.Time, ended chan struct{}) error {
 	defer close(ended)
 	defer close(started)
 
@@ -123,15 +221,18 @@ func (w *DeployWatcher) monitorDeployment(ctx context.Context, generation int64,
 
 	stdout := w.OsWriters.Stdout()
 	init := sync.Once{}
+	objectRef := w.WorkloadKind.ObjectRef(w.AppName)
+	// revision is the workload revision for the generation being watched
+	revision := ""
 
 	for {
-		deployment, err := w.client.AppsV1().Deployments(w.AppNamespace).Get(ctx, w.AppName, metav1.GetOptions{})
+		workload, err := w.getWorkload(ctx)
 		if err != nil {
 			if apierrors.IsNotFound(err) {
 				colorstring.Fprintln(stdout, DeployEvent{
 					Timestamp: time.Now(),
 					Type:      EventTypeError,
-					Object:    fmt.Sprintf("deployment/%s", w.AppName),
+					Object:    objectRef,
 					Message:   "Deployment failed because it was deleted.",
 				}.String())
 				return app.ErrFailed
@@ -139,47 +240,73 @@ func (w *DeployWatcher) monitorDeployment(ctx context.Context, generation int64,
 			if errors.Is(err, context.Canceled) {
 				return w.translateCancellation(ctx)
 			}
-			return fmt.Errorf("error retrieving deployment: %w", err)
+			return fmt.Errorf("error retrieving %s: %w", strings.ToLower(string(w.WorkloadKind)), err)
 		}
-		if deployment != nil {
-			init.Do(func() {
-				start := FindDeploymentStartTime(ctx, w.client, w.AppNamespace, deployment, generation)
-				if start != nil {
-					colorstring.Fprintln(stdout, DeployEvent{
-						Timestamp: *start,
-						Type:      EventTypeNormal,
-						Reason:    "Created",
-						Object:    fmt.Sprintf("deployment/%s", w.AppName),
-						Message:   fmt.Sprintf("Created deployment revision %s", deployment.Annotations[RevisionAnnotation]),
-					}.String())
-				}
-				started <- start
-			})
-			if generation != 0 && deployment.Generation > generation {
-				// If the deployment has a new generation, there must be a new deployment that invalidates this one
-				msg := fmt.Sprintf("A new deployment (generation = %d) was triggered which invalidates this deployment.", deployment.Generation)
+		init.Do(func() {
+			start := w.findStartTime(ctx, workload, generation)
+			w.startedAt = start
+			if start != nil {
+				colorstring.Fprintln(stdout, DeployEvent{
+					Timestamp: *start,
+					Type:      EventTypeNormal,
+					Reason:    "Created",
+					Object:    objectRef,
+					Message:   fmt.Sprintf("Created %s revision %s", strings.ToLower(string(w.WorkloadKind)), workload.Revision),
+				}.String())
+			}
+			w.warnDisruptionBudgets(ctx, workload)
+			started <- start
+		})
+		if workload.Generation == generation && workload.ObservedGeneration >= generation {
+			revision = workload.Revision
+		}
+		if generation != 0 && workload.Generation > generation {
+			if !isScaleOnly(workload, revision) {
+				// If the workload has a new generation with a new revision, there must be a new deployment that invalidates this one
+				msg := fmt.Sprintf("A new deployment (generation = %d) was triggered which invalidates this deployment.", workload.Generation)
 				colorstring.Fprintln(stdout, DeployEvent{
 					Timestamp: time.Now(),
 					Type:      EventTypeWarning,
-					Object:    fmt.Sprintf("deployment/%s", deployment.Name),
+					Object:    objectRef,
 					Message:   msg,
 				}.String())
 				return fmt.Errorf("%s", msg)
 			}
+			if workload.ObservedGeneration >= workload.Generation {
+				// The workload was scaled (e.g. by a HorizontalPodAutoscaler) while rolling out; keep watching the same revision
+				colorstring.Fprintln(stdout, DeployEvent{
+					Timestamp: time.Now(),
+					Type:      EventTypeNormal,
+					Reason:    "Scaled",
+					Object:    objectRef,
+					Message:   fmt.Sprintf("Scaled %s (generation = %d) while rolling out revision %s", strings.ToLower(string(w.WorkloadKind)), workload.Generation, revision),
+				}.String())
+				generation = workload.Generation
+			}
 		}
 
-		status, err := CheckDeployment(deployment)
-		if err != nil {
-			return err
+		if workload.Err != nil {
+			return rolloutError{err: workload.Err}
 		}
-		if status == app.RolloutStatusComplete {
-			return nil
+		if workload.Status == app.RolloutStatusComplete {
+			if w.SkipRouteChecks {
+				return nil
+			}
+			return w.monitorRoutes(ctx)
 		}
 
 		// Pause 3s between polling
 		delay := 3 * time.Second
 		select {
 		case <-time.After(delay):
+		case f := <-w.rejected:
+			colorstring.Fprintln(stdout, DeployEvent{
+				Timestamp: time.Now(),
+				Type:      EventTypeError,
+				Object:    objectRef,
+				Message:   fmt.Sprintf("Deployment failed because new pods were rejected: %s", f.Summary),
+			}.String())
+			return rolloutError{err: fmt.Errorf("new pods could not be created: %s", f.Summary)}
 		case <-ctx.Done():
 			return w.translateCancellation(ctx)
 		}
@@ -277,13 +404,36 @@ func (w *DeployWatcher) emitEvent(ctx context.Context, earliest time.Time, event
 		Reason:    event.Reason,
 		Object:    obj,
 		Message:   event.Message,
-		Failure:   failures.ClassifyEvent(event),
+		Failure:   w.catalog().ClassifyEvent(event),
 	}
 	// Promote informational events to Warning when classification reveals an
 	// actionable failure the raw event Type didn't flag (e.g. probe failures
 	// arrive as Normal/Warning depending on K8s version).
-	if de.Failure != nil && de.Type == EventTypeNormal {
-		de.Type = EventTypeWarning
+	if de.Failure != nil {
+		w.report.Add(*de.Failure)
+		if de.Type == EventTypeNormal {
+			de.Type = EventTypeWarning
+		}
+		if failsFast(event, *de.Failure) {
+			select {
+			case w.rejected <- *de.Failure:
+			default:
+			}
+		}
 	}
 	colorstring.Fprintln(stdout, de.String())
 }
+
+func (w *DeployWatcher) catalog() *failures.Catalog {
+	if w.Failures != nil {
+		return w.Failures
+	}
+	return failures.Default()
+}
+
+// failsFast reports whether a classified event means the rollout cannot make progress
+// A controller that cannot create pods (quota, LimitRange, admission webhook, PodSecurity) keeps retrying
+// and emitting FailedCreate until the progress deadline; there is no point waiting that out
+func failsFast(event corev1.Event, f failures.Failure) bool {
+	return event.Reason == "FailedCreate" && f.Category == failures.CategoryAdmission
+}
@@ -0,0 +1,109 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/nullstone-io/deployment-sdk/k8s/failures"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestFailsFast(t *testing.T) {
+	tests := []struct {
+		name    string
+		reason  string
+		message string
+		want    bool
+	}{
+		{
+			name:    "resource quota",
+			reason:  "FailedCreate",
+			message: `Error creating: pods "api-6b8f-x" is forbidden: exceeded quota: compute, requested: cpu=2, used: cpu=9, limited: cpu=10`,
+			want:    true,
+		},
+		{
+			name:    "limit range",
+			reason:  "FailedCreate",
+			message: `Error creating: pods "api-6b8f-x" is forbidden: maximum memory usage per Container is 1Gi, but limit is 2Gi`,
+			want:    true,
+		},
+		{
+			name:    "admission webhook",
+			reason:  "FailedCreate",
+			message: `Error creating: admission webhook "policy.example.com" denied the request: image must be signed`,
+			want:    true,
+		},
+		{
+			name:    "pod security",
+			reason:  "FailedCreate",
+			message: `Error creating: pods "api-6b8f-x" is forbidden: violates PodSecurity "restricted:latest": runAsNonRoot != true`,
+			want:    true,
+		},
+		{
+			name:    "unschedulable waits for autoscaler",
+			reason:  "FailedScheduling",
+			message: "0/3 nodes are available: 3 Insufficient cpu.",
+			want:    false,
+		},
+		{
+			name:    "probe failure",
+			reason:  "Unhealthy",
+			message: "Readiness probe failed: connection refused",
+			want:    false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			event := corev1.Event{
+				InvolvedObject: corev1.ObjectReference{Kind: "ReplicaSet", Namespace: "app", Name: "api-6b8f"},
+				Reason:         test.reason,
+				Message:        test.message,
+			}
+			f := failures.ClassifyEvent(event)
+			if f == nil {
+				t.Fatalf("expected %s event to be classified", test.reason)
+			}
+			assert.Equal(t, test.want, failsFast(event, *f))
+		})
+	}
+}
+
+func TestIsScaleOnly(t *testing.T) {
+	tests := []struct {
+		name     string
+		workload workloadSnapshot
+		revision string
+		want     bool
+	}{
+		{
+			name:     "same revision",
+			workload: workloadSnapshot{Generation: 4, ObservedGeneration: 4, Revision: "7"},
+			revision: "7",
+			want:     true,
+		},
+		{
+			name:     "new revision",
+			workload: workloadSnapshot{Generation: 4, ObservedGeneration: 4, Revision: "8"},
+			revision: "7",
+			want:     false,
+		},
+		{
+			name:     "not observed yet",
+			workload: workloadSnapshot{Generation: 4, ObservedGeneration: 3, Revision: "7"},
+			revision: "7",
+			want:     true,
+		},
+		{
+			name:     "revision unknown",
+			workload: workloadSnapshot{Generation: 4, ObservedGeneration: 4, Revision: "7"},
+			want:     false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, isScaleOnly(&test.workload, test.revision))
+		})
+	}
+}
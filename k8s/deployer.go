@@ -7,9 +7,13 @@ import (
 
 	"github.com/nullstone-io/deployment-sdk/app"
 	env_vars "github.com/nullstone-io/deployment-sdk/env-vars"
+	"github.com/nullstone-io/deployment-sdk/k8s/preflight"
 	"github.com/nullstone-io/deployment-sdk/logging"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -18,8 +22,15 @@ type Deployer struct {
 	AppName           string
 	MainContainerName string
 	ServiceName       string
+	// WorkloadKind is the kind of object named ServiceName; an empty value means a Deployment
+	WorkloadKind      WorkloadKind
 	JobDefinitionName string
 	OsWriters         logging.OsWriters
+	// SkipPreflight deploys without verifying the objects referenced by the pod template
+	SkipPreflight bool
+
+	// DynamicClient is required to deploy Argo Rollouts, which have no typed client
+	DynamicClient dynamic.Interface
 }
 
 func (d Deployer) Validate(meta app.DeployMetadata) (bool, error) {
@@ -63,6 +74,19 @@ func (d Deployer) Deploy(ctx context.Context, kubeClient *kubernetes.Clientset,
 }
 
 func (d Deployer) deployService(ctx context.Context, kubeClient *kubernetes.Clientset, meta app.DeployMetadata) (string, error) {
+	switch d.WorkloadKind {
+	case WorkloadKindStatefulSet:
+		return d.deployStatefulSet(ctx, kubeClient, meta)
+	case WorkloadKindDaemonSet:
+		return d.deployDaemonSet(ctx, kubeClient, meta)
+	case WorkloadKindRollout:
+		return d.deployRollout(ctx, kubeClient, meta)
+	default:
+		return d.deployDeployment(ctx, kubeClient, meta)
+	}
+}
+
+func (d Deployer) deployDeployment(ctx context.Context, kubeClient *kubernetes.Clientset, meta app.DeployMetadata) (string, error) {
 	stdout, _ := d.OsWriters.Stdout(), d.OsWriters.Stderr()
 
 	deployment, err := kubeClient.AppsV1().Deployments(d.K8sNamespace).Get(ctx, d.ServiceName, metav1.GetOptions{})
@@ -77,23 +101,137 @@ func (d Deployer) deployService(ctx context.Context, kubeClient *kubernetes.Clie
 	if err != nil {
 		return "", err
 	}
+	if err := d.runPreflight(ctx, kubeClient, deployment.Spec.Template); err != nil {
+		return "", err
+	}
 
 	updated, err := kubeClient.AppsV1().Deployments(d.K8sNamespace).Update(ctx, deployment, metav1.UpdateOptions{})
 	if err != nil {
 		return "", fmt.Errorf("error deploying app: %w", err)
 	}
 	fmt.Fprintln(stdout, "Updated deployment successfully")
-	updGeneration := updated.Generation
-	reference := fmt.Sprintf("%d", updGeneration)
+	return d.generationReference("deployment", curGeneration, updated.Generation), nil
+}
 
-	if curGeneration == updGeneration {
-		reference = DeployReferenceNoop
-		fmt.Fprintln(stdout, "No changes made to deployment.")
-	} else {
-		fmt.Fprintf(stdout, "Created new deployment (generation = %s).\n", reference)
+func (d Deployer) deployStatefulSet(ctx context.Context, kubeClient *kubernetes.Clientset, meta app.DeployMetadata) (string, error) {
+	stdout, _ := d.OsWriters.Stdout(), d.OsWriters.Stderr()
+
+	sts, err := kubeClient.AppsV1().StatefulSets(d.K8sNamespace).Get(ctx, d.ServiceName, metav1.GetOptions{})
+	if err != nil {
+		return "", err
 	}
+	curGeneration := sts.Generation
 
-	return reference, nil
+	sts.ObjectMeta = UpdateVersionLabel(sts.ObjectMeta, meta.Version)
+	sts.Spec.Template, err = d.updatePodTemplate(sts.Spec.Template, "stateful set", meta)
+	if err != nil {
+		return "", err
+	}
+	if err := d.runPreflight(ctx, kubeClient, sts.Spec.Template); err != nil {
+		return "", err
+	}
+
+	updated, err := kubeClient.AppsV1().StatefulSets(d.K8sNamespace).Update(ctx, sts, metav1.UpdateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("error deploying app: %w", err)
+	}
+	fmt.Fprintln(stdout, "Updated stateful set successfully")
+	if partition := statefulSetPartition(updated); partition > 0 {
+		fmt.Fprintf(stdout, "Rolling update is partitioned: only pods with an ordinal >= %d will be updated.\n", partition)
+	}
+	return d.generationReference("stateful set", curGeneration, updated.Generation), nil
+}
+
+func (d Deployer) deployDaemonSet(ctx context.Context, kubeClient *kubernetes.Clientset, meta app.DeployMetadata) (string, error) {
+	stdout, _ := d.OsWriters.Stdout(), d.OsWriters.Stderr()
+
+	ds, err := kubeClient.AppsV1().DaemonSets(d.K8sNamespace).Get(ctx, d.ServiceName, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	curGeneration := ds.Generation
+
+	ds.ObjectMeta = UpdateVersionLabel(ds.ObjectMeta, meta.Version)
+	ds.Spec.Template, err = d.updatePodTemplate(ds.Spec.Template, "daemon set", meta)
+	if err != nil {
+		return "", err
+	}
+	if err := d.runPreflight(ctx, kubeClient, ds.Spec.Template); err != nil {
+		return "", err
+	}
+
+	updated, err := kubeClient.AppsV1().DaemonSets(d.K8sNamespace).Update(ctx, ds, metav1.UpdateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("error deploying app: %w", err)
+	}
+	fmt.Fprintln(stdout, "Updated daemon set successfully")
+	return d.generationReference("daemon set", curGeneration, updated.Generation), nil
+}
+
+// deployRollout updates the pod template of an Argo Rollout
+// Argo Rollouts then performs the canary or blue-green strategy configured on the Rollout
+func (d Deployer) deployRollout(ctx context.Context, kubeClient *kubernetes.Clientset, meta app.DeployMetadata) (string, error) {
+	stdout, _ := d.OsWriters.Stdout(), d.OsWriters.Stderr()
+
+	if d.DynamicClient == nil {
+		return "", fmt.Errorf("cannot deploy Argo Rollout %q: dynamic kubernetes client was not configured", d.ServiceName)
+	}
+	rollouts := d.DynamicClient.Resource(RolloutGVR).Namespace(d.K8sNamespace)
+	rollout, err := rollouts.Get(ctx, d.ServiceName, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	curGeneration := rollout.GetGeneration()
+
+	rawTemplate, found, err := unstructured.NestedMap(rollout.Object, "spec", "template")
+	if err != nil {
+		return "", fmt.Errorf("invalid pod template in rollout %q: %w", d.ServiceName, err)
+	} else if !found {
+		return "", fmt.Errorf("rollout %q does not have a pod template (rollouts that use workloadRef are not supported)", d.ServiceName)
+	}
+	var template corev1.PodTemplateSpec
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(rawTemplate, &template); err != nil {
+		return "", fmt.Errorf("invalid pod template in rollout %q: %w", d.ServiceName, err)
+	}
+	if template, err = d.updatePodTemplate(template, "rollout", meta); err != nil {
+		return "", err
+	}
+	if err := d.runPreflight(ctx, kubeClient, template); err != nil {
+		return "", err
+	}
+	if rawTemplate, err = runtime.DefaultUnstructuredConverter.ToUnstructured(&template); err != nil {
+		return "", fmt.Errorf("error encoding pod template: %w", err)
+	}
+	if err := unstructured.SetNestedMap(rollout.Object, rawTemplate, "spec", "template"); err != nil {
+		return "", fmt.Errorf("error encoding pod template: %w", err)
+	}
+	labels := rollout.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[StandardVersionLabel] = meta.Version
+	rollout.SetLabels(labels)
+
+	updated, err := rollouts.Update(ctx, rollout, metav1.UpdateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("error deploying app: %w", err)
+	}
+	fmt.Fprintln(stdout, "Updated rollout successfully")
+	return d.generationReference("rollout", curGeneration, updated.GetGeneration()), nil
+}
+
+// generationReference reports the new generation of a workload as the deployment reference
+// If the update did not change the workload, DeployReferenceNoop is returned
+func (d Deployer) generationReference(objType string, curGeneration, updGeneration int64) string {
+	stdout, _ := d.OsWriters.Stdout(), d.OsWriters.Stderr()
+
+	if curGeneration == updGeneration {
+		fmt.Fprintf(stdout, "No changes made to %s.\n", objType)
+		return DeployReferenceNoop
+	}
+	reference := fmt.Sprintf("%d", updGeneration)
+	fmt.Fprintf(stdout, "Created new deployment (generation = %s).\n", reference)
+	return reference
 }
 
 func (d Deployer) deployJob(ctx context.Context, kubeClient *kubernetes.Clientset, meta app.DeployMetadata) error {
@@ -123,6 +261,9 @@ func (d Deployer) updateJobTemplateConfig(ctx context.Context, kubeClient *kuber
 	if err != nil {
 		return fmt.Errorf("cannot find main container %q in spec", d.MainContainerName)
 	}
+	if err := d.runPreflight(ctx, kubeClient, jobDef.Spec.Template); err != nil {
+		return err
+	}
 	if err := UpdateJobDefinition(ctx, kubeClient, d.K8sNamespace, jobDef, configMap); err != nil {
 		return err
 	}
@@ -163,6 +304,41 @@ func (d Deployer) updateCronJobs(ctx context.Context, kubeClient *kubernetes.Cli
 	return nil
 }
 
+// runPreflight verifies the objects referenced by the pod template before the template is deployed
+// Every problem found is printed; the deploy is stopped with a preflight.Error
+// Warnings are printed but don't stop the deploy
+func (d Deployer) runPreflight(ctx context.Context, kubeClient kubernetes.Interface, template corev1.PodTemplateSpec) error {
+	stdout, _ := d.OsWriters.Stdout(), d.OsWriters.Stderr()
+
+	if d.SkipPreflight {
+		fmt.Fprintln(stdout, "Skipping preflight checks")
+		return nil
+	}
+
+	checker := preflight.Checker{Client: kubeClient, Namespace: d.K8sNamespace}
+	result, err := checker.Check(ctx, template)
+	if err != nil {
+		return fmt.Errorf("error running preflight checks: %w", err)
+	}
+	if len(result.Warnings) > 0 {
+		fmt.Fprintf(stdout, "Preflight checks found %d potential problem(s) that may fail the deployment:\n", len(result.Warnings))
+		for _, f := range result.Warnings {
+			fmt.Fprintf(stdout, "  - [%s] %s\n", f.Name, f.Summary)
+			fmt.Fprintf(stdout, "    %s\n", f.Remediation)
+		}
+	}
+	if len(result.Problems) == 0 {
+		fmt.Fprintln(stdout, "Preflight checks passed")
+		return nil
+	}
+	fmt.Fprintf(stdout, "Preflight checks found %d problem(s) that would fail the deployment:\n", len(result.Problems))
+	for _, f := range result.Problems {
+		fmt.Fprintf(stdout, "  - [%s] %s\n", f.Name, f.Summary)
+		fmt.Fprintf(stdout, "    %s\n", f.Remediation)
+	}
+	return preflight.Error{Failures: result.Problems}
+}
+
 func (d Deployer) updatePodTemplate(template corev1.PodTemplateSpec, appType string, meta app.DeployMetadata) (corev1.PodTemplateSpec, error) {
 	stdout, _ := d.OsWriters.Stdout(), d.OsWriters.Stderr()
 
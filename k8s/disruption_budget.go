@@ -0,0 +1,74 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mitchellh/colorstring"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// CheckDisruptionBudget explains why a PodDisruptionBudget will block evictions of the pods it covers
+// Returns "" if the budget allows disruptions
+//
+// Rolling updates delete pods directly, but a rollout that needs room for its new pods relies on evictions
+// (node drains, cluster autoscaler scale-down and consolidation, node upgrades); a budget that allows no disruptions stalls them
+func CheckDisruptionBudget(pdb policyv1.PodDisruptionBudget) string {
+	if mu := pdb.Spec.MaxUnavailable; mu != nil && (mu.String() == "0" || mu.String() == "0%") {
+		return fmt.Sprintf("PodDisruptionBudget %s sets maxUnavailable: %s; evictions of the app's pods will be blocked during node drains and scale-down.", pdb.Name, mu.String())
+	}
+	if pdb.Status.ObservedGeneration < pdb.Generation || pdb.Status.ExpectedPods == 0 || pdb.Status.DisruptionsAllowed > 0 {
+		return ""
+	}
+	if pdb.Status.CurrentHealthy < pdb.Status.DesiredHealthy {
+		return fmt.Sprintf("PodDisruptionBudget %s requires %d healthy pods but only %d are healthy; evictions of the app's pods will be blocked until more pods are ready.",
+			pdb.Name, pdb.Status.DesiredHealthy, pdb.Status.CurrentHealthy)
+	}
+	return fmt.Sprintf("PodDisruptionBudget %s allows no disruptions (%d of %d pods must stay healthy); evictions of the app's pods will be blocked during node drains and scale-down.",
+		pdb.Name, pdb.Status.DesiredHealthy, pdb.Status.ExpectedPods)
+}
+
+// FindDisruptionBudgets returns the PodDisruptionBudgets whose selector matches the given pod labels
+func FindDisruptionBudgets(pdbs []policyv1.PodDisruptionBudget, podLabels map[string]string) []policyv1.PodDisruptionBudget {
+	result := make([]policyv1.PodDisruptionBudget, 0)
+	for _, pdb := range pdbs {
+		// In policy/v1, a nil selector selects no pods and an empty selector selects every pod in the namespace
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			continue
+		}
+		if selector.Matches(labels.Set(podLabels)) {
+			result = append(result, pdb)
+		}
+	}
+	return result
+}
+
+// warnDisruptionBudgets prints a warning for each PodDisruptionBudget covering the workload's pods that will block evictions
+func (w *DeployWatcher) warnDisruptionBudgets(ctx context.Context, workload *workloadSnapshot) {
+	if len(workload.podLabels) == 0 {
+		return
+	}
+	pdbs, err := w.client.PolicyV1().PodDisruptionBudgets(w.AppNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if !apierrors.IsForbidden(err) {
+			fmt.Fprintf(w.OsWriters.Stderr(), "There was an error retrieving pod disruption budgets for app: %s\n", err)
+		}
+		return
+	}
+	for _, pdb := range FindDisruptionBudgets(pdbs.Items, workload.podLabels) {
+		if msg := CheckDisruptionBudget(pdb); msg != "" {
+			colorstring.Fprintln(w.OsWriters.Stdout(), DeployEvent{
+				Timestamp: time.Now(),
+				Type:      EventTypeWarning,
+				Reason:    "DisruptionBudget",
+				Object:    fmt.Sprintf("poddisruptionbudget/%s", pdb.Name),
+				Message:   msg,
+			}.String())
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestCheckDisruptionBudget(t *testing.T) {
+	zero := intstr.FromInt32(0)
+	zeroPercent := intstr.FromString("0%")
+	one := intstr.FromInt32(1)
+	pdb := func(maxUnavailable *intstr.IntOrString, status policyv1.PodDisruptionBudgetStatus) policyv1.PodDisruptionBudget {
+		return policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Generation: 1},
+			Spec:       policyv1.PodDisruptionBudgetSpec{MaxUnavailable: maxUnavailable},
+			Status:     status,
+		}
+	}
+
+	tests := []struct {
+		name string
+		pdb  policyv1.PodDisruptionBudget
+		want string
+	}{
+		{
+			name: "max unavailable zero",
+			pdb:  pdb(&zero, policyv1.PodDisruptionBudgetStatus{ObservedGeneration: 1}),
+			want: "PodDisruptionBudget api sets maxUnavailable: 0; evictions of the app's pods will be blocked during node drains and scale-down.",
+		},
+		{
+			name: "max unavailable zero percent",
+			pdb:  pdb(&zeroPercent, policyv1.PodDisruptionBudgetStatus{ObservedGeneration: 1}),
+			want: "PodDisruptionBudget api sets maxUnavailable: 0%; evictions of the app's pods will be blocked during node drains and scale-down.",
+		},
+		{
+			name: "insufficient healthy pods",
+			pdb: pdb(&one, policyv1.PodDisruptionBudgetStatus{
+				ObservedGeneration: 1, ExpectedPods: 3, DesiredHealthy: 2, CurrentHealthy: 1,
+			}),
+			want: "PodDisruptionBudget api requires 2 healthy pods but only 1 are healthy; evictions of the app's pods will be blocked until more pods are ready.",
+		},
+		{
+			name: "min available equals replicas",
+			pdb: pdb(nil, policyv1.PodDisruptionBudgetStatus{
+				ObservedGeneration: 1, ExpectedPods: 2, DesiredHealthy: 2, CurrentHealthy: 2,
+			}),
+			want: "PodDisruptionBudget api allows no disruptions (2 of 2 pods must stay healthy); evictions of the app's pods will be blocked during node drains and scale-down.",
+		},
+		{
+			name: "disruptions allowed",
+			pdb: pdb(&one, policyv1.PodDisruptionBudgetStatus{
+				ObservedGeneration: 1, ExpectedPods: 3, DesiredHealthy: 2, CurrentHealthy: 3, DisruptionsAllowed: 1,
+			}),
+			want: "",
+		},
+		{
+			name: "not observed",
+			pdb:  pdb(&one, policyv1.PodDisruptionBudgetStatus{ExpectedPods: 3}),
+			want: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, CheckDisruptionBudget(test.pdb))
+		})
+	}
+}
+
+func TestFindDisruptionBudgets(t *testing.T) {
+	pdb := func(name string, selector *metav1.LabelSelector) policyv1.PodDisruptionBudget {
+		return policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       policyv1.PodDisruptionBudgetSpec{Selector: selector},
+		}
+	}
+	pdbs := []policyv1.PodDisruptionBudget{
+		pdb("api", &metav1.LabelSelector{MatchLabels: map[string]string{"nullstone.io/app": "api"}}),
+		pdb("web", &metav1.LabelSelector{MatchLabels: map[string]string{"nullstone.io/app": "web"}}),
+		pdb("everything", &metav1.LabelSelector{}),
+		pdb("nothing", nil),
+	}
+
+	got := FindDisruptionBudgets(pdbs, map[string]string{"nullstone.io/app": "api", "app.kubernetes.io/version": "v1"})
+	names := make([]string, 0)
+	for _, p := range got {
+		names = append(names, p.Name)
+	}
+	assert.Equal(t, []string{"api", "everything"}, names)
+}
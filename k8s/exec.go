@@ -0,0 +1,218 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+const (
+	// DefaultContainerAnnotation is the annotation kubectl uses to pick a pod's default container
+	DefaultContainerAnnotation = "kubectl.kubernetes.io/default-container"
+)
+
+var (
+	ErrNoExecPod = errors.New("no running and ready pod was found for the app")
+)
+
+// ExecTarget selects which pod (and container) to connect to
+// If PodName is empty, a running and ready pod for the app is selected (newest first)
+type ExecTarget struct {
+	PodName string
+	// Container is the name of the container to run the command in
+	// If empty, the pod's default container annotation, the app's main container, or the pod's first container is used
+	Container string
+}
+
+type ExecOptions struct {
+	ExecTarget
+
+	// Command is the command and its arguments to run inside the container (e.g. ["/bin/sh"])
+	Command []string
+
+	Stdin  io.Reader
+	Stdout io.Writer
+	// Stderr is ignored when TTY is true; a terminal merges stderr into stdout
+	Stderr io.Writer
+	TTY    bool
+	// TerminalSizeQueue propagates terminal resizes to the remote pty when TTY is true
+	TerminalSizeQueue remotecommand.TerminalSizeQueue
+}
+
+type ExecResult struct {
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	// ExitCode is the command's exit code when reported by the kubelet; otherwise nil
+	ExitCode *int `json:"exitCode,omitempty"`
+}
+
+// Exec runs a command inside a running pod for the app and streams stdio through the pods/exec subresource
+// The connection uses websockets and falls back to SPDY for clusters that don't support websocket streaming,
+// which removes the need for kubectl and the cloud provider's CLI
+func (a Actioner) Exec(ctx context.Context, options ExecOptions) (*ExecResult, error) {
+	if len(options.Command) == 0 {
+		return nil, fmt.Errorf("exec requires a command")
+	}
+	cfg, err := a.NewConfigFn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error creating kubernetes config: %w", err)
+	}
+	client, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("error creating kube client: %w", err)
+	}
+
+	pod, err := FindExecPod(ctx, client, a.Namespace, a.AppName, options.PodName)
+	if err != nil {
+		return nil, err
+	}
+	container, err := resolveExecContainer(*pod, options.Container, a.MainContainerName)
+	if err != nil {
+		return nil, err
+	}
+
+	stderr := options.Stderr
+	if options.TTY {
+		stderr = nil
+	}
+	req := client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(pod.Namespace).
+		Name(pod.Name).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   options.Command,
+			Stdin:     options.Stdin != nil,
+			Stdout:    options.Stdout != nil,
+			Stderr:    stderr != nil,
+			TTY:       options.TTY,
+		}, scheme.ParameterCodec)
+	executor, err := newExecutor(cfg, req.URL())
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ExecResult{Pod: pod.Name, Container: container}
+	streamOpts := remotecommand.StreamOptions{
+		Stdin:  options.Stdin,
+		Stdout: options.Stdout,
+		Stderr: stderr,
+		Tty:    options.TTY,
+	}
+	if options.TTY {
+		streamOpts.TerminalSizeQueue = options.TerminalSizeQueue
+	}
+	if err := executor.StreamWithContext(ctx, streamOpts); err != nil {
+		var exitErr utilexec.ExitError
+		if errors.As(err, &exitErr) && exitErr.Exited() {
+			code := exitErr.ExitStatus()
+			result.ExitCode = &code
+			return result, nil
+		}
+		return nil, fmt.Errorf("error executing command in pod %q: %w", pod.Name, err)
+	}
+	code := 0
+	result.ExitCode = &code
+	return result, nil
+}
+
+// FindExecPod returns the pod to connect to
+// If podName is empty, the newest running and ready pod labeled for the app is returned
+func FindExecPod(ctx context.Context, client kubernetes.Interface, namespace, appName, podName string) (*corev1.Pod, error) {
+	if podName != "" {
+		pod, err := client.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving pod %q: %w", podName, err)
+		}
+		if pod.Status.Phase != corev1.PodRunning {
+			return nil, fmt.Errorf("pod %q is not running (phase %s)", podName, pod.Status.Phase)
+		}
+		return pod, nil
+	}
+
+	pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("nullstone.io/app=%s", appName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing pods: %w", err)
+	}
+	candidates := make([]corev1.Pod, 0)
+	for _, pod := range pods.Items {
+		if pod.DeletionTimestamp == nil && pod.Status.Phase == corev1.PodRunning && isPodReady(pod) {
+			candidates = append(candidates, pod)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, ErrNoExecPod
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[j].CreationTimestamp.Before(&candidates[i].CreationTimestamp)
+	})
+	return &candidates[0], nil
+}
+
+func resolveExecContainer(pod corev1.Pod, container, mainContainerName string) (string, error) {
+	has := func(name string) bool {
+		for _, c := range pod.Spec.Containers {
+			if c.Name == name {
+				return true
+			}
+		}
+		return false
+	}
+	if container != "" {
+		if !has(container) {
+			return "", fmt.Errorf("container %q does not exist in pod %q", container, pod.Name)
+		}
+		return container, nil
+	}
+	if name := pod.Annotations[DefaultContainerAnnotation]; name != "" && has(name) {
+		return name, nil
+	}
+	if mainContainerName != "" && has(mainContainerName) {
+		return mainContainerName, nil
+	}
+	if len(pod.Spec.Containers) == 0 {
+		return "", fmt.Errorf("pod %q has no containers", pod.Name)
+	}
+	return pod.Spec.Containers[0].Name, nil
+}
+
+func isPodReady(pod corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// newExecutor streams over websockets, falling back to SPDY when the api server (or a proxy) can't upgrade
+func newExecutor(cfg *rest.Config, u *url.URL) (remotecommand.Executor, error) {
+	websocketExec, err := remotecommand.NewWebSocketExecutor(cfg, "GET", u.String())
+	if err != nil {
+		return nil, fmt.Errorf("error creating websocket executor: %w", err)
+	}
+	spdyExec, err := remotecommand.NewSPDYExecutor(cfg, "POST", u)
+	if err != nil {
+		return nil, fmt.Errorf("error creating spdy executor: %w", err)
+	}
+	return remotecommand.NewFallbackExecutor(websocketExec, spdyExec, shouldFallback)
+}
+
+func shouldFallback(err error) bool {
+	return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+}
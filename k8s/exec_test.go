@@ -0,0 +1,126 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestFindExecPod(t *testing.T) {
+	now := time.Now()
+	pod := func(name string, age time.Duration, phase corev1.PodPhase, ready bool) *corev1.Pod {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "ns",
+				Labels:            map[string]string{"nullstone.io/app": "api"},
+				CreationTimestamp: metav1.NewTime(now.Add(-age)),
+			},
+			Status: corev1.PodStatus{
+				Phase:      phase,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		pods    []*corev1.Pod
+		podName string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "newest ready pod",
+			pods: []*corev1.Pod{
+				pod("api-old", time.Hour, corev1.PodRunning, true),
+				pod("api-new", time.Minute, corev1.PodRunning, true),
+				pod("api-starting", time.Second, corev1.PodRunning, false),
+				pod("api-pending", time.Second, corev1.PodPending, false),
+			},
+			want: "api-new",
+		},
+		{
+			name:    "no ready pods",
+			pods:    []*corev1.Pod{pod("api-starting", time.Second, corev1.PodRunning, false)},
+			wantErr: ErrNoExecPod.Error(),
+		},
+		{
+			name:    "explicit pod",
+			pods:    []*corev1.Pod{pod("api-old", time.Hour, corev1.PodRunning, false), pod("api-new", time.Minute, corev1.PodRunning, true)},
+			podName: "api-old",
+			want:    "api-old",
+		},
+		{
+			name:    "explicit pod not running",
+			pods:    []*corev1.Pod{pod("api-done", time.Hour, corev1.PodSucceeded, false)},
+			podName: "api-done",
+			wantErr: `pod "api-done" is not running (phase Succeeded)`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := fake.NewClientset()
+			for _, p := range test.pods {
+				_, err := client.CoreV1().Pods("ns").Create(context.Background(), p, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+			got, err := FindExecPod(context.Background(), client, "ns", "api", test.podName)
+			if test.wantErr != "" {
+				assert.EqualError(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got.Name)
+		})
+	}
+}
+
+func TestResolveExecContainer(t *testing.T) {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-1"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "proxy"}, {Name: "app"}, {Name: "worker"}},
+		},
+	}
+	annotated := *pod.DeepCopy()
+	annotated.Annotations = map[string]string{DefaultContainerAnnotation: "worker"}
+
+	tests := []struct {
+		name          string
+		pod           corev1.Pod
+		container     string
+		mainContainer string
+		want          string
+		wantErr       string
+	}{
+		{name: "explicit", pod: pod, container: "worker", mainContainer: "app", want: "worker"},
+		{name: "explicit missing", pod: pod, container: "db", wantErr: `container "db" does not exist in pod "api-1"`},
+		{name: "default container annotation", pod: annotated, mainContainer: "app", want: "worker"},
+		{name: "main container", pod: pod, mainContainer: "app", want: "app"},
+		{name: "first container", pod: pod, mainContainer: "missing", want: "proxy"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := resolveExecContainer(test.pod, test.container, test.mainContainer)
+			if test.wantErr != "" {
+				assert.EqualError(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
@@ -0,0 +1,271 @@
+package failures
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path"
+	"sync/atomic"
+
+	"sigs.k8s.io/yaml"
+)
+
+//go:embed catalog/*.yaml
+var builtinFS embed.FS
+
+// builtinFiles lists the embedded catalog in evaluation order.
+// Within each file, more specific rules come before their fallbacks.
+var builtinFiles = []string{
+	"providers.yaml",
+	"admission.yaml",
+	"image.yaml",
+	"runtime.yaml",
+	"scheduling.yaml",
+	"storage.yaml",
+	"network.yaml",
+	"node.yaml",
+	"rollout.yaml",
+}
+
+// providerFiles are embedded overlays with discriminators that only apply to a provider's clusters.
+// They are not part of the builtin catalog; see Catalog.WithProvider.
+var providerFiles = map[Provider]string{
+	ProviderGKE: "gke.yaml",
+	ProviderEKS: "eks.yaml",
+}
+
+// Catalog is an ordered list of rules; the first rule that matches an observation classifies it.
+//
+// A catalog file is YAML:
+//
+//	providerHints:
+//	  registry:
+//	    - provider: eks
+//	      message: '\.dkr\.ecr\.'
+//	rules:
+//	  - id: image-pull-not-found
+//	    match:
+//	      source: [waiting]
+//	      reason: [ImagePullBackOff, ErrImagePull]
+//	      message: 'manifest unknown|not found'
+//	    name: ImagePullBackOff/NotFound
+//	    category: image
+//	    providerHints: registry
+//	    summary: Image tag or repository does not exist
+//	    examples:
+//	      - reason: ErrImagePull
+//	        message: manifest unknown
+type Catalog struct {
+	// ProviderHints are named sets of message patterns that tag a failure with a provider
+	ProviderHints map[string][]*ProviderHint `json:"providerHints,omitempty"`
+	Rules         []*Rule                    `json:"rules"`
+}
+
+var defaultCatalog atomic.Pointer[Catalog]
+
+func init() {
+	c, err := Builtin()
+	if err != nil {
+		panic(fmt.Sprintf("invalid builtin failure catalog: %s", err))
+	}
+	defaultCatalog.Store(c)
+}
+
+// Default returns the catalog used by the package-level Classify functions.
+func Default() *Catalog {
+	return defaultCatalog.Load()
+}
+
+// SetDefault replaces the catalog used by the package-level Classify functions.
+// Use LoadCatalog to layer organization-specific rules on top of the builtin catalog.
+func SetDefault(c *Catalog) {
+	defaultCatalog.Store(c)
+}
+
+// Builtin parses the catalog embedded in this package.
+func Builtin() (*Catalog, error) {
+	result := &Catalog{}
+	ids := map[string]string{}
+	for _, name := range builtinFiles {
+		data, err := builtinFS.ReadFile(path.Join("catalog", name))
+		if err != nil {
+			return nil, err
+		}
+		c, err := ParseCatalog(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		for _, rule := range c.Rules {
+			if other, ok := ids[rule.ID]; ok {
+				return nil, fmt.Errorf("%s: rule id %q is already defined in %s", name, rule.ID, other)
+			}
+			ids[rule.ID] = name
+		}
+		result = result.concat(c)
+	}
+	if err := result.validateHints(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// LoadCatalog returns the builtin catalog layered with each rule file in order (see Catalog.Layer).
+func LoadCatalog(files ...string) (*Catalog, error) {
+	c, err := Builtin()
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("error reading failure rules: %w", err)
+		}
+		overlay, err := ParseCatalog(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		if c, err = c.Layer(overlay); err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+	}
+	return c, nil
+}
+
+// WithProvider returns the catalog layered with the embedded discriminators for a provider (see Catalog.Layer).
+// Provider deploy watchers use this so that failures specific to their clusters (e.g. GKE Autopilot, EKS Fargate)
+// are classified ahead of the generic rules. Providers without discriminators return c unchanged.
+func (c *Catalog) WithProvider(provider Provider) (*Catalog, error) {
+	name, ok := providerFiles[provider]
+	if !ok {
+		return c, nil
+	}
+	data, err := builtinFS.ReadFile(path.Join("catalog", name))
+	if err != nil {
+		return nil, err
+	}
+	overlay, err := ParseCatalog(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return c.Layer(overlay)
+}
+
+// ParseCatalog parses and validates a YAML (or JSON) catalog.
+func ParseCatalog(data []byte) (*Catalog, error) {
+	c := &Catalog{}
+	if err := yaml.UnmarshalStrict(data, c); err != nil {
+		return nil, fmt.Errorf("invalid failure catalog: %w", err)
+	}
+	for name, hints := range c.ProviderHints {
+		for _, hint := range hints {
+			if hint.Message == "" {
+				return nil, fmt.Errorf("provider hint %q is missing a message pattern", name)
+			}
+			var err error
+			if hint.message, err = compilePattern(hint.Message); err != nil {
+				return nil, fmt.Errorf("provider hint %q has an invalid message pattern: %w", name, err)
+			}
+		}
+	}
+	ids := map[string]bool{}
+	for _, rule := range c.Rules {
+		if err := rule.compile(); err != nil {
+			return nil, err
+		}
+		if ids[rule.ID] {
+			return nil, fmt.Errorf("duplicate rule id %q", rule.ID)
+		}
+		ids[rule.ID] = true
+	}
+	return c, nil
+}
+
+// Layer returns a new catalog with overlay's rules taking precedence over c's.
+// An overlay rule with the same ID as an existing rule replaces it in place (or removes it when `disabled: true`);
+// all other overlay rules are evaluated before the existing rules.
+// Overlay provider hints are evaluated before existing hints of the same name.
+func (c *Catalog) Layer(overlay *Catalog) (*Catalog, error) {
+	replaced := map[string]*Rule{}
+	for _, rule := range overlay.Rules {
+		replaced[rule.ID] = rule
+	}
+
+	result := &Catalog{ProviderHints: map[string][]*ProviderHint{}}
+	for name, hints := range c.ProviderHints {
+		result.ProviderHints[name] = hints
+	}
+	for name, hints := range overlay.ProviderHints {
+		result.ProviderHints[name] = append(append([]*ProviderHint{}, hints...), result.ProviderHints[name]...)
+	}
+
+	var base []*Rule
+	for _, rule := range c.Rules {
+		if r, ok := replaced[rule.ID]; ok {
+			delete(replaced, rule.ID)
+			if r.Disabled {
+				continue
+			}
+			rule = r
+		}
+		base = append(base, rule)
+	}
+	for _, rule := range overlay.Rules {
+		if _, ok := replaced[rule.ID]; !ok {
+			continue
+		}
+		if rule.Disabled {
+			return nil, fmt.Errorf("rule %q disables a rule that does not exist", rule.ID)
+		}
+		result.Rules = append(result.Rules, rule)
+	}
+	result.Rules = append(result.Rules, base...)
+	if err := result.validateHints(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// concat appends other's rules after c's; used to assemble the builtin catalog from its files
+func (c *Catalog) concat(other *Catalog) *Catalog {
+	result := &Catalog{ProviderHints: map[string][]*ProviderHint{}}
+	for _, src := range []*Catalog{c, other} {
+		for name, hints := range src.ProviderHints {
+			result.ProviderHints[name] = append(result.ProviderHints[name], hints...)
+		}
+		result.Rules = append(result.Rules, src.Rules...)
+	}
+	return result
+}
+
+func (c *Catalog) validateHints() error {
+	for _, rule := range c.Rules {
+		if rule.ProviderHints == "" {
+			continue
+		}
+		if _, ok := c.ProviderHints[rule.ProviderHints]; !ok {
+			return fmt.Errorf("rule %q references unknown provider hints %q", rule.ID, rule.ProviderHints)
+		}
+	}
+	return nil
+}
+
+// Classify returns the Failure for an observation, or nil if no rule matches.
+func (c *Catalog) Classify(obs Observation, obj ObjectRef) *Failure {
+	rule, groups := c.find(obs)
+	if rule == nil {
+		return nil
+	}
+	return rule.apply(obs, groups, obj, c.ProviderHints[rule.ProviderHints])
+}
+
+func (c *Catalog) find(obs Observation) (*Rule, map[string]string) {
+	for _, rule := range c.Rules {
+		if rule.Disabled {
+			continue
+		}
+		if groups, ok := rule.match(obs); ok {
+			return rule, groups
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,205 @@
+package failures
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestBuiltin_Verify(t *testing.T) {
+	c, err := Builtin()
+	require.NoError(t, err)
+	for _, err := range c.Verify() {
+		t.Error(err)
+	}
+	for _, rule := range c.Rules {
+		assert.NotEmpty(t, rule.Examples, "rule %q has no examples", rule.ID)
+	}
+}
+
+func TestCatalog_WithProvider(t *testing.T) {
+	for provider := range providerFiles {
+		t.Run(string(provider), func(t *testing.T) {
+			c, err := Default().WithProvider(provider)
+			require.NoError(t, err)
+			for _, err := range c.Verify() {
+				t.Error(err)
+			}
+			assert.Greater(t, len(c.Rules), len(Default().Rules))
+		})
+	}
+
+	t.Run("provider without discriminators", func(t *testing.T) {
+		c, err := Default().WithProvider(ProviderAKS)
+		require.NoError(t, err)
+		assert.Same(t, Default(), c)
+	})
+
+	t.Run("discriminators only apply to their provider", func(t *testing.T) {
+		ev := corev1.Event{
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: "ns", Name: "api-1"},
+			Reason:         "FailedScheduling",
+			Message:        "Pod not supported on Fargate: fields not supported: HostNetwork",
+		}
+		eks, err := Default().WithProvider(ProviderEKS)
+		require.NoError(t, err)
+		got := eks.ClassifyEvent(ev)
+		require.NotNil(t, got)
+		assert.Equal(t, "FailedScheduling/FargateUnsupported", got.Name)
+		assert.Equal(t, "Pod uses a feature Fargate does not support: fields not supported: HostNetwork", got.Summary)
+		assert.Equal(t, ProviderEKS, got.Provider)
+
+		gke, err := Default().WithProvider(ProviderGKE)
+		require.NoError(t, err)
+		got = gke.ClassifyEvent(ev)
+		require.NotNil(t, got)
+		assert.Equal(t, "FailedScheduling", got.Name)
+	})
+}
+
+func TestParseCatalog_Invalid(t *testing.T) {
+	cases := map[string]string{
+		"missing id":       "rules: [{name: X, category: image, summary: s, match: {source: [event]}}]",
+		"unknown source":   "rules: [{id: x, name: X, category: image, summary: s, match: {source: [node]}}]",
+		"unknown category": "rules: [{id: x, name: X, category: security, summary: s, match: {source: [event]}}]",
+		"unknown provider": "rules: [{id: x, name: X, category: image, provider: ibm, summary: s, match: {source: [event]}}]",
+		"invalid pattern":  "rules: [{id: x, name: X, category: image, summary: s, match: {source: [event], message: '('}}]",
+		"invalid template": "rules: [{id: x, name: '{{.Reason', category: image, summary: s, match: {source: [event]}}]",
+		"unknown field":    "rules: [{id: x, name: X, category: image, summary: s, match: {source: [event], reasons: [A]}}]",
+		"duplicate id": `rules:
+  - {id: x, name: X, category: image, summary: s, match: {source: [event]}}
+  - {id: x, name: Y, category: image, summary: s, match: {source: [event]}}`,
+	}
+	for name, data := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseCatalog([]byte(data))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLoadCatalog(t *testing.T) {
+	overlay := `
+providerHints:
+  registry:
+    - provider: gke
+      message: 'registry\.internal\.example\.com'
+rules:
+  # organization-specific admission webhook
+  - id: acme-image-signature
+    match:
+      source: [event, condition]
+      reason: [FailedCreate]
+      message: 'admission webhook "signatures\.acme\.io" denied the request: image (?P<image>\S+) is not signed'
+    name: AdmissionWebhookDenied/ImageSignature
+    category: admission
+    summary: 'Image {{.Groups.image}} is not signed'
+    remediation: Sign the image in the release pipeline.
+    docs: ["https://runbooks.acme.io/k8s/image-signature"]
+    examples:
+      - reason: FailedCreate
+        message: 'admission webhook "signatures.acme.io" denied the request: image api:v1.2.3 is not signed'
+  # link our runbook for quota failures
+  - id: admission-resource-quota
+    match:
+      source: [event, condition]
+      reason: [FailedCreate]
+      message: 'exceeded quota'
+    name: ResourceQuotaExceeded
+    category: admission
+    summary: Pod creation exceeded a ResourceQuota in the namespace
+    remediation: 'File a quota request: https://runbooks.acme.io/k8s/quota'
+    examples:
+      - reason: FailedCreate
+        message: 'exceeded quota: compute'
+  - id: volume-resize
+    disabled: true
+`
+	file := filepath.Join(t.TempDir(), "acme.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(overlay), 0644))
+
+	c, err := LoadCatalog(file)
+	require.NoError(t, err)
+	assert.Empty(t, c.Verify())
+
+	event := func(reason, msg string) corev1.Event {
+		return corev1.Event{
+			InvolvedObject: corev1.ObjectReference{Kind: "ReplicaSet", Namespace: "ns", Name: "api-6b8f"},
+			Reason:         reason,
+			Message:        msg,
+		}
+	}
+
+	t.Run("new rules take precedence", func(t *testing.T) {
+		got := c.ClassifyEvent(event("FailedCreate", `admission webhook "signatures.acme.io" denied the request: image api:v1.2.3 is not signed`))
+		require.NotNil(t, got)
+		assert.Equal(t, "AdmissionWebhookDenied/ImageSignature", got.Name)
+		assert.Equal(t, "Image api:v1.2.3 is not signed", got.Summary)
+		assert.Equal(t, []string{"https://runbooks.acme.io/k8s/image-signature"}, got.Docs)
+	})
+
+	t.Run("rules with the same id are replaced", func(t *testing.T) {
+		got := c.ClassifyEvent(event("FailedCreate", "exceeded quota: compute"))
+		require.NotNil(t, got)
+		assert.Equal(t, "ResourceQuotaExceeded", got.Name)
+		assert.Equal(t, "File a quota request: https://runbooks.acme.io/k8s/quota", got.Remediation)
+	})
+
+	t.Run("disabled rules are removed", func(t *testing.T) {
+		assert.Nil(t, c.ClassifyEvent(event("VolumeResizeFailed", "resize failed")))
+	})
+
+	t.Run("provider hints are layered", func(t *testing.T) {
+		pod := corev1.Pod{}
+		status := corev1.ContainerStatus{Name: "app"}
+		status.State.Waiting = &corev1.ContainerStateWaiting{Reason: "ErrImagePull", Message: "registry.internal.example.com/api:v1: not found"}
+		got := c.ClassifyContainer(pod, status)
+		require.NotNil(t, got)
+		assert.Equal(t, ProviderGKE, got.Provider)
+
+		status.State.Waiting.Message = "1234.dkr.ecr.us-east-1.amazonaws.com/api:v1: not found"
+		got = c.ClassifyContainer(pod, status)
+		require.NotNil(t, got)
+		assert.Equal(t, ProviderEKS, got.Provider)
+	})
+
+	t.Run("builtin catalog is unchanged", func(t *testing.T) {
+		got := ClassifyEvent(event("FailedCreate", "exceeded quota: compute"))
+		require.NotNil(t, got)
+		assert.Equal(t, "Raise the quota, reduce requests, or set defaults via LimitRange.", got.Remediation)
+	})
+}
+
+func TestCatalog_Layer_DisableUnknown(t *testing.T) {
+	overlay, err := ParseCatalog([]byte("rules: [{id: not-a-rule, disabled: true}]"))
+	require.NoError(t, err)
+	_, err = Default().Layer(overlay)
+	assert.Error(t, err)
+}
+
+func TestCatalog_Verify_Shadowed(t *testing.T) {
+	c, err := ParseCatalog([]byte(`
+rules:
+  - id: generic
+    match: {source: [event], reason: [BackOff]}
+    name: BackOff
+    category: runtime
+    summary: Back-off restarting failed container
+  - id: specific
+    match: {source: [event], reason: [BackOff], message: 'pulling image'}
+    name: BackOff/Image
+    category: image
+    summary: Back-off pulling image
+    examples:
+      - reason: BackOff
+        message: 'Back-off pulling image "api:v1"'
+`))
+	require.NoError(t, err)
+	errs := c.Verify()
+	require.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], `rule "specific" example 1: classified by rule "generic"`)
+}
@@ -3,78 +3,220 @@ package failures
 import (
 	"strings"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 )
 
-// ClassifyContainer inspects a single container's status against the catalog.
+// ClassifyContainer inspects a single container's status against the default catalog.
 // Returns nil when the container is healthy (no waiting failure, no terminated
-// failure). The dispatch order is:
-//
-//  1. Image-related waiting reasons (cheapest; no message regexes)
-//  2. Runtime / lifecycle (CrashLoopBackOff, OOMKilled, Create/RunContainerError, Terminated)
+// failure).
 //
 // The pod argument is used only to populate ObjectRef.Namespace/Name and to
 // allow future cross-checks; pass corev1.Pod{} if you only have a status.
 func ClassifyContainer(pod corev1.Pod, status corev1.ContainerStatus) *Failure {
+	return Default().ClassifyContainer(pod, status)
+}
+
+// ClassifyPod returns failures derived from pod-level state — scheduling,
+// eviction, disruption. It does NOT recurse into containers; call
+// ClassifyContainer per container alongside this.
+func ClassifyPod(pod corev1.Pod) []Failure {
+	return Default().ClassifyPod(pod)
+}
+
+// ClassifyEvent maps a single corev1.Event to a Failure. Returns nil for events
+// that no rule in the default catalog matches (callers can still log the raw event).
+func ClassifyEvent(ev corev1.Event) *Failure {
+	return Default().ClassifyEvent(ev)
+}
+
+// ClassifyDeployment surfaces §7 (Rollout) signals from a Deployment.
+// Returns 0..N failures; in practice a Deployment can carry both
+// ProgressDeadlineExceeded and ReplicaFailure simultaneously.
+func ClassifyDeployment(d appsv1.Deployment) []Failure {
+	return Default().ClassifyDeployment(d)
+}
+
+func (c *Catalog) ClassifyContainer(pod corev1.Pod, status corev1.ContainerStatus) *Failure {
+	return c.ClassifyContainerOnNode(pod, status, nil)
+}
+
+// ClassifyContainerOnNode is ClassifyContainer for a pod whose node is known.
+// The node's labels are available to rules that discriminate on the node (e.g. Fargate, GKE Sandbox); node may be nil.
+func (c *Catalog) ClassifyContainerOnNode(pod corev1.Pod, status corev1.ContainerStatus, node *corev1.Node) *Failure {
 	obj := ObjectRef{
 		Kind:      "Pod",
 		Namespace: pod.Namespace,
 		Name:      pod.Name,
 		Container: status.Name,
 	}
-	if f := classifyImage(obj, status); f != nil {
-		return f
-	}
-	if f := classifyRuntime(obj, status); f != nil {
-		return f
+	if obs := containerObservation(status); obs != nil {
+		obs.NodeLabels = nodeLabels(node)
+		return c.Classify(*obs, obj)
 	}
 	return nil
 }
 
-// ClassifyPod returns failures derived from pod-level state — scheduling,
-// eviction, disruption. It does NOT recurse into containers; call
-// ClassifyContainer per container alongside this.
-func ClassifyPod(pod corev1.Pod) []Failure {
+func (c *Catalog) ClassifyPod(pod corev1.Pod) []Failure {
+	return c.ClassifyPodOnNode(pod, nil)
+}
+
+// ClassifyPodOnNode is ClassifyPod for a pod whose node is known; node may be nil.
+func (c *Catalog) ClassifyPodOnNode(pod corev1.Pod, node *corev1.Node) []Failure {
 	obj := ObjectRef{Kind: "Pod", Namespace: pod.Namespace, Name: pod.Name}
+	labels := nodeLabels(node)
+	classify := func(obs Observation) *Failure {
+		obs.NodeLabels = labels
+		return c.Classify(obs, obj)
+	}
 	var out []Failure
-	if f := classifyPending(obj, pod); f != nil {
-		out = append(out, *f)
+	if pod.Status.Phase == corev1.PodPending {
+		for _, cond := range pod.Status.Conditions {
+			if cond.Type != corev1.PodScheduled || cond.Status == corev1.ConditionTrue {
+				continue
+			}
+			if f := classify(podConditionObservation(cond)); f != nil {
+				out = append(out, *f)
+				break
+			}
+		}
 	}
-	if f := classifyNode(obj, pod); f != nil {
-		out = append(out, *f)
+	// Eviction and disruption describe the same node-level outcome; report only the first
+	for _, obs := range nodeObservations(pod) {
+		if f := classify(obs); f != nil {
+			out = append(out, *f)
+			break
+		}
+	}
+	for _, obs := range readinessGateObservations(pod) {
+		if f := classify(obs); f != nil {
+			out = append(out, *f)
+		}
 	}
 	return out
 }
 
-// ClassifyEvent maps a single corev1.Event to a Failure. Returns nil for events
-// whose reason isn't in the catalog (callers can still log the raw event).
-//
-// The dispatch is by reason because the event API namespaces failures that way;
-// message-based sub-classification happens inside the per-category function.
-func ClassifyEvent(ev corev1.Event) *Failure {
+func (c *Catalog) ClassifyEvent(ev corev1.Event) *Failure {
 	obj := ObjectRef{
 		Kind:      ev.InvolvedObject.Kind,
 		Namespace: ev.InvolvedObject.Namespace,
 		Name:      ev.InvolvedObject.Name,
 	}
-	switch ev.Reason {
-	case "FailedMount", "FailedAttachVolume", "ProvisioningFailed", "FailedBinding", "VolumeResizeFailed":
-		return setObserved(classifyVolumeEvent(obj, ev), ev)
-	case "FailedCreatePodSandBox", "SyncLoadBalancerFailed", "CreatingLoadBalancerFailed", "Unhealthy":
-		return setObserved(classifyNetworkEvent(obj, ev), ev)
-	case "FailedCreate":
-		return setObserved(classifyAdmissionEvent(obj, ev), ev)
-	case "FailedScheduling":
-		// Equivalent to PodScheduled=False:Unschedulable on the pod, but emitted
-		// as an event by the scheduler. Reuse the same message classifier.
-		return setObserved(classifySchedulingMessage(obj, ev.Message), ev)
-	case "Evicted":
-		// Pod-level eviction also fires an event; reuse the node classifier
-		// against a synthesized pod with status.reason=Evicted.
-		fake := corev1.Pod{Status: corev1.PodStatus{Reason: "Evicted", Message: ev.Message}}
-		return setObserved(classifyNode(obj, fake), ev)
+	obs := Observation{
+		Source:  SourceEvent,
+		Kind:    ev.InvolvedObject.Kind,
+		Reason:  ev.Reason,
+		Message: ev.Message,
 	}
-	return nil
+	return setObserved(c.Classify(obs, obj), ev)
+}
+
+func (c *Catalog) ClassifyDeployment(d appsv1.Deployment) []Failure {
+	obj := ObjectRef{Kind: "Deployment", Namespace: d.Namespace, Name: d.Name}
+	var out []Failure
+	for _, cond := range d.Status.Conditions {
+		unhealthy := (cond.Type == appsv1.DeploymentProgressing && cond.Status == corev1.ConditionFalse) ||
+			(cond.Type == appsv1.DeploymentReplicaFailure && cond.Status == corev1.ConditionTrue)
+		if !unhealthy {
+			continue
+		}
+		obs := Observation{
+			Source:          SourceCondition,
+			Kind:            "Deployment",
+			ConditionType:   string(cond.Type),
+			ConditionStatus: string(cond.Status),
+			Reason:          cond.Reason,
+			Message:         cond.Message,
+		}
+		if f := c.Classify(obs, obj); f != nil {
+			f.ObservedAt = cond.LastTransitionTime.Time
+			out = append(out, *f)
+		}
+	}
+	return out
+}
+
+// containerObservation normalizes a container status; returns nil for running or successfully completed containers
+func containerObservation(status corev1.ContainerStatus) *Observation {
+	if t := status.State.Terminated; t != nil {
+		if t.Reason == "Completed" || (t.ExitCode == 0 && t.Reason == "") {
+			return nil
+		}
+		exit := t.ExitCode
+		return &Observation{Source: SourceTerminated, Kind: "Pod", Reason: t.Reason, Message: t.Message, ExitCode: &exit}
+	}
+	w := status.State.Waiting
+	if w == nil {
+		return nil
+	}
+	obs := &Observation{Source: SourceWaiting, Kind: "Pod", Reason: w.Reason, Message: w.Message}
+	if last := status.LastTerminationState.Terminated; last != nil {
+		obs.LastTermination = &Termination{Reason: last.Reason, Message: last.Message, ExitCode: last.ExitCode}
+	}
+	return obs
+}
+
+func podConditionObservation(cond corev1.PodCondition) Observation {
+	return Observation{
+		Source:          SourceCondition,
+		Kind:            "Pod",
+		ConditionType:   string(cond.Type),
+		ConditionStatus: string(cond.Status),
+		Reason:          cond.Reason,
+		Message:         cond.Message,
+	}
+}
+
+// nodeObservations returns the pod's eviction status followed by its DisruptionTarget condition
+func nodeObservations(pod corev1.Pod) []Observation {
+	var out []Observation
+	reason := pod.Status.Reason
+	if reason == "" && strings.Contains(strings.ToLower(pod.Status.Message), "the node was low on resource") {
+		// Some kubelets only report the pressure message; treat it as an eviction
+		reason = "Evicted"
+	}
+	if reason != "" {
+		out = append(out, Observation{Source: SourcePod, Kind: "Pod", Reason: reason, Message: pod.Status.Message})
+	}
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.DisruptionTarget && cond.Status == corev1.ConditionTrue {
+			out = append(out, podConditionObservation(cond))
+		}
+	}
+	return out
+}
+
+// readinessGateObservations returns an observation for each readiness gate that a running pod does not pass yet
+// A gate whose condition has not been reported by its controller is observed with status Unknown
+func readinessGateObservations(pod corev1.Pod) []Observation {
+	if pod.Status.Phase != corev1.PodRunning {
+		return nil
+	}
+	var out []Observation
+	for _, gate := range pod.Spec.ReadinessGates {
+		obs := Observation{
+			Source:          SourceCondition,
+			Kind:            "Pod",
+			ConditionType:   string(gate.ConditionType),
+			ConditionStatus: string(corev1.ConditionUnknown),
+		}
+		for _, cond := range pod.Status.Conditions {
+			if cond.Type == gate.ConditionType {
+				obs = podConditionObservation(cond)
+			}
+		}
+		if obs.ConditionStatus != string(corev1.ConditionTrue) {
+			out = append(out, obs)
+		}
+	}
+	return out
+}
+
+func nodeLabels(node *corev1.Node) map[string]string {
+	if node == nil {
+		return nil
+	}
+	return node.Labels
 }
 
 // setObserved populates Failure.ObservedAt from the event's most recent timestamp.
@@ -90,14 +232,3 @@ func setObserved(f *Failure, ev corev1.Event) *Failure {
 	}
 	return f
 }
-
-// containsAny reports whether s contains any of the given substrings.
-// Used by message-based classifiers to keep regex-free, allocation-light dispatch.
-func containsAny(s string, subs ...string) bool {
-	for _, sub := range subs {
-		if strings.Contains(s, sub) {
-			return true
-		}
-	}
-	return false
-}
@@ -272,6 +272,72 @@ func TestClassifyPod_NodePressure(t *testing.T) {
 	assert.Equal(t, CategoryNode, got[0].Category)
 }
 
+func TestClassifyOnNode(t *testing.T) {
+	eks, err := Default().WithProvider(ProviderEKS)
+	require.NoError(t, err)
+	gke, err := Default().WithProvider(ProviderGKE)
+	require.NoError(t, err)
+	node := func(labels map[string]string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n", Labels: labels}}
+	}
+	pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "p"}}
+	oom := containerStatus("app", nil, &corev1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137}, nil)
+
+	t.Run("fargate node", func(t *testing.T) {
+		got := eks.ClassifyContainerOnNode(pod, oom, node(map[string]string{"eks.amazonaws.com/compute-type": "fargate"}))
+		require.NotNil(t, got)
+		assert.Equal(t, "OOMKilled/Fargate", got.Name)
+		assert.Equal(t, ProviderEKS, got.Provider)
+	})
+	t.Run("ec2 node", func(t *testing.T) {
+		got := eks.ClassifyContainerOnNode(pod, oom, node(map[string]string{"eks.amazonaws.com/compute-type": "ec2"}))
+		require.NotNil(t, got)
+		assert.Equal(t, "OOMKilled", got.Name)
+	})
+	t.Run("unknown node", func(t *testing.T) {
+		got := eks.ClassifyContainer(pod, oom)
+		require.NotNil(t, got)
+		assert.Equal(t, "OOMKilled", got.Name)
+	})
+	t.Run("label presence", func(t *testing.T) {
+		status := containerStatus("app", &corev1.ContainerStateWaiting{Reason: "RunContainerError", Message: "unsupported syscall"}, nil, nil)
+		got := gke.ClassifyContainerOnNode(pod, status, node(map[string]string{"sandbox.gke.io/runtime": "gvisor"}))
+		require.NotNil(t, got)
+		assert.Equal(t, "RunContainerError/GKESandbox", got.Name)
+	})
+}
+
+func TestClassifyPod_ReadinessGates(t *testing.T) {
+	gke, err := Default().WithProvider(ProviderGKE)
+	require.NoError(t, err)
+	negGate := corev1.PodConditionType("cloud.google.com/load-balancer-neg-ready")
+	pod := func(phase corev1.PodPhase, conditions ...corev1.PodCondition) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "p"},
+			Spec:       corev1.PodSpec{ReadinessGates: []corev1.PodReadinessGate{{ConditionType: negGate}}},
+			Status:     corev1.PodStatus{Phase: phase, Conditions: conditions},
+		}
+	}
+
+	t.Run("gate not passed", func(t *testing.T) {
+		got := gke.ClassifyPod(pod(corev1.PodRunning, corev1.PodCondition{Type: negGate, Status: corev1.ConditionFalse}))
+		require.Len(t, got, 1)
+		assert.Equal(t, "ReadinessGate/NEG", got[0].Name)
+		assert.Equal(t, "cloud.google.com/load-balancer-neg-ready=False:", got[0].Signals.Condition)
+	})
+	t.Run("gate not reported", func(t *testing.T) {
+		got := gke.ClassifyPod(pod(corev1.PodRunning))
+		require.Len(t, got, 1)
+		assert.Equal(t, "cloud.google.com/load-balancer-neg-ready=Unknown:", got[0].Signals.Condition)
+	})
+	t.Run("gate passed", func(t *testing.T) {
+		assert.Empty(t, gke.ClassifyPod(pod(corev1.PodRunning, corev1.PodCondition{Type: negGate, Status: corev1.ConditionTrue})))
+	})
+	t.Run("generic catalog", func(t *testing.T) {
+		assert.Empty(t, ClassifyPod(pod(corev1.PodRunning)))
+	})
+}
+
 func TestClassifyEvent(t *testing.T) {
 	makeEvent := func(reason, msg string) corev1.Event {
 		return corev1.Event{
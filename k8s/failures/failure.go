@@ -2,6 +2,9 @@
 // catalog defined in k8s/failure-modes.md. All classifiers are pure: they take
 // typed snapshots (ContainerStatus, Pod, Event, Deployment) and return a
 // structured Failure record. No API calls.
+//
+// The catalog itself is data: the rules in catalog/*.yaml are embedded in the
+// package and can be layered with organization-specific rules (see LoadCatalog).
 package failures
 
 import (
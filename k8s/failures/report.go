@@ -0,0 +1,148 @@
+package failures
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// categoryRank orders categories from most to least likely to be a root cause.
+// Admission/image/storage/scheduling failures prevent a pod from ever running,
+// so they outrank runtime crashes, which in turn explain most probe failures.
+// Rollout failures (ProgressDeadlineExceeded) are almost always a symptom.
+var categoryRank = map[Category]int{
+	CategoryAdmission:  0,
+	CategoryImage:      1,
+	CategoryStorage:    2,
+	CategoryScheduling: 3,
+	CategoryRuntime:    4,
+	CategoryNetwork:    5,
+	CategoryNode:       6,
+	CategoryRollout:    7,
+}
+
+// RootCause is a single failure deduplicated across every object it was observed on.
+type RootCause struct {
+	// Failure is the first observation; it carries the summary, remediation, and evidence.
+	Failure Failure `json:"failure"`
+	// Pods lists the distinct pods that reported this failure (in observation order).
+	Pods []string `json:"pods,omitempty"`
+	// Occurrences counts every observation (events and status snapshots).
+	Occurrences int `json:"occurrences"`
+}
+
+// Describe renders the root cause with the number of affected pods out of totalPods
+// (e.g. "ImagePullBackOff/NotFound on 3/3 new pods: Image tag or repository does not exist")
+func (rc RootCause) Describe(totalPods int) string {
+	subject := rc.Failure.Name
+	if len(rc.Pods) > 0 && totalPods > 0 {
+		subject = fmt.Sprintf("%s on %d/%d new pods", subject, len(rc.Pods), totalPods)
+	} else if rc.Failure.Object.Kind != "" && rc.Failure.Object.Kind != "Pod" {
+		subject = fmt.Sprintf("%s on %s/%s", subject, strings.ToLower(rc.Failure.Object.Kind), rc.Failure.Object.Name)
+	}
+	return fmt.Sprintf("%s: %s", subject, rc.Failure.Summary)
+}
+
+// Report accumulates the failures observed during a rollout and ranks them by likely root cause.
+// A Report is not safe for concurrent use.
+type Report struct {
+	causes map[string]*RootCause
+	order  []string
+}
+
+func NewReport() *Report {
+	return &Report{causes: map[string]*RootCause{}}
+}
+
+// Add records an observation; failures with the same key (see causeKey) are merged so that
+// the same failure across N pods becomes one RootCause with N affected pods.
+func (r *Report) Add(f Failure) {
+	key := causeKey(f)
+	rc, ok := r.causes[key]
+	if !ok {
+		rc = &RootCause{Failure: f}
+		r.causes[key] = rc
+		r.order = append(r.order, key)
+	}
+	rc.Occurrences++
+	if rc.Failure.Object.Container == "" && f.Object.Container != "" {
+		// Container status snapshots are more specific than pod events
+		rc.Failure.Object.Container = f.Object.Container
+	}
+	if f.Object.Kind == "Pod" && f.Object.Name != "" && !containsString(rc.Pods, f.Object.Name) {
+		rc.Pods = append(rc.Pods, f.Object.Name)
+	}
+}
+
+// causeKey identifies a distinct failure by its name and summary, which is rendered with the groups the rule captured.
+// Pods are left out so that the same failure on different pods is merged; any other object is included
+// so that, for example, two missing Secrets stay separate root causes.
+func causeKey(f Failure) string {
+	key := f.Name + "\x00" + f.Summary
+	if f.Object.Kind != "" && f.Object.Kind != "Pod" {
+		key += "\x00" + f.Object.Kind + "/" + f.Object.Namespace + "/" + f.Object.Name
+	}
+	return key
+}
+
+// AddAll records each failure in fs.
+func (r *Report) AddAll(fs []Failure) {
+	for _, f := range fs {
+		r.Add(f)
+	}
+}
+
+// Len returns the number of distinct failures in the report.
+func (r *Report) Len() int {
+	return len(r.causes)
+}
+
+// Pods returns the distinct pods that reported any failure.
+func (r *Report) Pods() []string {
+	var pods []string
+	for _, key := range r.order {
+		for _, pod := range r.causes[key].Pods {
+			if !containsString(pods, pod) {
+				pods = append(pods, pod)
+			}
+		}
+	}
+	return pods
+}
+
+// RootCauses returns the deduplicated failures, most likely root cause first.
+// Ranking is by category (see categoryRank), then by affected pods, then by occurrences.
+// Ties keep observation order.
+func (r *Report) RootCauses() []RootCause {
+	result := make([]RootCause, 0, len(r.order))
+	for _, key := range r.order {
+		result = append(result, *r.causes[key])
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if ra, rb := rank(a.Failure.Category), rank(b.Failure.Category); ra != rb {
+			return ra < rb
+		}
+		if len(a.Pods) != len(b.Pods) {
+			return len(a.Pods) > len(b.Pods)
+		}
+		return a.Occurrences > b.Occurrences
+	})
+	return result
+}
+
+func rank(c Category) int {
+	if r, ok := categoryRank[c]; ok {
+		return r
+	}
+	return len(categoryRank)
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
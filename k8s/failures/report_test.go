@@ -0,0 +1,84 @@
+package failures
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReport_RootCauses(t *testing.T) {
+	podFailure := func(name string, category Category, pod, container string) Failure {
+		return Failure{
+			Name:     name,
+			Category: category,
+			Summary:  name + " summary",
+			Object:   ObjectRef{Kind: "Pod", Namespace: "default", Name: pod, Container: container},
+		}
+	}
+	deadline := Failure{
+		Name:     "ProgressDeadlineExceeded",
+		Category: CategoryRollout,
+		Summary:  "Deployment exceeded its progressDeadlineSeconds without completing",
+		Object:   ObjectRef{Kind: "Deployment", Namespace: "default", Name: "api"},
+	}
+
+	report := NewReport()
+	report.Add(deadline)
+	report.Add(podFailure("ReadinessProbeFailed", CategoryNetwork, "api-1", ""))
+	report.Add(podFailure("ReadinessProbeFailed", CategoryNetwork, "api-2", ""))
+	report.Add(podFailure("CrashLoopBackOff", CategoryRuntime, "api-1", ""))
+	report.Add(podFailure("CrashLoopBackOff", CategoryRuntime, "api-1", "app"))
+	report.Add(podFailure("OOMKilled", CategoryRuntime, "api-2", "app"))
+	report.Add(podFailure("CrashLoopBackOff", CategoryRuntime, "api-2", "app"))
+
+	got := report.RootCauses()
+	names := make([]string, 0, len(got))
+	for _, rc := range got {
+		names = append(names, rc.Failure.Name)
+	}
+	assert.Equal(t, []string{"CrashLoopBackOff", "OOMKilled", "ReadinessProbeFailed", "ProgressDeadlineExceeded"}, names)
+	assert.Equal(t, 4, report.Len())
+	assert.Equal(t, []string{"api-1", "api-2"}, report.Pods())
+
+	crash := got[0]
+	assert.Equal(t, []string{"api-1", "api-2"}, crash.Pods)
+	assert.Equal(t, 3, crash.Occurrences)
+	assert.Equal(t, "app", crash.Failure.Object.Container, "container should be filled in from the status snapshot")
+	assert.Equal(t, "CrashLoopBackOff on 2/3 new pods: CrashLoopBackOff summary", crash.Describe(3))
+	assert.Equal(t, "ProgressDeadlineExceeded on deployment/api: Deployment exceeded its progressDeadlineSeconds without completing", got[3].Describe(3))
+}
+
+func TestReport_Add_DistinctObjects(t *testing.T) {
+	missingSecret := func(name string) Failure {
+		return Failure{
+			Name:     "SecretNotFound",
+			Category: CategoryAdmission,
+			Summary:  "Secret " + name + " does not exist",
+			Object:   ObjectRef{Kind: "Secret", Namespace: "default", Name: name},
+		}
+	}
+	fargate := func(pod, detail string) Failure {
+		return Failure{
+			Name:     "FargateUnsupported",
+			Category: CategoryScheduling,
+			Summary:  "Pod uses a feature Fargate does not support: " + detail,
+			Object:   ObjectRef{Kind: "Pod", Namespace: "default", Name: pod},
+		}
+	}
+
+	report := NewReport()
+	report.Add(missingSecret("db"))
+	report.Add(missingSecret("api-key"))
+	report.Add(missingSecret("db"))
+	report.Add(fargate("api-1", "hostNetwork"))
+	report.Add(fargate("api-2", "hostNetwork"))
+	report.Add(fargate("api-1", "DaemonSet"))
+
+	got := report.RootCauses()
+	assert.Equal(t, 4, report.Len())
+	assert.Equal(t, "db", got[0].Failure.Object.Name)
+	assert.Equal(t, 2, got[0].Occurrences)
+	assert.Equal(t, "api-key", got[1].Failure.Object.Name)
+	assert.Equal(t, []string{"api-1", "api-2"}, got[2].Pods)
+	assert.Equal(t, []string{"api-1"}, got[3].Pods)
+}
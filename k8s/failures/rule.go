@@ -0,0 +1,316 @@
+package failures
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"slices"
+	"text/template"
+)
+
+// Source identifies which piece of Kubernetes state produced an Observation.
+type Source string
+
+const (
+	// SourceWaiting is a container's state.waiting (Reason/Message), including its lastState.terminated
+	SourceWaiting Source = "waiting"
+	// SourceTerminated is a container's state.terminated (Reason/Message/ExitCode)
+	SourceTerminated Source = "terminated"
+	// SourcePod is a pod's status.reason/status.message (e.g. Evicted)
+	SourcePod Source = "pod"
+	// SourceCondition is an unhealthy condition on a Pod or Deployment
+	SourceCondition Source = "condition"
+	// SourceEvent is a corev1.Event
+	SourceEvent Source = "event"
+)
+
+var knownSources = []Source{SourceWaiting, SourceTerminated, SourcePod, SourceCondition, SourceEvent}
+
+var knownCategories = []Category{
+	CategoryImage, CategoryRuntime, CategoryScheduling, CategoryStorage,
+	CategoryNetwork, CategoryAdmission, CategoryRollout, CategoryNode,
+}
+
+var knownProviders = []Provider{ProviderGeneric, ProviderGKE, ProviderEKS, ProviderAKS}
+
+// Observation is the normalized signal that rules match against.
+// The classifiers build one per container status, pod status, condition, or event.
+type Observation struct {
+	Source Source `json:"source,omitempty"`
+	// Kind is the kind of the object the signal was observed on (e.g. Pod, ReplicaSet, Deployment)
+	Kind string `json:"kind,omitempty"`
+	// ConditionType and ConditionStatus are only set for SourceCondition
+	ConditionType   string `json:"conditionType,omitempty"`
+	ConditionStatus string `json:"conditionStatus,omitempty"`
+	Reason          string `json:"reason,omitempty"`
+	Message         string `json:"message,omitempty"`
+	ExitCode        *int32 `json:"exitCode,omitempty"`
+	// LastTermination is the previous termination of a waiting container (e.g. during CrashLoopBackOff)
+	LastTermination *Termination `json:"lastTermination,omitempty"`
+	// NodeLabels are the labels of the node the pod is running on; only set when the classifier was given the node
+	NodeLabels map[string]string `json:"nodeLabels,omitempty"`
+}
+
+// Termination is a container termination (state.terminated or lastState.terminated).
+type Termination struct {
+	Reason   string `json:"reason,omitempty"`
+	Message  string `json:"message,omitempty"`
+	ExitCode int32  `json:"exitCode"`
+}
+
+// Match is the set of conditions a rule requires; every non-empty field must match.
+// Message patterns are regular expressions matched case-insensitively.
+type Match struct {
+	Source        []Source `json:"source"`
+	Kind          []string `json:"kind,omitempty"`
+	ConditionType []string `json:"conditionType,omitempty"`
+	Reason        []string `json:"reason,omitempty"`
+	Message       string   `json:"message,omitempty"`
+	ExitCode      []int32  `json:"exitCode,omitempty"`
+	// LastTermination requires the observation to carry a previous termination; `{}` matches any
+	LastTermination *TerminationMatch `json:"lastTermination,omitempty"`
+	// NodeLabels requires the pod's node to carry each label; an empty value matches any value of the label
+	NodeLabels map[string]string `json:"nodeLabels,omitempty"`
+
+	message *regexp.Regexp
+}
+
+type TerminationMatch struct {
+	Reason   []string `json:"reason,omitempty"`
+	Message  string   `json:"message,omitempty"`
+	ExitCode []int32  `json:"exitCode,omitempty"`
+
+	message *regexp.Regexp
+}
+
+// ProviderHint tags a failure with a provider when the observation message matches.
+type ProviderHint struct {
+	Provider Provider `json:"provider"`
+	Message  string   `json:"message"`
+
+	message *regexp.Regexp
+}
+
+// Example is a sample observation that a rule must classify; see Catalog.Verify.
+// If Source is empty, the rule's first source is used.
+type Example struct {
+	Observation
+	// WantName and WantProvider assert the rendered failure in addition to the matching rule
+	WantName     string   `json:"wantName,omitempty"`
+	WantProvider Provider `json:"wantProvider,omitempty"`
+}
+
+// Rule maps observations to a Failure.
+// Name, Summary, and Remediation are text/templates rendered with the Observation;
+// named groups captured by the message pattern are available as {{.Groups.name}}.
+type Rule struct {
+	// ID uniquely identifies the rule so that overlays can replace or disable it
+	ID       string `json:"id"`
+	Disabled bool   `json:"disabled,omitempty"`
+	Match    Match  `json:"match"`
+
+	Name     string   `json:"name"`
+	Category Category `json:"category"`
+	// Provider is the default provider; ProviderHints names a hint set in the catalog that refines it
+	Provider      Provider `json:"provider,omitempty"`
+	ProviderHints string   `json:"providerHints,omitempty"`
+	Summary       string   `json:"summary"`
+	Remediation   string   `json:"remediation,omitempty"`
+	Docs          []string `json:"docs,omitempty"`
+	// Signals overrides the evidence derived from the observation (e.g. to tag the equivalent condition)
+	Signals Signals `json:"signals,omitempty"`
+
+	Examples []Example `json:"examples,omitempty"`
+
+	name        *template.Template
+	summary     *template.Template
+	remediation *template.Template
+}
+
+type templateData struct {
+	Observation
+	Groups map[string]string
+}
+
+func (r *Rule) compile() error {
+	if r.ID == "" {
+		return fmt.Errorf("rule is missing an id")
+	}
+	if r.Disabled {
+		return nil
+	}
+	if r.Name == "" {
+		return fmt.Errorf("rule %q is missing a name", r.ID)
+	}
+	if len(r.Match.Source) == 0 {
+		return fmt.Errorf("rule %q must match at least one source", r.ID)
+	}
+	for _, src := range r.Match.Source {
+		if !slices.Contains(knownSources, src) {
+			return fmt.Errorf("rule %q has unknown source %q", r.ID, src)
+		}
+	}
+	if !slices.Contains(knownCategories, r.Category) {
+		return fmt.Errorf("rule %q has unknown category %q", r.ID, r.Category)
+	}
+	if r.Provider == "" {
+		r.Provider = ProviderGeneric
+	}
+	if !slices.Contains(knownProviders, r.Provider) {
+		return fmt.Errorf("rule %q has unknown provider %q", r.ID, r.Provider)
+	}
+
+	var err error
+	if r.Match.message, err = compilePattern(r.Match.Message); err != nil {
+		return fmt.Errorf("rule %q has an invalid message pattern: %w", r.ID, err)
+	}
+	if lt := r.Match.LastTermination; lt != nil {
+		if lt.message, err = compilePattern(lt.Message); err != nil {
+			return fmt.Errorf("rule %q has an invalid lastTermination message pattern: %w", r.ID, err)
+		}
+	}
+	if r.name, err = compileTemplate(r.Name); err != nil {
+		return fmt.Errorf("rule %q has an invalid name template: %w", r.ID, err)
+	}
+	if r.summary, err = compileTemplate(r.Summary); err != nil {
+		return fmt.Errorf("rule %q has an invalid summary template: %w", r.ID, err)
+	}
+	if r.remediation, err = compileTemplate(r.Remediation); err != nil {
+		return fmt.Errorf("rule %q has an invalid remediation template: %w", r.ID, err)
+	}
+	return nil
+}
+
+// match reports whether the observation satisfies the rule; on success, it returns the named message groups
+func (r *Rule) match(obs Observation) (map[string]string, bool) {
+	m := r.Match
+	if !slices.Contains(m.Source, obs.Source) {
+		return nil, false
+	}
+	if len(m.Kind) > 0 && !slices.Contains(m.Kind, obs.Kind) {
+		return nil, false
+	}
+	if len(m.ConditionType) > 0 && !slices.Contains(m.ConditionType, obs.ConditionType) {
+		return nil, false
+	}
+	if len(m.Reason) > 0 && !slices.Contains(m.Reason, obs.Reason) {
+		return nil, false
+	}
+	if len(m.ExitCode) > 0 && (obs.ExitCode == nil || !slices.Contains(m.ExitCode, *obs.ExitCode)) {
+		return nil, false
+	}
+	if lt := m.LastTermination; lt != nil {
+		last := obs.LastTermination
+		if last == nil {
+			return nil, false
+		}
+		if len(lt.Reason) > 0 && !slices.Contains(lt.Reason, last.Reason) {
+			return nil, false
+		}
+		if len(lt.ExitCode) > 0 && !slices.Contains(lt.ExitCode, last.ExitCode) {
+			return nil, false
+		}
+		if lt.message != nil && !lt.message.MatchString(last.Message) {
+			return nil, false
+		}
+	}
+	for key, value := range m.NodeLabels {
+		got, ok := obs.NodeLabels[key]
+		if !ok || (value != "" && got != value) {
+			return nil, false
+		}
+	}
+	groups := map[string]string{}
+	if m.message != nil {
+		found := m.message.FindStringSubmatch(obs.Message)
+		if found == nil {
+			return nil, false
+		}
+		for i, name := range m.message.SubexpNames() {
+			if name != "" {
+				groups[name] = found[i]
+			}
+		}
+	}
+	return groups, true
+}
+
+// apply renders the Failure for an observation that matched this rule
+func (r *Rule) apply(obs Observation, groups map[string]string, obj ObjectRef, hints []*ProviderHint) *Failure {
+	data := templateData{Observation: obs, Groups: groups}
+	f := &Failure{
+		Name:        render(r.name, r.Name, data),
+		Category:    r.Category,
+		Summary:     render(r.summary, r.Summary, data),
+		Remediation: render(r.remediation, r.Remediation, data),
+		Object:      obj,
+		Signals:     obs.signals(),
+		Provider:    r.Provider,
+		Docs:        slices.Clone(r.Docs),
+	}
+	for _, hint := range hints {
+		if hint.message.MatchString(obs.Message) {
+			f.Provider = hint.Provider
+			break
+		}
+	}
+	f.Signals.override(r.Signals)
+	return f
+}
+
+// signals derives the raw evidence for a Failure from the observation
+func (o Observation) signals() Signals {
+	switch o.Source {
+	case SourceWaiting:
+		s := Signals{WaitingReason: o.Reason, EventMessage: o.Message}
+		if last := o.LastTermination; last != nil {
+			exit := last.ExitCode
+			s.TerminatedReason, s.ExitCode, s.EventMessage = last.Reason, &exit, last.Message
+		}
+		return s
+	case SourceTerminated:
+		return Signals{TerminatedReason: o.Reason, ExitCode: o.ExitCode, EventMessage: o.Message}
+	case SourceCondition:
+		return Signals{
+			Condition:    fmt.Sprintf("%s=%s:%s", o.ConditionType, o.ConditionStatus, o.Reason),
+			EventMessage: o.Message,
+		}
+	default:
+		return Signals{EventReason: o.Reason, EventMessage: o.Message}
+	}
+}
+
+func (s *Signals) override(o Signals) {
+	if o.EventReason != "" {
+		s.EventReason = o.EventReason
+	}
+	if o.WaitingReason != "" {
+		s.WaitingReason = o.WaitingReason
+	}
+	if o.TerminatedReason != "" {
+		s.TerminatedReason = o.TerminatedReason
+	}
+	if o.Condition != "" {
+		s.Condition = o.Condition
+	}
+}
+
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	return regexp.Compile("(?i)" + pattern)
+}
+
+func compileTemplate(text string) (*template.Template, error) {
+	return template.New("").Option("missingkey=zero").Parse(text)
+}
+
+// render executes a rule template; a template that fails at runtime falls back to its raw text
+func render(tmpl *template.Template, raw string, data templateData) string {
+	buf := bytes.Buffer{}
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return raw
+	}
+	return buf.String()
+}
@@ -0,0 +1,41 @@
+package failures
+
+import (
+	"fmt"
+)
+
+// Verify runs every rule's examples through the whole catalog and reports each example that
+// is not classified by the rule declaring it (e.g. because an earlier rule shadows it)
+// or that does not render the expected name/provider.
+// Run this in CI against a layered catalog to validate organization-specific rules.
+func (c *Catalog) Verify() []error {
+	var errs []error
+	for _, rule := range c.Rules {
+		if rule.Disabled {
+			continue
+		}
+		for i, ex := range rule.Examples {
+			obs := ex.Observation
+			if obs.Source == "" {
+				obs.Source = rule.Match.Source[0]
+			}
+			got, groups := c.find(obs)
+			if got == nil {
+				errs = append(errs, fmt.Errorf("rule %q example %d: no rule matched", rule.ID, i+1))
+				continue
+			}
+			if got != rule {
+				errs = append(errs, fmt.Errorf("rule %q example %d: classified by rule %q", rule.ID, i+1, got.ID))
+				continue
+			}
+			f := got.apply(obs, groups, ObjectRef{}, c.ProviderHints[got.ProviderHints])
+			if ex.WantName != "" && f.Name != ex.WantName {
+				errs = append(errs, fmt.Errorf("rule %q example %d: name is %q, want %q", rule.ID, i+1, f.Name, ex.WantName))
+			}
+			if ex.WantProvider != "" && f.Provider != ex.WantProvider {
+				errs = append(errs, fmt.Errorf("rule %q example %d: provider is %q, want %q", rule.ID, i+1, f.Provider, ex.WantProvider))
+			}
+		}
+	}
+	return errs
+}
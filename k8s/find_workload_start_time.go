@@ -0,0 +1,46 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// FindControllerRevisionStartTime finds when the newest ControllerRevision of a StatefulSet or DaemonSet was created
+func FindControllerRevisionStartTime(ctx context.Context, client *kubernetes.Clientset, namespace string, selector *metav1.LabelSelector) *time.Time {
+	if selector == nil {
+		return nil
+	}
+	revisions, err := client.AppsV1().ControllerRevisions(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: metav1.FormatLabelSelector(selector),
+	})
+	if err != nil || len(revisions.Items) == 0 {
+		return nil
+	}
+	latest := revisions.Items[0]
+	for _, cr := range revisions.Items {
+		if cr.Revision > latest.Revision {
+			latest = cr
+		}
+	}
+	t := latest.CreationTimestamp.Time
+	return &t
+}
+
+// FindRolloutRevisionStartTime finds when the ReplicaSet of an Argo Rollout revision was created
+func FindRolloutRevisionStartTime(ctx context.Context, client *kubernetes.Clientset, namespace string, podHash string) *time.Time {
+	if podHash == "" {
+		return nil
+	}
+	replicaSets, err := client.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", RolloutPodTemplateHashLabel, podHash),
+	})
+	if err != nil || len(replicaSets.Items) == 0 {
+		return nil
+	}
+	t := replicaSets.Items[0].CreationTimestamp.Time
+	return &t
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/nullstone-io/deployment-sdk/app"
 	"github.com/nullstone-io/deployment-sdk/k8s/logs"
 	"github.com/nullstone-io/deployment-sdk/logging"
+	"github.com/nullstone-io/deployment-sdk/maps"
 )
 
 type LogStreamer struct {
@@ -26,6 +27,18 @@ func (l LogStreamer) Stream(ctx context.Context, options app.LogStreamOptions) e
 		fmt.Sprintf("nullstone.io/app=%s", l.AppName)},
 		options.Selectors...,
 	)
+	// The kubernetes log API cannot filter log lines; resource labels become pod label selectors
+	// and the rest of the query is filtered client-side
+	emitter, err := options.Query.FilterEmitter(options.Emitter)
+	if err != nil {
+		return err
+	}
+	options.Emitter = emitter
+	if options.Query != nil {
+		for _, key := range maps.SortedKeys(options.Query.ResourceLabels) {
+			options.Selectors = append(options.Selectors, fmt.Sprintf("%s=%s", key, options.Query.ResourceLabels[key]))
+		}
+	}
 
 	streamer := logs.WorkloadStreamer{
 		Namespace:    l.AppNamespace,
@@ -15,12 +15,13 @@ import (
 )
 
 type StreamGetter interface {
-	GetStreamer(pod *corev1.Pod, containerName string, since time.Time, follow bool) (rest.ResponseWrapper, error)
+	GetStreamer(pod *corev1.Pod, containerName string, since time.Time, follow bool, previous bool) (rest.ResponseWrapper, error)
 }
 
 // ContainerStreamer streams logs from a pod's container
 // It is responsible for:
 // - waiting for the container to start
+// - emitting logs from the previous container instance if requested and the container has restarted
 // - streaming logs as app.LogMessage
 // - flushing logs before stopping
 type ContainerStreamer struct {
@@ -55,15 +56,19 @@ func (s *ContainerStreamer) Stream(ctx context.Context, options app.LogStreamOpt
 	if options.WatchInterval >= 0 {
 		follow = true
 	}
-	request, err := s.LogSource.GetStreamer(s.Pod, s.ContainerName, since, follow)
+	writer := buffer.NewWriter(fmt.Sprintf("%s/%s", s.Pod.Name, s.ContainerName))
+	defer writer.Close()
+
+	if options.Previous && ContainerRestartCount(s.Pod, s.ContainerName) > 0 {
+		s.streamPrevious(ctx, writer, options)
+	}
+
+	request, err := s.LogSource.GetStreamer(s.Pod, s.ContainerName, since, follow, false)
 	if err != nil {
 		s.debug(options, fmt.Sprintf("Failed to initialize log streamer: %s\n", err))
 		return
 	}
 
-	writer := buffer.NewWriter(fmt.Sprintf("%s/%s", s.Pod.Name, s.ContainerName))
-	defer writer.Close()
-
 	readCloser, err := s.startStream(ctx, request)
 	if err != nil {
 		s.debug(options, fmt.Sprintf("Failed to start stream: %s\n", err))
@@ -80,7 +85,7 @@ func (s *ContainerStreamer) Stream(ctx context.Context, options app.LogStreamOpt
 			s.flush(request, writer, options)
 			return
 		default:
-			if readErr := s.writeLine(r, writer, options); readErr != nil {
+			if readErr := s.writeLine(r, writer, options, false); readErr != nil {
 				// The follow stream can EOF before the caller signals stop
 				// (e.g. kubelet closes it on container exit). Flush here so
 				// any bytes still buffered on the k8s side reach the writer.
@@ -153,7 +158,7 @@ func (s *ContainerStreamer) flush(request rest.ResponseWrapper, writer BufferWri
 	go func() {
 		defer close(doneCh)
 		for {
-			if readErr := s.writeLine(r, writer, options); readErr != nil {
+			if readErr := s.writeLine(r, writer, options, false); readErr != nil {
 				return
 			}
 			select {
@@ -185,11 +190,49 @@ func (s *ContainerStreamer) flush(request rest.ResponseWrapper, writer BufferWri
 	}
 }
 
-func (s *ContainerStreamer) writeLine(r *bufio.Reader, writer BufferWriter, options app.LogStreamOptions) error {
+// streamPrevious emits the logs of the container's last terminated instance
+// A crash-looping container usually exits before the current stream opens; this is the only place its final output is visible
+func (s *ContainerStreamer) streamPrevious(ctx context.Context, writer BufferWriter, options app.LogStreamOptions) {
+	var since time.Time
+	if options.StartTime != nil {
+		since = *options.StartTime
+	}
+	request, err := s.LogSource.GetStreamer(s.Pod, s.ContainerName, since, false, true)
+	if err != nil {
+		s.debug(options, fmt.Sprintf("Failed to initialize previous log streamer: %s", err))
+		return
+	}
+	readCloser, err := request.Stream(ctx)
+	if err != nil {
+		s.debug(options, fmt.Sprintf("Failed to start previous log stream: %s", err))
+		return
+	}
+	defer readCloser.Close()
+	r := bufio.NewReader(readCloser)
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		default:
+			if readErr := s.writeLine(r, writer, options, true); readErr != nil {
+				return
+			}
+		}
+	}
+}
+
+func (s *ContainerStreamer) writeLine(r *bufio.Reader, writer BufferWriter, options app.LogStreamOptions, previous bool) error {
 	str, readErr := r.ReadString('\n')
 	if str != "" {
 		str = strings.TrimSuffix(str, "\n")
-		writer.Write(MessageFromLine(s.Namespace, s.WorkloadName, s.Pod.Name, s.ContainerName, str))
+		msg := MessageFromLine(s.Namespace, s.WorkloadName, s.Pod.Name, s.ContainerName, str)
+		if previous {
+			msg.Stream = fmt.Sprintf("%s (previous)", msg.Stream)
+		}
+		if options.ParseJSON {
+			msg = app.ParseStructuredLogMessage(msg)
+		}
+		writer.Write(msg)
 	}
 	if readErr != nil && readErr != io.EOF {
 		s.debug(options, fmt.Sprintf("Failed to read container logs: %s", readErr))
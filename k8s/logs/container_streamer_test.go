@@ -43,15 +43,19 @@ func (w *recordingWriter) Close() {}
 // fakeLogSource hands out pre-made io.ReadClosers on each call to Stream.
 // The test controls exactly what bytes appear on each successive Stream() call.
 type fakeLogSource struct {
-	streams []io.ReadCloser
-	idx     int
-	mu      sync.Mutex
+	streams  []io.ReadCloser
+	idx      int
+	previous []bool
+	mu       sync.Mutex
 }
 
 var _ StreamGetter = (*fakeLogSource)(nil)
 var _ rest.ResponseWrapper = (*fakeLogSource)(nil)
 
-func (f *fakeLogSource) GetStreamer(*corev1.Pod, string, time.Time, bool) (rest.ResponseWrapper, error) {
+func (f *fakeLogSource) GetStreamer(_ *corev1.Pod, _ string, _ time.Time, _ bool, previous bool) (rest.ResponseWrapper, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.previous = append(f.previous, previous)
 	return f, nil
 }
 
@@ -208,3 +212,74 @@ func TestContainerStreamer_NoFlushWhenTimeoutZero(t *testing.T) {
 		t.Fatalf("flush should not have opened a second stream; idx=%d", src.idx)
 	}
 }
+
+// A container that has restarted emits its previous instance's output before the current stream
+// when Previous is requested, so a crash-looping container's final output is visible.
+func TestContainerStreamer_PreviousLogs(t *testing.T) {
+	prevR, prevW := io.Pipe()
+	mainR, mainW := io.Pipe()
+	src := &fakeLogSource{streams: []io.ReadCloser{prevR, mainR}}
+
+	go func() {
+		_, _ = prevW.Write([]byte("panic: boom\n"))
+		_ = prevW.Close()
+	}()
+	go func() {
+		_, _ = mainW.Write([]byte("starting\n"))
+		_ = mainW.Close()
+	}()
+
+	pod := testPod()
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "main", RestartCount: 3}}
+	streamer := &ContainerStreamer{
+		Namespace:     "ns",
+		WorkloadName:  "wl",
+		Pod:           pod,
+		ContainerName: "main",
+		LogSource:     src,
+	}
+	buf := &recordingBuffer{}
+	streamer.Stream(context.Background(), app.LogStreamOptions{Previous: true}, buf)
+
+	if len(buf.messages) != 2 {
+		t.Fatalf("unexpected lines %q", buf.lines())
+	}
+	if buf.messages[0].Message != "panic: boom" || buf.messages[0].Stream != "pod-x/main (previous)" {
+		t.Fatalf("unexpected previous message %+v", buf.messages[0])
+	}
+	if buf.messages[1].Message != "starting" || buf.messages[1].Stream != "pod-x/main" {
+		t.Fatalf("unexpected current message %+v", buf.messages[1])
+	}
+	if len(src.previous) != 2 || !src.previous[0] || src.previous[1] {
+		t.Fatalf("unexpected previous flags %v", src.previous)
+	}
+}
+
+// Previous is a no-op for containers that have never restarted.
+func TestContainerStreamer_PreviousSkippedWithoutRestart(t *testing.T) {
+	mainR, mainW := io.Pipe()
+	src := &fakeLogSource{streams: []io.ReadCloser{mainR}}
+
+	go func() {
+		_, _ = mainW.Write([]byte("only-line\n"))
+		_ = mainW.Close()
+	}()
+
+	streamer := &ContainerStreamer{
+		Namespace:     "ns",
+		WorkloadName:  "wl",
+		Pod:           testPod(),
+		ContainerName: "main",
+		LogSource:     src,
+	}
+	buf := &recordingBuffer{}
+	streamer.Stream(context.Background(), app.LogStreamOptions{Previous: true}, buf)
+
+	lines := buf.lines()
+	if len(lines) != 1 || lines[0] != "only-line" {
+		t.Fatalf("unexpected lines %q", lines)
+	}
+	if len(src.previous) != 1 || src.previous[0] {
+		t.Fatalf("previous logs should not have been requested; flags=%v", src.previous)
+	}
+}
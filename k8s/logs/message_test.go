@@ -0,0 +1,20 @@
+package logs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nullstone-io/deployment-sdk/app"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageFromLine(t *testing.T) {
+	got := MessageFromLine("ns", "api", "api-1", "app", "2024-01-02T03:04:05Z hello world")
+	assert.Equal(t, app.LogMessage{
+		SourceType: "k8s",
+		Source:     "ns/api",
+		Stream:     "api-1/app",
+		Timestamp:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Message:    "hello world",
+	}, got)
+}
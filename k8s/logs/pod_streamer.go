@@ -2,6 +2,7 @@ package logs
 
 import (
 	"context"
+	"slices"
 	"sync"
 
 	"github.com/nullstone-io/deployment-sdk/app"
@@ -32,7 +33,7 @@ func (s *PodStreamer) Stream(ctx context.Context, options app.LogStreamOptions,
 
 	// Build a list of ContainerStreamers based on the Pod spec
 	streamers := make([]*ContainerStreamer, 0)
-	for _, containerName := range s.getContainerNames() {
+	for _, containerName := range s.getContainerNames(options.Containers) {
 		streamer := &ContainerStreamer{
 			Namespace:     s.Namespace,
 			WorkloadName:  s.WorkloadName,
@@ -75,17 +76,36 @@ func (s *PodStreamer) Stop() {
 	}
 }
 
-func (s *PodStreamer) getContainerNames() []string {
+// getContainerNames returns the pod's init containers and containers
+// If filter is not empty, only containers named in filter are returned
+func (s *PodStreamer) getContainerNames(filter []string) []string {
 	containerNames := make([]string, 0)
+	add := func(name string) {
+		if len(filter) == 0 || slices.Contains(filter, name) {
+			containerNames = append(containerNames, name)
+		}
+	}
 	for _, container := range s.Pod.Spec.InitContainers {
-		containerNames = append(containerNames, container.Name)
+		add(container.Name)
 	}
 	for _, container := range s.Pod.Spec.Containers {
-		containerNames = append(containerNames, container.Name)
+		add(container.Name)
 	}
 	return containerNames
 }
 
+// ContainerRestartCount returns how many times the named container (or init container) in the pod has restarted
+func ContainerRestartCount(pod *corev1.Pod, containerName string) int32 {
+	for _, statuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for _, cs := range statuses {
+			if cs.Name == containerName {
+				return cs.RestartCount
+			}
+		}
+	}
+	return 0
+}
+
 func (s *PodStreamer) debug(options app.LogStreamOptions, msg string) {
 	if options.DebugLogger != nil {
 		options.DebugLogger.Printf("[DEBUG:%s] %s\n", s.Pod.Name, msg)
@@ -14,13 +14,17 @@ type StreamSource struct {
 	GetTimeout time.Duration
 }
 
-func (s StreamSource) GetStreamer(pod *corev1.Pod, containerName string, since time.Time, follow bool) (rest.ResponseWrapper, error) {
-	sinceTime := metav1.NewTime(since)
+func (s StreamSource) GetStreamer(pod *corev1.Pod, containerName string, since time.Time, follow bool, previous bool) (rest.ResponseWrapper, error) {
 	podLogOptions := &corev1.PodLogOptions{
 		Container:  containerName,
 		Timestamps: true,
-		SinceTime:  &sinceTime,
 		Follow:     follow,
+		Previous:   previous,
+	}
+	// A zero since retrieves all logs; this is used for the output of a previous container instance
+	if !since.IsZero() {
+		sinceTime := metav1.NewTime(since)
+		podLogOptions.SinceTime = &sinceTime
 	}
 	requests, err := polymorphichelpers.LogsForObjectFn(RestClientGetter{Config: s.Config}, pod, podLogOptions, s.GetTimeout, false)
 	if err != nil {
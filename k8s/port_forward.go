@@ -0,0 +1,178 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+type PortForwardOptions struct {
+	// PodName selects the pod to forward to; if empty, a running and ready pod for the app is selected
+	PodName string
+	// Ports are kubectl-style port mappings: "8080:80", "80" (same local port), or ":80" (random local port)
+	Ports []string
+	// Addresses are the local addresses to listen on (default: localhost)
+	Addresses []string
+
+	Out    io.Writer
+	ErrOut io.Writer
+}
+
+type ForwardedPort struct {
+	Local  uint16 `json:"local"`
+	Remote uint16 `json:"remote"`
+}
+
+// PortForwardSession is an established port forward
+// Forwarding stops when the context passed to PortForward is done or Close is called
+type PortForwardSession struct {
+	Pod   string
+	Ports []ForwardedPort
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+	err      error
+}
+
+// Close stops forwarding, releases the local listeners, and returns the error that ended forwarding (if any)
+func (s *PortForwardSession) Close() error {
+	s.stopOnce.Do(func() { close(s.stop) })
+	return s.Wait()
+}
+
+// Wait blocks until forwarding stops
+func (s *PortForwardSession) Wait() error {
+	<-s.done
+	return s.err
+}
+
+// portForwardSessions holds the sessions started by the port-forward action until they stop
+var portForwardSessions = &sessionRegistry{sessions: map[string]*PortForwardSession{}}
+
+type sessionRegistry struct {
+	mu       sync.Mutex
+	next     int
+	sessions map[string]*PortForwardSession
+}
+
+func (r *sessionRegistry) add(session *PortForwardSession) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.next++
+	id := strconv.Itoa(r.next)
+	r.sessions[id] = session
+	return id
+}
+
+func (r *sessionRegistry) get(id string) *PortForwardSession {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.sessions[id]
+}
+
+func (r *sessionRegistry) remove(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.sessions, id)
+}
+
+// PortForward listens on local ports and proxies connections to a pod for the app through the pods/portforward subresource
+// This returns once the local listeners are ready; the returned session reports the bound local ports
+func (a Actioner) PortForward(ctx context.Context, options PortForwardOptions) (*PortForwardSession, error) {
+	if len(options.Ports) == 0 {
+		return nil, fmt.Errorf("port-forward requires at least one port")
+	}
+	cfg, err := a.NewConfigFn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error creating kubernetes config: %w", err)
+	}
+	client, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("error creating kube client: %w", err)
+	}
+
+	pod, err := FindExecPod(ctx, client, a.Namespace, a.AppName, options.PodName)
+	if err != nil {
+		return nil, err
+	}
+
+	u := client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(pod.Namespace).
+		Name(pod.Name).
+		SubResource("portforward").
+		URL()
+	transport, upgrader, err := spdy.RoundTripperFor(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("error creating spdy transport: %w", err)
+	}
+	spdyDialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", u)
+	websocketDialer, err := portforward.NewSPDYOverWebsocketDialer(u, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("error creating websocket dialer: %w", err)
+	}
+	dialer := portforward.NewFallbackDialer(websocketDialer, spdyDialer, shouldFallback)
+
+	addresses := options.Addresses
+	if len(addresses) == 0 {
+		addresses = []string{"localhost"}
+	}
+	out, errOut := options.Out, options.ErrOut
+	if out == nil {
+		out = io.Discard
+	}
+	if errOut == nil {
+		errOut = io.Discard
+	}
+
+	session := &PortForwardSession{
+		Pod:  pod.Name,
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	ready := make(chan struct{})
+	pf, err := portforward.NewOnAddresses(dialer, addresses, options.Ports, session.stop, ready, out, errOut)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port forward: %w", err)
+	}
+
+	go func() {
+		session.err = pf.ForwardPorts()
+		close(session.done)
+	}()
+	go func() {
+		select {
+		case <-ctx.Done():
+			session.Close()
+		case <-session.done:
+		}
+	}()
+
+	select {
+	case <-ready:
+	case <-session.done:
+		err := session.err
+		if err == nil {
+			err = ctx.Err()
+		}
+		return nil, fmt.Errorf("error forwarding ports to pod %q: %w", pod.Name, err)
+	}
+
+	ports, err := pf.GetPorts()
+	if err != nil {
+		session.Close()
+		return nil, fmt.Errorf("error retrieving forwarded ports: %w", err)
+	}
+	for _, p := range ports {
+		session.Ports = append(session.Ports, ForwardedPort{Local: p.Local, Remote: p.Remote})
+	}
+	return session, nil
+}
@@ -0,0 +1,98 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nullstone-io/deployment-sdk/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+func TestActioner_PortForward_Input(t *testing.T) {
+	tests := []struct {
+		name    string
+		action  string
+		input   string
+		wantErr string
+	}{
+		{name: "invalid json", action: ActionPortForward, input: `{`, wantErr: "invalid input for port-forward: unexpected end of JSON input"},
+		{name: "no ports", action: ActionPortForward, input: `{"podName":"api-1"}`, wantErr: "port-forward requires ports"},
+		{name: "stop without session", action: ActionStopPortForward, input: `{}`, wantErr: "stop-port-forward requires sessionId"},
+		{name: "stop unknown session", action: ActionStopPortForward, input: `{"sessionId":"missing"}`, wantErr: `port forward session "missing" does not exist or has already stopped`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := Actioner{Namespace: "ns", AppName: "api"}
+			_, err := a.PerformAction(context.Background(), workspace.ActionOptions{Action: test.action, Input: json.RawMessage(test.input)})
+			assert.EqualError(t, err, test.wantErr)
+		})
+	}
+}
+
+func TestActioner_PortForward_PodUnreachable(t *testing.T) {
+	pod := corev1.Pod{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Name: "api-1", Namespace: "ns"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && r.URL.Path == "/api/v1/namespaces/ns/pods/api-1" {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(pod)
+			return
+		}
+		// Reject the portforward upgrade
+		http.Error(w, "forbidden", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	a := Actioner{
+		Namespace: "ns",
+		AppName:   "api",
+		NewConfigFn: func(ctx context.Context) (*rest.Config, error) {
+			return &rest.Config{Host: server.URL}, nil
+		},
+	}
+	_, err := a.PerformAction(context.Background(), workspace.ActionOptions{
+		Action: ActionPortForward,
+		Input:  json.RawMessage(`{"podName":"api-1","ports":[":80"]}`),
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `error forwarding ports to pod "api-1"`)
+}
+
+func TestActioner_StopPortForward(t *testing.T) {
+	session := &PortForwardSession{
+		Pod:  "api-1",
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	go func() {
+		<-session.stop
+		close(session.done)
+	}()
+	id := portForwardSessions.add(session)
+	defer portForwardSessions.remove(id)
+
+	a := Actioner{Namespace: "ns", AppName: "api"}
+	res, err := a.PerformAction(context.Background(), workspace.ActionOptions{
+		Action: ActionStopPortForward,
+		Input:  json.RawMessage(`{"sessionId":"` + id + `"}`),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "completed", res.Status)
+	assert.Equal(t, `stopped forwarding ports to pod "api-1"`, res.Message)
+	select {
+	case <-session.done:
+	default:
+		t.Fatal("session was not stopped")
+	}
+}
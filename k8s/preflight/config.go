@@ -0,0 +1,116 @@
+package preflight
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nullstone-io/deployment-sdk/k8s/failures"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	docsConfigMaps = "https://kubernetes.io/docs/concepts/configuration/configmap/"
+	docsSecrets    = "https://kubernetes.io/docs/concepts/configuration/secret/"
+)
+
+// keyLookup retrieves the keys of a ConfigMap or Secret
+type keyLookup func(ctx context.Context, name string) (keys map[string]bool, err error)
+
+func (c Checker) checkConfigMaps(ctx context.Context, spec corev1.PodSpec) ([]failures.Failure, error) {
+	return c.checkKeyedObjects(ctx, "ConfigMap", docsConfigMaps, configMapReferences(spec), func(ctx context.Context, name string) (map[string]bool, error) {
+		cm, err := c.Client.CoreV1().ConfigMaps(c.Namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		keys := map[string]bool{}
+		for key := range cm.Data {
+			keys[key] = true
+		}
+		for key := range cm.BinaryData {
+			keys[key] = true
+		}
+		return keys, nil
+	})
+}
+
+func (c Checker) checkSecrets(ctx context.Context, spec corev1.PodSpec) ([]failures.Failure, error) {
+	return c.checkKeyedObjects(ctx, "Secret", docsSecrets, secretReferences(spec), func(ctx context.Context, name string) (map[string]bool, error) {
+		secret, err := c.Client.CoreV1().Secrets(c.Namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		keys := map[string]bool{}
+		for key := range secret.Data {
+			keys[key] = true
+		}
+		return keys, nil
+	})
+}
+
+// checkKeyedObjects verifies that every non-optional reference points at an existing object and key
+// Each missing object and missing key is reported once, attributed to its first reference
+func (c Checker) checkKeyedObjects(ctx context.Context, kind, docs string, refs []reference, lookup keyLookup) ([]failures.Failure, error) {
+	result := make([]failures.Failure, 0)
+	type lookupState struct {
+		keys    map[string]bool
+		missing bool
+		skip    bool
+	}
+	objects := map[string]*lookupState{}
+	reported := map[string]bool{}
+
+	for _, ref := range refs {
+		if ref.Optional || ref.Name == "" {
+			continue
+		}
+		state, ok := objects[ref.Name]
+		if !ok {
+			keys, err := lookup(ctx, ref.Name)
+			missing, skip, err := lookupResult(err)
+			if err != nil {
+				return result, fmt.Errorf("error retrieving %s %q: %w", kind, ref.Name, err)
+			}
+			state = &lookupState{keys: keys, missing: missing, skip: skip}
+			objects[ref.Name] = state
+		}
+		if state.skip {
+			continue
+		}
+
+		obj := c.objectRef(kind, ref.Name)
+		obj.Container = ref.Container
+		if state.missing {
+			if reported[ref.Name] {
+				continue
+			}
+			reported[ref.Name] = true
+			result = append(result, failures.Failure{
+				Name:        kind + "NotFound",
+				Category:    failures.CategoryRuntime,
+				Summary:     fmt.Sprintf("%s %q does not exist (referenced by %s)", kind, ref.Name, ref.describe()),
+				Remediation: fmt.Sprintf("Create the %s in namespace %q, fix the reference, or mark the reference optional: true.", kind, c.Namespace),
+				Object:      obj,
+				Provider:    failures.ProviderGeneric,
+				Docs:        []string{docs},
+			})
+			continue
+		}
+		if ref.Key == "" || state.keys[ref.Key] {
+			continue
+		}
+		if id := ref.Name + "/" + ref.Key; !reported[id] {
+			reported[id] = true
+			result = append(result, failures.Failure{
+				Name:        kind + "KeyNotFound",
+				Category:    failures.CategoryRuntime,
+				Summary:     fmt.Sprintf("%s %q has no key %q (referenced by %s)", kind, ref.Name, ref.Key, ref.describe()),
+				Remediation: fmt.Sprintf("Add the key to the %s, fix the key name, or mark the reference optional: true.", kind),
+				Object:      obj,
+				Provider:    failures.ProviderGeneric,
+				Docs:        []string{docs},
+			})
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,135 @@
+package preflight
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nullstone-io/deployment-sdk/k8s/failures"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (c Checker) checkServiceAccount(ctx context.Context, spec corev1.PodSpec) ([]failures.Failure, error) {
+	name := spec.ServiceAccountName
+	if name == "" {
+		name = spec.DeprecatedServiceAccount
+	}
+	if name == "" {
+		name = "default"
+	}
+	_, err := c.Client.CoreV1().ServiceAccounts(c.Namespace).Get(ctx, name, metav1.GetOptions{})
+	missing, _, err := lookupResult(err)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving ServiceAccount %q: %w", name, err)
+	}
+	if !missing {
+		return nil, nil
+	}
+	return []failures.Failure{{
+		Name:        "ServiceAccountNotFound",
+		Category:    failures.CategoryAdmission,
+		Summary:     fmt.Sprintf("ServiceAccount %q does not exist; pods will be rejected at admission", name),
+		Remediation: fmt.Sprintf("Create the ServiceAccount in namespace %q or fix serviceAccountName.", c.Namespace),
+		Object:      c.objectRef("ServiceAccount", name),
+		Provider:    failures.ProviderGeneric,
+		Docs:        []string{"https://kubernetes.io/docs/concepts/security/service-accounts/"},
+	}}, nil
+}
+
+func (c Checker) checkPersistentVolumeClaims(ctx context.Context, spec corev1.PodSpec) ([]failures.Failure, error) {
+	result := make([]failures.Failure, 0)
+	for _, v := range spec.Volumes {
+		if v.PersistentVolumeClaim == nil {
+			continue
+		}
+		name := v.PersistentVolumeClaim.ClaimName
+		pvc, err := c.Client.CoreV1().PersistentVolumeClaims(c.Namespace).Get(ctx, name, metav1.GetOptions{})
+		missing, skip, err := lookupResult(err)
+		if err != nil {
+			return result, fmt.Errorf("error retrieving PersistentVolumeClaim %q: %w", name, err)
+		}
+		switch {
+		case skip:
+		case missing:
+			result = append(result, failures.Failure{
+				Name:        "PVCNotFound",
+				Category:    failures.CategoryStorage,
+				Summary:     fmt.Sprintf("PersistentVolumeClaim %q does not exist (referenced by volume %q); pods will stay Pending", name, v.Name),
+				Remediation: "Create the PersistentVolumeClaim or fix claimName.",
+				Object:      c.objectRef("PersistentVolumeClaim", name),
+				Provider:    failures.ProviderGeneric,
+				Docs:        []string{"https://kubernetes.io/docs/concepts/storage/persistent-volumes/"},
+			})
+		case pvc.Status.Phase == corev1.ClaimLost:
+			result = append(result, failures.Failure{
+				Name:        "PVCLost",
+				Category:    failures.CategoryStorage,
+				Summary:     fmt.Sprintf("PersistentVolumeClaim %q lost its underlying volume (referenced by volume %q)", name, v.Name),
+				Remediation: "Restore the PersistentVolume the claim was bound to, or recreate the claim.",
+				Object:      c.objectRef("PersistentVolumeClaim", name),
+				Provider:    failures.ProviderGeneric,
+				Docs:        []string{"https://kubernetes.io/docs/concepts/storage/persistent-volumes/"},
+			})
+		}
+	}
+	return result, nil
+}
+
+func (c Checker) checkImagePullSecrets(ctx context.Context, spec corev1.PodSpec) ([]failures.Failure, error) {
+	result := make([]failures.Failure, 0)
+	for _, ref := range spec.ImagePullSecrets {
+		secret, err := c.Client.CoreV1().Secrets(c.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		missing, skip, err := lookupResult(err)
+		if err != nil {
+			return result, fmt.Errorf("error retrieving image pull secret %q: %w", ref.Name, err)
+		}
+		switch {
+		case skip:
+		case missing:
+			result = append(result, failures.Failure{
+				Name:        "ImagePullSecretNotFound",
+				Category:    failures.CategoryImage,
+				Summary:     fmt.Sprintf("Image pull secret %q does not exist; private images will fail with ImagePullBackOff", ref.Name),
+				Remediation: "Create the docker-registry secret or remove it from imagePullSecrets.",
+				Object:      c.objectRef("Secret", ref.Name),
+				Provider:    failures.ProviderGeneric,
+				Docs:        []string{"https://kubernetes.io/docs/tasks/configure-pod-container/pull-image-private-registry/"},
+			})
+		case secret.Type != corev1.SecretTypeDockerConfigJson && secret.Type != corev1.SecretTypeDockercfg:
+			result = append(result, failures.Failure{
+				Name:        "ImagePullSecretInvalid",
+				Category:    failures.CategoryImage,
+				Summary:     fmt.Sprintf("Image pull secret %q has type %q; the kubelet ignores it when pulling images", ref.Name, secret.Type),
+				Remediation: "Recreate the secret with type kubernetes.io/dockerconfigjson (kubectl create secret docker-registry).",
+				Object:      c.objectRef("Secret", ref.Name),
+				Provider:    failures.ProviderGeneric,
+				Docs:        []string{"https://kubernetes.io/docs/tasks/configure-pod-container/pull-image-private-registry/"},
+			})
+		}
+	}
+	return result, nil
+}
+
+func (c Checker) checkPriorityClass(ctx context.Context, spec corev1.PodSpec) ([]failures.Failure, error) {
+	name := spec.PriorityClassName
+	if name == "" {
+		return nil, nil
+	}
+	_, err := c.Client.SchedulingV1().PriorityClasses().Get(ctx, name, metav1.GetOptions{})
+	missing, _, err := lookupResult(err)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving PriorityClass %q: %w", name, err)
+	}
+	if !missing {
+		return nil, nil
+	}
+	return []failures.Failure{{
+		Name:        "PriorityClassNotFound",
+		Category:    failures.CategoryAdmission,
+		Summary:     fmt.Sprintf("PriorityClass %q does not exist; pods will be rejected at admission", name),
+		Remediation: "Create the PriorityClass or fix priorityClassName.",
+		Object:      failures.ObjectRef{Kind: "PriorityClass", Name: name},
+		Provider:    failures.ProviderGeneric,
+		Docs:        []string{"https://kubernetes.io/docs/concepts/scheduling-eviction/pod-priority-preemption/"},
+	}}, nil
+}
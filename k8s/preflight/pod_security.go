@@ -0,0 +1,174 @@
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nullstone-io/deployment-sdk/k8s/failures"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// PodSecurityEnforceLabel is the namespace label that sets the enforced Pod Security Standard
+	PodSecurityEnforceLabel = "pod-security.kubernetes.io/enforce"
+
+	podSecurityBaseline   = "baseline"
+	podSecurityRestricted = "restricted"
+)
+
+var (
+	// baselineCapabilities are the capabilities the baseline standard allows containers to add
+	baselineCapabilities = map[corev1.Capability]bool{
+		"AUDIT_WRITE": true, "CHOWN": true, "DAC_OVERRIDE": true, "FOWNER": true, "FSETID": true, "KILL": true,
+		"MKNOD": true, "NET_BIND_SERVICE": true, "SETFCAP": true, "SETGID": true, "SETPCAP": true, "SETUID": true, "SYS_CHROOT": true,
+	}
+)
+
+func (c Checker) checkPodSecurity(ctx context.Context, spec corev1.PodSpec) ([]failures.Failure, error) {
+	ns, err := c.Client.CoreV1().Namespaces().Get(ctx, c.Namespace, metav1.GetOptions{})
+	missing, skip, err := lookupResult(err)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving namespace %q: %w", c.Namespace, err)
+	}
+	if missing || skip {
+		return nil, nil
+	}
+	level := ns.Labels[PodSecurityEnforceLabel]
+	violations := podSecurityViolations(level, spec)
+	if len(violations) == 0 {
+		return nil, nil
+	}
+	return []failures.Failure{{
+		Name:     "PodSecurityDenial",
+		Category: failures.CategoryAdmission,
+		Summary: fmt.Sprintf("Namespace %q enforces PodSecurity %q, which the pod template violates: %s",
+			c.Namespace, level, strings.Join(violations, "; ")),
+		Remediation: "Drop disallowed capabilities, set runAsNonRoot/seccompProfile/allowPrivilegeEscalation, remove host namespaces and hostPath, or relax the namespace label.",
+		Object:      failures.ObjectRef{Kind: "Namespace", Name: c.Namespace},
+		Provider:    failures.ProviderGeneric,
+		Docs:        []string{"https://kubernetes.io/docs/concepts/security/pod-security-standards/"},
+	}}, nil
+}
+
+// podSecurityViolations evaluates the most common controls of the Pod Security Standards
+// See https://kubernetes.io/docs/concepts/security/pod-security-standards/
+func podSecurityViolations(level string, spec corev1.PodSpec) []string {
+	if level != podSecurityBaseline && level != podSecurityRestricted {
+		return nil
+	}
+	restricted := level == podSecurityRestricted
+	violations := make([]string, 0)
+
+	// Baseline
+	if spec.HostNetwork {
+		violations = append(violations, "hostNetwork=true")
+	}
+	if spec.HostPID {
+		violations = append(violations, "hostPID=true")
+	}
+	if spec.HostIPC {
+		violations = append(violations, "hostIPC=true")
+	}
+	for _, v := range spec.Volumes {
+		if v.HostPath != nil {
+			violations = append(violations, fmt.Sprintf("volume %q uses hostPath", v.Name))
+		} else if restricted && !isRestrictedVolume(v) {
+			violations = append(violations, fmt.Sprintf("volume %q uses a restricted volume type", v.Name))
+		}
+	}
+
+	podSC := spec.SecurityContext
+	if podSC == nil {
+		podSC = &corev1.PodSecurityContext{}
+	}
+	if isUnconfinedSeccomp(podSC.SeccompProfile) {
+		violations = append(violations, "pod seccompProfile is Unconfined")
+	}
+	for _, c := range allContainers(spec) {
+		sc := c.SecurityContext
+		if sc == nil {
+			sc = &corev1.SecurityContext{}
+		}
+		prefix := fmt.Sprintf("container %q", c.Name)
+		if sc.Privileged != nil && *sc.Privileged {
+			violations = append(violations, prefix+" is privileged")
+		}
+		for _, port := range c.Ports {
+			if port.HostPort != 0 {
+				violations = append(violations, fmt.Sprintf("%s uses hostPort %d", prefix, port.HostPort))
+			}
+		}
+		if isUnconfinedSeccomp(sc.SeccompProfile) {
+			violations = append(violations, prefix+" seccompProfile is Unconfined")
+		}
+		if sc.ProcMount != nil && *sc.ProcMount == corev1.UnmaskedProcMount {
+			violations = append(violations, prefix+" uses an Unmasked procMount")
+		}
+		if sc.Capabilities != nil {
+			for _, capability := range sc.Capabilities.Add {
+				if !baselineCapabilities[capability] || (restricted && capability != "NET_BIND_SERVICE") {
+					violations = append(violations, fmt.Sprintf("%s adds capability %s", prefix, capability))
+				}
+			}
+		}
+		if !restricted {
+			continue
+		}
+
+		// Restricted
+		if sc.AllowPrivilegeEscalation == nil || *sc.AllowPrivilegeEscalation {
+			violations = append(violations, prefix+" must set allowPrivilegeEscalation=false")
+		}
+		if !dropsAllCapabilities(sc.Capabilities) {
+			violations = append(violations, prefix+" must drop ALL capabilities")
+		}
+		if !runsAsNonRoot(podSC, sc) {
+			violations = append(violations, prefix+" must set runAsNonRoot=true")
+		}
+		if (sc.RunAsUser != nil && *sc.RunAsUser == 0) || (sc.RunAsUser == nil && podSC.RunAsUser != nil && *podSC.RunAsUser == 0) {
+			violations = append(violations, prefix+" must not set runAsUser=0")
+		}
+		if !hasSeccompProfile(podSC.SeccompProfile, sc.SeccompProfile) {
+			violations = append(violations, prefix+" must set seccompProfile to RuntimeDefault or Localhost")
+		}
+	}
+	return violations
+}
+
+func isRestrictedVolume(v corev1.Volume) bool {
+	return v.ConfigMap != nil || v.CSI != nil || v.DownwardAPI != nil || v.EmptyDir != nil ||
+		v.Ephemeral != nil || v.PersistentVolumeClaim != nil || v.Projected != nil || v.Secret != nil
+}
+
+func isUnconfinedSeccomp(profile *corev1.SeccompProfile) bool {
+	return profile != nil && profile.Type == corev1.SeccompProfileTypeUnconfined
+}
+
+func hasSeccompProfile(pod, container *corev1.SeccompProfile) bool {
+	profile := container
+	if profile == nil {
+		profile = pod
+	}
+	return profile != nil && (profile.Type == corev1.SeccompProfileTypeRuntimeDefault || profile.Type == corev1.SeccompProfileTypeLocalhost)
+}
+
+func dropsAllCapabilities(caps *corev1.Capabilities) bool {
+	if caps == nil {
+		return false
+	}
+	for _, capability := range caps.Drop {
+		if capability == "ALL" {
+			return true
+		}
+	}
+	return false
+}
+
+func runsAsNonRoot(pod *corev1.PodSecurityContext, container *corev1.SecurityContext) bool {
+	if container.RunAsNonRoot != nil {
+		return *container.RunAsNonRoot
+	}
+	return pod.RunAsNonRoot != nil && *pod.RunAsNonRoot
+}
@@ -0,0 +1,98 @@
+// Package preflight verifies the cluster objects a pod template references before the template is deployed.
+// Problems that kubernetes would only report minutes into a rollout (e.g. CreateContainerConfigError
+// from a missing secret key) are reported up front using the failures.Failure model.
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nullstone-io/deployment-sdk/k8s/failures"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Checker runs the preflight checks for pod templates in a single namespace
+type Checker struct {
+	Client    kubernetes.Interface
+	Namespace string
+}
+
+type check func(ctx context.Context, spec corev1.PodSpec) ([]failures.Failure, error)
+
+// Result holds the problems found by the preflight checks
+type Result struct {
+	// Problems are certain to fail the deployment (e.g. a missing secret key)
+	Problems []failures.Failure
+	// Warnings come from checks that approximate what the cluster evaluates (e.g. Pod Security admission),
+	// so they are likely, but not certain, to fail the deployment
+	Warnings []failures.Failure
+}
+
+// Check verifies the objects referenced by the pod template:
+// ConfigMaps and Secrets (including keys), the ServiceAccount, PVCs, image pull secrets,
+// and the PriorityClass; the namespace's PodSecurity level is evaluated as a warning.
+// A check is skipped when the deployer is not allowed to read the objects it needs.
+func (c Checker) Check(ctx context.Context, template corev1.PodTemplateSpec) (Result, error) {
+	checks := []check{
+		c.checkConfigMaps,
+		c.checkSecrets,
+		c.checkServiceAccount,
+		c.checkPersistentVolumeClaims,
+		c.checkImagePullSecrets,
+		c.checkPriorityClass,
+	}
+	heuristicChecks := []check{
+		c.checkPodSecurity,
+	}
+	result := Result{Problems: make([]failures.Failure, 0), Warnings: make([]failures.Failure, 0)}
+	for _, fn := range checks {
+		found, err := fn(ctx, template.Spec)
+		if err != nil {
+			return result, err
+		}
+		result.Problems = append(result.Problems, found...)
+	}
+	for _, fn := range heuristicChecks {
+		found, err := fn(ctx, template.Spec)
+		if err != nil {
+			return result, err
+		}
+		result.Warnings = append(result.Warnings, found...)
+	}
+	return result, nil
+}
+
+// Error is returned when preflight checks find problems that would fail a deployment
+type Error struct {
+	Failures []failures.Failure
+}
+
+func (e Error) Error() string {
+	names := make([]string, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		names = append(names, f.Name)
+	}
+	return fmt.Sprintf("preflight checks failed (%s)", strings.Join(names, ", "))
+}
+
+// lookupResult interprets the error from retrieving a referenced object
+// missing is true when the object does not exist
+// skip is true when the deployer is not allowed to read the object; this should not block a deploy
+func lookupResult(err error) (missing bool, skip bool, rerr error) {
+	switch {
+	case err == nil:
+		return false, false, nil
+	case apierrors.IsNotFound(err):
+		return true, false, nil
+	case apierrors.IsForbidden(err), apierrors.IsUnauthorized(err):
+		return false, true, nil
+	}
+	return false, false, err
+}
+
+func (c Checker) objectRef(kind, name string) failures.ObjectRef {
+	return failures.ObjectRef{Kind: kind, Namespace: c.Namespace, Name: name}
+}
@@ -0,0 +1,223 @@
+package preflight
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nullstone-io/deployment-sdk/k8s/failures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestChecker_Check(t *testing.T) {
+	namespace := func(labels map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app", Labels: labels}}
+	}
+	existing := []runtime.Object{
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "default"}},
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "api"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "settings"}, Data: map[string]string{"LOG_LEVEL": "info"}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "db"}, Data: map[string][]byte{"username": []byte("app")}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "registry"}, Type: corev1.SecretTypeDockerConfigJson},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "not-registry"}, Type: corev1.SecretTypeOpaque},
+		&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "data"}, Status: corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound}},
+		&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "lost"}, Status: corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimLost}},
+		&schedulingv1.PriorityClass{ObjectMeta: metav1.ObjectMeta{Name: "high"}},
+	}
+	secretKey := func(name, key string, optional bool) *corev1.EnvVarSource {
+		return &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: name},
+			Key:                  key,
+			Optional:             &optional,
+		}}
+	}
+
+	tests := []struct {
+		name         string
+		namespace    *corev1.Namespace
+		spec         corev1.PodSpec
+		want         []string
+		wantWarnings []string
+	}{
+		{
+			name:      "all references exist",
+			namespace: namespace(nil),
+			spec: corev1.PodSpec{
+				ServiceAccountName: "api",
+				PriorityClassName:  "high",
+				ImagePullSecrets:   []corev1.LocalObjectReference{{Name: "registry"}},
+				Containers: []corev1.Container{{
+					Name: "app",
+					Env: []corev1.EnvVar{
+						{Name: "DB_USER", ValueFrom: secretKey("db", "username", false)},
+						{Name: "LOG_LEVEL", ValueFrom: &corev1.EnvVarSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "settings"},
+							Key:                  "LOG_LEVEL",
+						}}},
+					},
+				}},
+				Volumes: []corev1.Volume{
+					{Name: "data", VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "data"}}},
+				},
+			},
+			want: []string{},
+		},
+		{
+			name:      "missing secret key",
+			namespace: namespace(nil),
+			spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name: "app",
+					Env: []corev1.EnvVar{
+						{Name: "DB_PASSWORD", ValueFrom: secretKey("db", "password", false)},
+						{Name: "DB_PASSWORD_AGAIN", ValueFrom: secretKey("db", "password", false)},
+						{Name: "OPTIONAL", ValueFrom: secretKey("db", "other", true)},
+					},
+				}},
+			},
+			want: []string{"SecretKeyNotFound"},
+		},
+		{
+			name:      "missing objects",
+			namespace: namespace(nil),
+			spec: corev1.PodSpec{
+				ServiceAccountName: "worker",
+				PriorityClassName:  "urgent",
+				ImagePullSecrets:   []corev1.LocalObjectReference{{Name: "missing-registry"}, {Name: "not-registry"}},
+				Containers: []corev1.Container{{
+					Name: "app",
+					EnvFrom: []corev1.EnvFromSource{
+						{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "missing-settings"}}},
+					},
+				}},
+				Volumes: []corev1.Volume{
+					{Name: "certs", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "certs"}}},
+					{Name: "scratch", VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "scratch"}}},
+					{Name: "lost", VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "lost"}}},
+				},
+			},
+			want: []string{
+				"ConfigMapNotFound",
+				"SecretNotFound",
+				"ServiceAccountNotFound",
+				"PVCNotFound",
+				"PVCLost",
+				"ImagePullSecretNotFound",
+				"ImagePullSecretInvalid",
+				"PriorityClassNotFound",
+			},
+		},
+		{
+			name:      "pod security",
+			namespace: namespace(map[string]string{PodSecurityEnforceLabel: "baseline"}),
+			spec: corev1.PodSpec{
+				HostNetwork: true,
+				Containers:  []corev1.Container{{Name: "app"}},
+			},
+			want:         []string{},
+			wantWarnings: []string{"PodSecurityDenial"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset(append(existing, test.namespace)...)
+			checker := Checker{Client: client, Namespace: "app"}
+			result, err := checker.Check(context.Background(), corev1.PodTemplateSpec{Spec: test.spec})
+			require.NoError(t, err)
+			assert.Equal(t, test.want, failureNames(result.Problems))
+			assert.ElementsMatch(t, test.wantWarnings, failureNames(result.Warnings))
+		})
+	}
+}
+
+func failureNames(found []failures.Failure) []string {
+	names := make([]string, 0)
+	for _, f := range found {
+		names = append(names, f.Name)
+	}
+	return names
+}
+
+func TestPodSecurityViolations(t *testing.T) {
+	yes, no := true, false
+	compliant := corev1.PodSpec{
+		SecurityContext: &corev1.PodSecurityContext{
+			RunAsNonRoot:   &yes,
+			SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+		},
+		Containers: []corev1.Container{{
+			Name: "app",
+			SecurityContext: &corev1.SecurityContext{
+				AllowPrivilegeEscalation: &no,
+				Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}, Add: []corev1.Capability{"NET_BIND_SERVICE"}},
+			},
+		}},
+	}
+
+	tests := []struct {
+		name  string
+		level string
+		spec  corev1.PodSpec
+		want  []string
+	}{
+		{
+			name:  "privileged level",
+			level: "privileged",
+			spec:  corev1.PodSpec{HostPID: true, Containers: []corev1.Container{{Name: "app"}}},
+			want:  nil,
+		},
+		{
+			name:  "baseline allows defaults",
+			level: "baseline",
+			spec:  corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+			want:  []string{},
+		},
+		{
+			name:  "baseline",
+			level: "baseline",
+			spec: corev1.PodSpec{
+				Volumes: []corev1.Volume{{Name: "docker", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/run/docker.sock"}}}},
+				Containers: []corev1.Container{{
+					Name:            "app",
+					Ports:           []corev1.ContainerPort{{ContainerPort: 80, HostPort: 80}},
+					SecurityContext: &corev1.SecurityContext{Privileged: &yes, Capabilities: &corev1.Capabilities{Add: []corev1.Capability{"SYS_ADMIN"}}},
+				}},
+			},
+			want: []string{
+				`volume "docker" uses hostPath`,
+				`container "app" is privileged`,
+				`container "app" uses hostPort 80`,
+				`container "app" adds capability SYS_ADMIN`,
+			},
+		},
+		{
+			name:  "restricted compliant",
+			level: "restricted",
+			spec:  compliant,
+			want:  []string{},
+		},
+		{
+			name:  "restricted defaults",
+			level: "restricted",
+			spec:  corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+			want: []string{
+				`container "app" must set allowPrivilegeEscalation=false`,
+				`container "app" must drop ALL capabilities`,
+				`container "app" must set runAsNonRoot=true`,
+				`container "app" must set seccompProfile to RuntimeDefault or Localhost`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, podSecurityViolations(test.level, test.spec))
+		})
+	}
+}
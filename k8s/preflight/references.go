@@ -0,0 +1,133 @@
+package preflight
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// reference is a pod template's reference to a ConfigMap or Secret (or one of its keys)
+type reference struct {
+	Name string
+	// Key is empty when the whole object is referenced (envFrom, volumes without items)
+	Key      string
+	Optional bool
+	// Container is the container that holds the reference; empty for volumes
+	Container string
+	// Source describes where the reference lives (e.g. `env "DB_PASSWORD"`, `volume "config"`)
+	Source string
+}
+
+func (r reference) describe() string {
+	if r.Container == "" {
+		return r.Source
+	}
+	return fmt.Sprintf("container %q %s", r.Container, r.Source)
+}
+
+// allContainers returns the init containers followed by the app containers
+func allContainers(spec corev1.PodSpec) []corev1.Container {
+	containers := make([]corev1.Container, 0, len(spec.InitContainers)+len(spec.Containers))
+	containers = append(containers, spec.InitContainers...)
+	return append(containers, spec.Containers...)
+}
+
+func configMapReferences(spec corev1.PodSpec) []reference {
+	refs := make([]reference, 0)
+	for _, c := range allContainers(spec) {
+		for _, env := range c.Env {
+			if env.ValueFrom == nil || env.ValueFrom.ConfigMapKeyRef == nil {
+				continue
+			}
+			ref := env.ValueFrom.ConfigMapKeyRef
+			refs = append(refs, reference{
+				Name:      ref.Name,
+				Key:       ref.Key,
+				Optional:  isOptional(ref.Optional),
+				Container: c.Name,
+				Source:    fmt.Sprintf("env %q", env.Name),
+			})
+		}
+		for _, envFrom := range c.EnvFrom {
+			if envFrom.ConfigMapRef == nil {
+				continue
+			}
+			refs = append(refs, reference{
+				Name:      envFrom.ConfigMapRef.Name,
+				Optional:  isOptional(envFrom.ConfigMapRef.Optional),
+				Container: c.Name,
+				Source:    "envFrom",
+			})
+		}
+	}
+	for _, v := range spec.Volumes {
+		if v.ConfigMap != nil {
+			refs = append(refs, volumeReferences(v.Name, v.ConfigMap.Name, v.ConfigMap.Items, isOptional(v.ConfigMap.Optional))...)
+		}
+		if v.Projected != nil {
+			for _, source := range v.Projected.Sources {
+				if source.ConfigMap != nil {
+					refs = append(refs, volumeReferences(v.Name, source.ConfigMap.Name, source.ConfigMap.Items, isOptional(source.ConfigMap.Optional))...)
+				}
+			}
+		}
+	}
+	return refs
+}
+
+func secretReferences(spec corev1.PodSpec) []reference {
+	refs := make([]reference, 0)
+	for _, c := range allContainers(spec) {
+		for _, env := range c.Env {
+			if env.ValueFrom == nil || env.ValueFrom.SecretKeyRef == nil {
+				continue
+			}
+			ref := env.ValueFrom.SecretKeyRef
+			refs = append(refs, reference{
+				Name:      ref.Name,
+				Key:       ref.Key,
+				Optional:  isOptional(ref.Optional),
+				Container: c.Name,
+				Source:    fmt.Sprintf("env %q", env.Name),
+			})
+		}
+		for _, envFrom := range c.EnvFrom {
+			if envFrom.SecretRef == nil {
+				continue
+			}
+			refs = append(refs, reference{
+				Name:      envFrom.SecretRef.Name,
+				Optional:  isOptional(envFrom.SecretRef.Optional),
+				Container: c.Name,
+				Source:    "envFrom",
+			})
+		}
+	}
+	for _, v := range spec.Volumes {
+		if v.Secret != nil {
+			refs = append(refs, volumeReferences(v.Name, v.Secret.SecretName, v.Secret.Items, isOptional(v.Secret.Optional))...)
+		}
+		if v.Projected != nil {
+			for _, source := range v.Projected.Sources {
+				if source.Secret != nil {
+					refs = append(refs, volumeReferences(v.Name, source.Secret.Name, source.Secret.Items, isOptional(source.Secret.Optional))...)
+				}
+			}
+		}
+	}
+	return refs
+}
+
+// volumeReferences references the whole object, plus each key that is projected into the volume
+func volumeReferences(volumeName, objectName string, items []corev1.KeyToPath, optional bool) []reference {
+	source := fmt.Sprintf("volume %q", volumeName)
+	refs := []reference{{Name: objectName, Optional: optional, Source: source}}
+	for _, item := range items {
+		refs = append(refs, reference{Name: objectName, Key: item.Key, Optional: optional, Source: source})
+	}
+	return refs
+}
+
+func isOptional(optional *bool) bool {
+	return optional != nil && *optional
+}
@@ -10,6 +10,8 @@ import (
 const (
 	// RevisionAnnotation is the revision annotation of a deployment's replica sets which records its rollout sequence
 	RevisionAnnotation = "deployment.kubernetes.io/revision"
+	// DaemonSetRevisionAnnotation records the template generation of a daemon set
+	DaemonSetRevisionAnnotation = "deprecated.daemonset.template.generation"
 )
 
 func Revision(obj runtime.Object) (int64, error) {
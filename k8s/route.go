@@ -0,0 +1,399 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	RouteKindIngress   = "Ingress"
+	RouteKindHTTPRoute = "HTTPRoute"
+
+	// NegReadinessGate is the readiness gate GKE injects into pods backed by container-native load balancing (NEGs)
+	NegReadinessGate = "cloud.google.com/load-balancer-neg-ready"
+	// AwsTargetHealthGatePrefix prefixes the readiness gates the AWS Load Balancer Controller injects for target health
+	AwsTargetHealthGatePrefix = "target-health.elbv2.k8s.aws/"
+)
+
+var (
+	// HTTPRouteGVR and GatewayGVR identify Gateway API objects; there is no typed client for them, so they are accessed through the dynamic client
+	HTTPRouteGVR = schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "httproutes"}
+	GatewayGVR   = schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "gateways"}
+)
+
+// AppStatusRoute reports an Ingress or Gateway API HTTPRoute that sends traffic to the app's Service
+type AppStatusRoute struct {
+	// Kind is Ingress or HTTPRoute
+	Kind  string   `json:"kind"`
+	Name  string   `json:"name"`
+	Hosts []string `json:"hosts"`
+	// Addresses are the load balancer IPs/hostnames of the Ingress or of the HTTPRoute's parent Gateways
+	Addresses []string `json:"addresses"`
+	// Parents reports whether each parent Gateway accepted the HTTPRoute (HTTPRoute only)
+	Parents []AppStatusRouteParent `json:"parents,omitempty"`
+	// Ready is true when the route has an address and (for HTTPRoutes) every parent accepted it and resolved its backends
+	Ready bool `json:"ready"`
+	// Failed is true when the route was rejected; it will not become ready without a configuration change
+	Failed bool `json:"failed,omitempty"`
+	// Message explains why the route is not ready
+	Message string `json:"message,omitempty"`
+}
+
+// ObjectRef formats the route the way kubectl refers to it (e.g. "httproute/api")
+func (r AppStatusRoute) ObjectRef() string {
+	return fmt.Sprintf("%s/%s", strings.ToLower(r.Kind), r.Name)
+}
+
+type AppStatusRouteParent struct {
+	// Gateway is the parent Gateway as namespace/name
+	Gateway      string `json:"gateway"`
+	Accepted     bool   `json:"accepted"`
+	ResolvedRefs bool   `json:"resolvedRefs"`
+	Reason       string `json:"reason,omitempty"`
+	Message      string `json:"message,omitempty"`
+}
+
+// AppStatusReadinessGate summarizes a pod readiness gate across the app's pods
+// Load balancer controllers use readiness gates to hold pods out of service until they are healthy load balancer targets
+type AppStatusReadinessGate struct {
+	ConditionType string `json:"conditionType"`
+	// Provider is "gke-neg", "aws-load-balancer-controller", or "" for other controllers
+	Provider  string `json:"provider,omitempty"`
+	ReadyPods int    `json:"readyPods"`
+	TotalPods int    `json:"totalPods"`
+}
+
+// LoadAppRoutes finds the Ingresses and HTTPRoutes in the namespace that send traffic to any of the given Services
+// Clusters without the Gateway API CRDs (or without permission to read them) report only Ingresses
+func LoadAppRoutes(ctx context.Context, client kubernetes.Interface, dyn dynamic.Interface, namespace string, serviceNames []string) ([]AppStatusRoute, error) {
+	result := make([]AppStatusRoute, 0)
+
+	ingresses, err := client.NetworkingV1().Ingresses(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil && !isOptionalResourceError(err) {
+		return nil, fmt.Errorf("error retrieving ingresses: %w", err)
+	}
+	if err == nil {
+		for _, ing := range ingresses.Items {
+			if IngressTargetsService(ing, serviceNames) {
+				result = append(result, AppStatusRouteFromIngress(ing))
+			}
+		}
+	}
+
+	routes, err := dyn.Resource(HTTPRouteGVR).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if isOptionalResourceError(err) {
+			return result, nil
+		}
+		return nil, fmt.Errorf("error retrieving http routes: %w", err)
+	}
+	gateways := map[string]*unstructured.Unstructured{}
+	for _, route := range routes.Items {
+		if !HTTPRouteTargetsService(route, serviceNames) {
+			continue
+		}
+		for _, ref := range httpRouteParentGateways(route) {
+			if _, ok := gateways[ref]; ok {
+				continue
+			}
+			ns, name, _ := strings.Cut(ref, "/")
+			gw, err := dyn.Resource(GatewayGVR).Namespace(ns).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				if !isOptionalResourceError(err) {
+					return nil, fmt.Errorf("error retrieving gateway %q: %w", ref, err)
+				}
+				gw = nil
+			}
+			gateways[ref] = gw
+		}
+		result = append(result, AppStatusRouteFromHTTPRoute(route, gateways))
+	}
+	return result, nil
+}
+
+// isOptionalResourceError is true when an object type is not installed in the cluster or the caller may not read it
+func isOptionalResourceError(err error) bool {
+	return apierrors.IsNotFound(err) || apierrors.IsForbidden(err)
+}
+
+// IngressTargetsService reports whether any of the Ingress's backends is one of the given Services
+func IngressTargetsService(ing networkingv1.Ingress, serviceNames []string) bool {
+	targets := func(backend *networkingv1.IngressBackend) bool {
+		return backend != nil && backend.Service != nil && containsString(serviceNames, backend.Service.Name)
+	}
+	if targets(ing.Spec.DefaultBackend) {
+		return true
+	}
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			if targets(&path.Backend) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func AppStatusRouteFromIngress(ing networkingv1.Ingress) AppStatusRoute {
+	result := AppStatusRoute{
+		Kind:      RouteKindIngress,
+		Name:      ing.Name,
+		Hosts:     make([]string, 0),
+		Addresses: make([]string, 0),
+	}
+	for _, rule := range ing.Spec.Rules {
+		if rule.Host != "" {
+			result.Hosts = append(result.Hosts, rule.Host)
+		}
+	}
+	for _, lb := range ing.Status.LoadBalancer.Ingress {
+		if lb.Hostname != "" {
+			result.Addresses = append(result.Addresses, lb.Hostname)
+		} else if lb.IP != "" {
+			result.Addresses = append(result.Addresses, lb.IP)
+		}
+	}
+	result.Ready = len(result.Addresses) > 0
+	if !result.Ready {
+		result.Message = "Waiting for the ingress controller to assign a load balancer address"
+	}
+	return result
+}
+
+// HTTPRouteTargetsService reports whether any of the HTTPRoute's backendRefs is one of the given Services in the route's namespace
+func HTTPRouteTargetsService(route unstructured.Unstructured, serviceNames []string) bool {
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	for _, rule := range rules {
+		ruleMap, ok := rule.(map[string]any)
+		if !ok {
+			continue
+		}
+		refs, _, _ := unstructured.NestedSlice(ruleMap, "backendRefs")
+		for _, ref := range refs {
+			refMap, ok := ref.(map[string]any)
+			if !ok {
+				continue
+			}
+			group, _, _ := unstructured.NestedString(refMap, "group")
+			kind, _, _ := unstructured.NestedString(refMap, "kind")
+			ns, _, _ := unstructured.NestedString(refMap, "namespace")
+			name, _, _ := unstructured.NestedString(refMap, "name")
+			if group != "" || (kind != "" && kind != "Service") || (ns != "" && ns != route.GetNamespace()) {
+				continue
+			}
+			if containsString(serviceNames, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// httpRouteParentGateways returns the parent Gateways of an HTTPRoute as namespace/name
+func httpRouteParentGateways(route unstructured.Unstructured) []string {
+	result := make([]string, 0)
+	refs, _, _ := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+	for _, ref := range refs {
+		refMap, ok := ref.(map[string]any)
+		if !ok {
+			continue
+		}
+		if kind, _, _ := unstructured.NestedString(refMap, "kind"); kind != "" && kind != "Gateway" {
+			continue
+		}
+		key := parentRefKey(refMap, route.GetNamespace())
+		if !containsString(result, key) {
+			result = append(result, key)
+		}
+	}
+	return result
+}
+
+func parentRefKey(ref map[string]any, defaultNamespace string) string {
+	ns, _, _ := unstructured.NestedString(ref, "namespace")
+	if ns == "" {
+		ns = defaultNamespace
+	}
+	name, _, _ := unstructured.NestedString(ref, "name")
+	return fmt.Sprintf("%s/%s", ns, name)
+}
+
+// AppStatusRouteFromHTTPRoute evaluates an HTTPRoute against its parent Gateways (keyed by namespace/name; nil if missing)
+// Route conditions are only trusted once the gateway controller has observed the route's current generation
+func AppStatusRouteFromHTTPRoute(route unstructured.Unstructured, gateways map[string]*unstructured.Unstructured) AppStatusRoute {
+	result := AppStatusRoute{
+		Kind:      RouteKindHTTPRoute,
+		Name:      route.GetName(),
+		Addresses: make([]string, 0),
+		Parents:   make([]AppStatusRouteParent, 0),
+	}
+	result.Hosts, _, _ = unstructured.NestedStringSlice(route.Object, "spec", "hostnames")
+	if result.Hosts == nil {
+		result.Hosts = make([]string, 0)
+	}
+
+	statuses := map[string]map[string]any{}
+	parentStatuses, _, _ := unstructured.NestedSlice(route.Object, "status", "parents")
+	for _, ps := range parentStatuses {
+		psMap, ok := ps.(map[string]any)
+		if !ok {
+			continue
+		}
+		ref, _, _ := unstructured.NestedMap(psMap, "parentRef")
+		statuses[parentRefKey(ref, route.GetNamespace())] = psMap
+	}
+
+	var pending, failed []string
+	for _, key := range httpRouteParentGateways(route) {
+		parent := AppStatusRouteParent{Gateway: key}
+		status, ok := statuses[key]
+		if !ok {
+			pending = append(pending, fmt.Sprintf("Waiting for gateway %s to accept the route", key))
+			result.Parents = append(result.Parents, parent)
+			continue
+		}
+		conditions, _, _ := unstructured.NestedSlice(status, "conditions")
+		accepted := findUnstructuredCondition(conditions, "Accepted")
+		resolved := findUnstructuredCondition(conditions, "ResolvedRefs")
+		for _, cond := range []*unstructuredCondition{accepted, resolved} {
+			switch {
+			case cond == nil || cond.ObservedGeneration < route.GetGeneration():
+			case cond.Status == string(corev1.ConditionFalse):
+				parent.Reason, parent.Message = cond.Reason, cond.Message
+				failed = append(failed, fmt.Sprintf("Gateway %s reports %s=False (%s): %s", key, cond.Type, cond.Reason, cond.Message))
+			case cond.Status == string(corev1.ConditionTrue) && cond.Type == "Accepted":
+				parent.Accepted = true
+			case cond.Status == string(corev1.ConditionTrue) && cond.Type == "ResolvedRefs":
+				parent.ResolvedRefs = true
+			}
+		}
+		if parent.Reason == "" && (!parent.Accepted || !parent.ResolvedRefs) {
+			pending = append(pending, fmt.Sprintf("Waiting for gateway %s to accept the route", key))
+		}
+		result.Parents = append(result.Parents, parent)
+
+		gw := gateways[key]
+		if gw == nil {
+			pending = append(pending, fmt.Sprintf("Gateway %s was not found", key))
+			continue
+		}
+		addresses, _, _ := unstructured.NestedSlice(gw.Object, "status", "addresses")
+		for _, addr := range addresses {
+			if addrMap, ok := addr.(map[string]any); ok {
+				if val, _, _ := unstructured.NestedString(addrMap, "value"); val != "" && !containsString(result.Addresses, val) {
+					result.Addresses = append(result.Addresses, val)
+				}
+			}
+		}
+		gwConditions, _, _ := unstructured.NestedSlice(gw.Object, "status", "conditions")
+		if programmed := findUnstructuredCondition(gwConditions, "Programmed"); programmed != nil && programmed.Status == string(corev1.ConditionFalse) {
+			pending = append(pending, fmt.Sprintf("Gateway %s is not programmed (%s): %s", key, programmed.Reason, programmed.Message))
+		}
+	}
+	if len(failed) == 0 && len(pending) == 0 && len(result.Addresses) == 0 {
+		pending = append(pending, "Waiting for the gateway to be assigned an address")
+	}
+
+	switch {
+	case len(failed) > 0:
+		result.Failed = true
+		result.Message = strings.Join(failed, "; ")
+	case len(pending) > 0:
+		result.Message = strings.Join(pending, "; ")
+	default:
+		result.Ready = true
+	}
+	return result
+}
+
+type unstructuredCondition struct {
+	Type               string
+	Status             string
+	Reason             string
+	Message            string
+	ObservedGeneration int64
+}
+
+func findUnstructuredCondition(conditions []any, condType string) *unstructuredCondition {
+	for _, c := range conditions {
+		m, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		if t, _, _ := unstructured.NestedString(m, "type"); t != condType {
+			continue
+		}
+		cond := &unstructuredCondition{Type: condType}
+		cond.Status, _, _ = unstructured.NestedString(m, "status")
+		cond.Reason, _, _ = unstructured.NestedString(m, "reason")
+		cond.Message, _, _ = unstructured.NestedString(m, "message")
+		switch v := m["observedGeneration"].(type) {
+		case int64:
+			cond.ObservedGeneration = v
+		case float64:
+			cond.ObservedGeneration = int64(v)
+		}
+		return cond
+	}
+	return nil
+}
+
+// AppStatusReadinessGatesFromPods summarizes the readiness gates on running pods that are not terminating
+func AppStatusReadinessGatesFromPods(pods []corev1.Pod) []AppStatusReadinessGate {
+	result := make([]AppStatusReadinessGate, 0)
+	index := map[string]int{}
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		for _, gate := range pod.Spec.ReadinessGates {
+			condType := string(gate.ConditionType)
+			i, ok := index[condType]
+			if !ok {
+				i = len(result)
+				index[condType] = i
+				result = append(result, AppStatusReadinessGate{ConditionType: condType, Provider: readinessGateProvider(condType)})
+			}
+			result[i].TotalPods++
+			for _, cond := range pod.Status.Conditions {
+				if cond.Type == gate.ConditionType && cond.Status == corev1.ConditionTrue {
+					result[i].ReadyPods++
+					break
+				}
+			}
+		}
+	}
+	return result
+}
+
+func readinessGateProvider(condType string) string {
+	switch {
+	case condType == NegReadinessGate:
+		return "gke-neg"
+	case strings.HasPrefix(condType, AwsTargetHealthGatePrefix):
+		return "aws-load-balancer-controller"
+	}
+	return ""
+}
+
+func containsString(items []string, s string) bool {
+	for _, item := range items {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,169 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestAppStatusRouteFromIngress(t *testing.T) {
+	ing := networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "api"},
+		Spec: networkingv1.IngressSpec{
+			Rules: []networkingv1.IngressRule{
+				{
+					Host: "api.example.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{
+								{Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "api"}}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	assert.True(t, IngressTargetsService(ing, []string{"api"}))
+	assert.False(t, IngressTargetsService(ing, []string{"web"}))
+
+	got := AppStatusRouteFromIngress(ing)
+	assert.Equal(t, AppStatusRoute{
+		Kind:      RouteKindIngress,
+		Name:      "api",
+		Hosts:     []string{"api.example.com"},
+		Addresses: []string{},
+		Message:   "Waiting for the ingress controller to assign a load balancer address",
+	}, got)
+
+	ing.Status.LoadBalancer.Ingress = []networkingv1.IngressLoadBalancerIngress{{Hostname: "k8s-api-123.us-east-1.elb.amazonaws.com"}}
+	got = AppStatusRouteFromIngress(ing)
+	assert.True(t, got.Ready)
+	assert.Equal(t, []string{"k8s-api-123.us-east-1.elb.amazonaws.com"}, got.Addresses)
+}
+
+func TestAppStatusRouteFromHTTPRoute(t *testing.T) {
+	route := func(conditions ...any) unstructured.Unstructured {
+		obj := unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "gateway.networking.k8s.io/v1",
+			"kind":       "HTTPRoute",
+			"metadata":   map[string]any{"name": "api", "namespace": "apps", "generation": int64(2)},
+			"spec": map[string]any{
+				"hostnames":  []any{"api.example.com"},
+				"parentRefs": []any{map[string]any{"name": "public", "namespace": "infra"}},
+				"rules": []any{
+					map[string]any{"backendRefs": []any{map[string]any{"name": "api", "port": int64(80)}}},
+				},
+			},
+		}}
+		if len(conditions) > 0 {
+			obj.Object["status"] = map[string]any{
+				"parents": []any{
+					map[string]any{
+						"parentRef":  map[string]any{"name": "public", "namespace": "infra"},
+						"conditions": conditions,
+					},
+				},
+			}
+		}
+		return obj
+	}
+	condition := func(condType, status, reason string, generation int64) map[string]any {
+		return map[string]any{"type": condType, "status": status, "reason": reason, "message": reason, "observedGeneration": generation}
+	}
+	gateway := &unstructured.Unstructured{Object: map[string]any{
+		"status": map[string]any{
+			"addresses":  []any{map[string]any{"type": "IPAddress", "value": "34.1.2.3"}},
+			"conditions": []any{map[string]any{"type": "Programmed", "status": "True"}},
+		},
+	}}
+	gateways := map[string]*unstructured.Unstructured{"infra/public": gateway}
+
+	assert.True(t, HTTPRouteTargetsService(route(), []string{"api"}))
+	assert.False(t, HTTPRouteTargetsService(route(), []string{"web"}))
+
+	tests := []struct {
+		name        string
+		route       unstructured.Unstructured
+		gateways    map[string]*unstructured.Unstructured
+		wantReady   bool
+		wantFailed  bool
+		wantMessage string
+	}{
+		{
+			name:        "not accepted yet",
+			route:       route(),
+			gateways:    gateways,
+			wantMessage: "Waiting for gateway infra/public to accept the route",
+		},
+		{
+			name:      "accepted",
+			route:     route(condition("Accepted", "True", "Accepted", 2), condition("ResolvedRefs", "True", "ResolvedRefs", 2)),
+			gateways:  gateways,
+			wantReady: true,
+		},
+		{
+			name:        "stale conditions",
+			route:       route(condition("Accepted", "True", "Accepted", 1), condition("ResolvedRefs", "False", "BackendNotFound", 1)),
+			gateways:    gateways,
+			wantMessage: "Waiting for gateway infra/public to accept the route",
+		},
+		{
+			name:        "backend not found",
+			route:       route(condition("Accepted", "True", "Accepted", 2), condition("ResolvedRefs", "False", "BackendNotFound", 2)),
+			gateways:    gateways,
+			wantFailed:  true,
+			wantMessage: "Gateway infra/public reports ResolvedRefs=False (BackendNotFound): BackendNotFound",
+		},
+		{
+			name:        "gateway missing",
+			route:       route(condition("Accepted", "True", "Accepted", 2), condition("ResolvedRefs", "True", "ResolvedRefs", 2)),
+			gateways:    map[string]*unstructured.Unstructured{"infra/public": nil},
+			wantMessage: "Gateway infra/public was not found",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := AppStatusRouteFromHTTPRoute(test.route, test.gateways)
+			assert.Equal(t, test.wantReady, got.Ready)
+			assert.Equal(t, test.wantFailed, got.Failed)
+			assert.Equal(t, test.wantMessage, got.Message)
+			assert.Equal(t, []string{"api.example.com"}, got.Hosts)
+		})
+	}
+}
+
+func TestAppStatusReadinessGatesFromPods(t *testing.T) {
+	pod := func(ready bool, gates ...string) corev1.Pod {
+		p := corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodRunning}}
+		for _, gate := range gates {
+			p.Spec.ReadinessGates = append(p.Spec.ReadinessGates, corev1.PodReadinessGate{ConditionType: corev1.PodConditionType(gate)})
+			status := corev1.ConditionFalse
+			if ready {
+				status = corev1.ConditionTrue
+			}
+			p.Status.Conditions = append(p.Status.Conditions, corev1.PodCondition{Type: corev1.PodConditionType(gate), Status: status})
+		}
+		return p
+	}
+	awsGate := AwsTargetHealthGatePrefix + "k8s-apps-api-1234"
+	terminating := pod(false, NegReadinessGate)
+	terminating.DeletionTimestamp = &metav1.Time{}
+
+	got := AppStatusReadinessGatesFromPods([]corev1.Pod{
+		pod(true, NegReadinessGate),
+		pod(false, NegReadinessGate),
+		terminating,
+		pod(true, awsGate),
+		pod(true),
+	})
+	assert.Equal(t, []AppStatusReadinessGate{
+		{ConditionType: NegReadinessGate, Provider: "gke-neg", ReadyPods: 1, TotalPods: 2},
+		{ConditionType: awsGate, Provider: "aws-load-balancer-controller", ReadyPods: 1, TotalPods: 1},
+	}, got)
+}
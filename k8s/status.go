@@ -28,6 +28,14 @@ type AppStatus struct {
 	DeploymentName string                  `json:"deploymentName"`
 	ReplicaSets    []AppStatusReplicaSet   `json:"replicaSets"`
 	Jobs           []AppStatusJobExecution `json:"jobs"`
+	// Workloads reports StatefulSets, DaemonSets, and Argo Rollouts (Deployments are reported through ReplicaSets)
+	Workloads []AppStatusWorkload `json:"workloads,omitempty"`
+	// Autoscaler reports the HorizontalPodAutoscaler that scales the app's workload; nil if the app is not autoscaled
+	Autoscaler *AppStatusAutoscaler `json:"autoscaler,omitempty"`
+	// Routes reports the Ingresses and Gateway API HTTPRoutes that send traffic to the app's Services
+	Routes []AppStatusRoute `json:"routes,omitempty"`
+	// ReadinessGates summarizes pod readiness gates (e.g. GKE NEG readiness, AWS Load Balancer Controller target health)
+	ReadinessGates []AppStatusReadinessGate `json:"readinessGates,omitempty"`
 	// Failures aggregates rollout-level failures (Deployment ProgressDeadlineExceeded,
 	// ReplicaFailure conditions). Container and pod-level failures live on their
 	// respective entries inside ReplicaSets.
@@ -0,0 +1,185 @@
+package k8s
+
+import (
+	"fmt"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// AppStatusAutoscaler reports a HorizontalPodAutoscaler that scales the app's workload
+type AppStatusAutoscaler struct {
+	Name            string `json:"name"`
+	MinReplicas     int    `json:"minReplicas"`
+	MaxReplicas     int    `json:"maxReplicas"`
+	CurrentReplicas int    `json:"currentReplicas"`
+	DesiredReplicas int    `json:"desiredReplicas"`
+	// ScalingLimited is true when the desired replica count was clamped to MinReplicas or MaxReplicas
+	ScalingLimited bool `json:"scalingLimited"`
+	// Message explains why the autoscaler cannot compute a scale (e.g. metrics are unavailable)
+	Message string                      `json:"message,omitempty"`
+	Metrics []AppStatusAutoscalerMetric `json:"metrics"`
+}
+
+// AppStatusAutoscalerMetric pairs a metric's target with its last observed value
+type AppStatusAutoscalerMetric struct {
+	// Type is one of Resource, ContainerResource, Pods, Object, External
+	Type string `json:"type"`
+	// Name is the resource (e.g. cpu) or custom metric name
+	Name string `json:"name"`
+	// Current and Target are formatted values (e.g. "45%", "250m", "1200")
+	// Current is empty when the autoscaler has not observed the metric
+	Current string `json:"current"`
+	Target  string `json:"target"`
+}
+
+func AppStatusAutoscalerFromK8s(hpa autoscalingv2.HorizontalPodAutoscaler) AppStatusAutoscaler {
+	minReplicas := 1
+	if hpa.Spec.MinReplicas != nil {
+		minReplicas = int(*hpa.Spec.MinReplicas)
+	}
+
+	current := map[string]string{}
+	for _, ms := range hpa.Status.CurrentMetrics {
+		typ, name, value := metricStatusValue(ms)
+		current[typ+"/"+name] = value
+	}
+	metrics := make([]AppStatusAutoscalerMetric, 0)
+	for _, spec := range hpa.Spec.Metrics {
+		typ, name, target := metricSpecTarget(spec)
+		metrics = append(metrics, AppStatusAutoscalerMetric{
+			Type:    typ,
+			Name:    name,
+			Current: current[typ+"/"+name],
+			Target:  target,
+		})
+	}
+
+	result := AppStatusAutoscaler{
+		Name:            hpa.Name,
+		MinReplicas:     minReplicas,
+		MaxReplicas:     int(hpa.Spec.MaxReplicas),
+		CurrentReplicas: int(hpa.Status.CurrentReplicas),
+		DesiredReplicas: int(hpa.Status.DesiredReplicas),
+		Metrics:         metrics,
+	}
+	for _, cond := range hpa.Status.Conditions {
+		switch {
+		case cond.Type == autoscalingv2.ScalingLimited && cond.Status == corev1.ConditionTrue:
+			result.ScalingLimited = true
+		case cond.Type == autoscalingv2.ScalingActive && cond.Status == corev1.ConditionFalse:
+			result.Message = cond.Message
+		case cond.Type == autoscalingv2.AbleToScale && cond.Status == corev1.ConditionFalse:
+			result.Message = cond.Message
+		}
+	}
+	return result
+}
+
+// FindAutoscaler returns the HorizontalPodAutoscaler that targets the workload, or nil if it is not autoscaled
+func FindAutoscaler(hpas []autoscalingv2.HorizontalPodAutoscaler, kind WorkloadKind, name string) *autoscalingv2.HorizontalPodAutoscaler {
+	if kind == "" {
+		kind = WorkloadKindDeployment
+	}
+	for i, hpa := range hpas {
+		ref := hpa.Spec.ScaleTargetRef
+		if ref.Kind == string(kind) && ref.Name == name {
+			return &hpas[i]
+		}
+	}
+	return nil
+}
+
+// AutoscalerDesiredReplicas returns the replica count the autoscaler is converging on (clamped to its bounds)
+// Returns false when the autoscaler has not computed a scale yet
+func AutoscalerDesiredReplicas(hpa *autoscalingv2.HorizontalPodAutoscaler) (int32, bool) {
+	if hpa == nil || hpa.Status.DesiredReplicas <= 0 {
+		return 0, false
+	}
+	desired := hpa.Status.DesiredReplicas
+	if hpa.Spec.MinReplicas != nil && desired < *hpa.Spec.MinReplicas {
+		desired = *hpa.Spec.MinReplicas
+	}
+	if hpa.Spec.MaxReplicas > 0 && desired > hpa.Spec.MaxReplicas {
+		desired = hpa.Spec.MaxReplicas
+	}
+	return desired, true
+}
+
+func metricSpecTarget(spec autoscalingv2.MetricSpec) (string, string, string) {
+	switch spec.Type {
+	case autoscalingv2.ResourceMetricSourceType:
+		if spec.Resource != nil {
+			return string(spec.Type), string(spec.Resource.Name), formatMetricTarget(spec.Resource.Target)
+		}
+	case autoscalingv2.ContainerResourceMetricSourceType:
+		if spec.ContainerResource != nil {
+			name := fmt.Sprintf("%s/%s", spec.ContainerResource.Container, spec.ContainerResource.Name)
+			return string(spec.Type), name, formatMetricTarget(spec.ContainerResource.Target)
+		}
+	case autoscalingv2.PodsMetricSourceType:
+		if spec.Pods != nil {
+			return string(spec.Type), spec.Pods.Metric.Name, formatMetricTarget(spec.Pods.Target)
+		}
+	case autoscalingv2.ObjectMetricSourceType:
+		if spec.Object != nil {
+			return string(spec.Type), spec.Object.Metric.Name, formatMetricTarget(spec.Object.Target)
+		}
+	case autoscalingv2.ExternalMetricSourceType:
+		if spec.External != nil {
+			return string(spec.Type), spec.External.Metric.Name, formatMetricTarget(spec.External.Target)
+		}
+	}
+	return string(spec.Type), "", ""
+}
+
+func metricStatusValue(status autoscalingv2.MetricStatus) (string, string, string) {
+	switch status.Type {
+	case autoscalingv2.ResourceMetricSourceType:
+		if status.Resource != nil {
+			return string(status.Type), string(status.Resource.Name), formatMetricValue(status.Resource.Current)
+		}
+	case autoscalingv2.ContainerResourceMetricSourceType:
+		if status.ContainerResource != nil {
+			name := fmt.Sprintf("%s/%s", status.ContainerResource.Container, status.ContainerResource.Name)
+			return string(status.Type), name, formatMetricValue(status.ContainerResource.Current)
+		}
+	case autoscalingv2.PodsMetricSourceType:
+		if status.Pods != nil {
+			return string(status.Type), status.Pods.Metric.Name, formatMetricValue(status.Pods.Current)
+		}
+	case autoscalingv2.ObjectMetricSourceType:
+		if status.Object != nil {
+			return string(status.Type), status.Object.Metric.Name, formatMetricValue(status.Object.Current)
+		}
+	case autoscalingv2.ExternalMetricSourceType:
+		if status.External != nil {
+			return string(status.Type), status.External.Metric.Name, formatMetricValue(status.External.Current)
+		}
+	}
+	return string(status.Type), "", ""
+}
+
+func formatMetricTarget(target autoscalingv2.MetricTarget) string {
+	switch {
+	case target.AverageUtilization != nil:
+		return fmt.Sprintf("%d%%", *target.AverageUtilization)
+	case target.AverageValue != nil:
+		return target.AverageValue.String()
+	case target.Value != nil:
+		return target.Value.String()
+	}
+	return ""
+}
+
+func formatMetricValue(value autoscalingv2.MetricValueStatus) string {
+	switch {
+	case value.AverageUtilization != nil:
+		return fmt.Sprintf("%d%%", *value.AverageUtilization)
+	case value.AverageValue != nil:
+		return value.AverageValue.String()
+	case value.Value != nil:
+		return value.Value.String()
+	}
+	return ""
+}
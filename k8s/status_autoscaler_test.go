@@ -0,0 +1,94 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAppStatusAutoscalerFromK8s(t *testing.T) {
+	two, seventy, fortyFive := int32(2), int32(70), int32(45)
+	rps := resource.MustParse("100")
+	hpa := autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: "api"},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{Kind: "Deployment", Name: "api"},
+			MinReplicas:    &two,
+			MaxReplicas:    10,
+			Metrics: []autoscalingv2.MetricSpec{
+				{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{
+						Name:   corev1.ResourceCPU,
+						Target: autoscalingv2.MetricTarget{Type: autoscalingv2.UtilizationMetricType, AverageUtilization: &seventy},
+					},
+				},
+				{
+					Type: autoscalingv2.PodsMetricSourceType,
+					Pods: &autoscalingv2.PodsMetricSource{
+						Metric: autoscalingv2.MetricIdentifier{Name: "requests_per_second"},
+						Target: autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType, AverageValue: &rps},
+					},
+				},
+			},
+		},
+		Status: autoscalingv2.HorizontalPodAutoscalerStatus{
+			CurrentReplicas: 3,
+			DesiredReplicas: 4,
+			CurrentMetrics: []autoscalingv2.MetricStatus{
+				{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricStatus{
+						Name:    corev1.ResourceCPU,
+						Current: autoscalingv2.MetricValueStatus{AverageUtilization: &fortyFive},
+					},
+				},
+			},
+			Conditions: []autoscalingv2.HorizontalPodAutoscalerCondition{
+				{Type: autoscalingv2.ScalingActive, Status: corev1.ConditionFalse, Message: "the HPA was unable to compute the replica count: unable to get metric requests_per_second"},
+				{Type: autoscalingv2.ScalingLimited, Status: corev1.ConditionFalse},
+			},
+		},
+	}
+
+	got := AppStatusAutoscalerFromK8s(hpa)
+	assert.Equal(t, AppStatusAutoscaler{
+		Name:            "api",
+		MinReplicas:     2,
+		MaxReplicas:     10,
+		CurrentReplicas: 3,
+		DesiredReplicas: 4,
+		Message:         "the HPA was unable to compute the replica count: unable to get metric requests_per_second",
+		Metrics: []AppStatusAutoscalerMetric{
+			{Type: "Resource", Name: "cpu", Current: "45%", Target: "70%"},
+			{Type: "Pods", Name: "requests_per_second", Current: "", Target: "100"},
+		},
+	}, got)
+}
+
+func TestFindAutoscaler(t *testing.T) {
+	hpa := func(kind, name string) autoscalingv2.HorizontalPodAutoscaler {
+		return autoscalingv2.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{Name: name + "-hpa"},
+			Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{Kind: kind, Name: name},
+			},
+		}
+	}
+	hpas := []autoscalingv2.HorizontalPodAutoscaler{hpa("Deployment", "web"), hpa("StatefulSet", "api"), hpa("Deployment", "api")}
+
+	got := FindAutoscaler(hpas, "", "api")
+	require.NotNil(t, got)
+	assert.Equal(t, "Deployment", got.Spec.ScaleTargetRef.Kind)
+
+	got = FindAutoscaler(hpas, WorkloadKindStatefulSet, "api")
+	require.NotNil(t, got)
+	assert.Equal(t, "StatefulSet", got.Spec.ScaleTargetRef.Kind)
+
+	assert.Nil(t, FindAutoscaler(hpas, WorkloadKindRollout, "api"))
+}
@@ -18,6 +18,8 @@ type AppStatusOverview struct {
 	DeploymentName string                        `json:"deploymentName"`
 	ReplicaSets    []AppStatusOverviewReplicaSet `json:"replicaSets"`
 	Jobs           AppStatusJobSummary           `json:"jobs"`
+	// Workloads reports StatefulSets, DaemonSets, and Argo Rollouts (Deployments are reported through ReplicaSets)
+	Workloads []AppStatusWorkload `json:"workloads,omitempty"`
 }
 
 func (a AppStatusOverview) GetDeploymentVersions() []string {
@@ -25,6 +27,9 @@ func (a AppStatusOverview) GetDeploymentVersions() []string {
 	for _, rs := range a.ReplicaSets {
 		refs = append(refs, rs.AppVersion)
 	}
+	for _, w := range a.Workloads {
+		refs = append(refs, w.AppVersion)
+	}
 	return refs
 }
 
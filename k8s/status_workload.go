@@ -0,0 +1,111 @@
+package k8s
+
+import (
+	"github.com/nullstone-io/deployment-sdk/app"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// AppStatusWorkload reports the rollout of a StatefulSet, DaemonSet, or Argo Rollout.
+// Deployments are reported through their ReplicaSets instead.
+// Argo Rollouts also manage ReplicaSets, so their pods are reported on AppStatus.ReplicaSets.
+type AppStatusWorkload struct {
+	Kind               WorkloadKind `json:"kind"`
+	Name               string       `json:"name"`
+	AppVersion         string       `json:"appVersion"`
+	Generation         int64        `json:"generation"`
+	ObservedGeneration int64        `json:"observedGeneration"`
+	DesiredReplicas    int          `json:"desiredReplicas"`
+	UpdatedReplicas    int          `json:"updatedReplicas"`
+	ReadyReplicas      int          `json:"readyReplicas"`
+	AvailableReplicas  int          `json:"availableReplicas"`
+	// CurrentRevision and UpdateRevision are the controller revisions (StatefulSet) or pod template hashes (Rollout)
+	// A rollout is underway while they differ
+	CurrentRevision string `json:"currentRevision,omitempty"`
+	UpdateRevision  string `json:"updateRevision,omitempty"`
+	// Partition is the ordinal at or above which StatefulSet pods receive updates (0 when not partitioned)
+	Partition int `json:"partition,omitempty"`
+	// Phase is the Argo Rollout phase (Healthy, Progressing, Paused, Degraded)
+	Phase         string            `json:"phase,omitempty"`
+	Message       string            `json:"message,omitempty"`
+	RolloutStatus app.RolloutStatus `json:"rolloutStatus"`
+
+	Pods []AppStatusPod `json:"pods,omitempty"`
+}
+
+func AppStatusWorkloadFromStatefulSet(sts appsv1.StatefulSet) AppStatusWorkload {
+	desired := 1
+	if sts.Spec.Replicas != nil {
+		desired = int(*sts.Spec.Replicas)
+	}
+	rolloutStatus, _ := CheckStatefulSet(&sts)
+	return AppStatusWorkload{
+		Kind:               WorkloadKindStatefulSet,
+		Name:               sts.Name,
+		AppVersion:         sts.Spec.Template.Labels[StandardVersionLabel],
+		Generation:         sts.Generation,
+		ObservedGeneration: sts.Status.ObservedGeneration,
+		DesiredReplicas:    desired,
+		UpdatedReplicas:    int(sts.Status.UpdatedReplicas),
+		ReadyReplicas:      int(sts.Status.ReadyReplicas),
+		AvailableReplicas:  int(sts.Status.AvailableReplicas),
+		CurrentRevision:    sts.Status.CurrentRevision,
+		UpdateRevision:     sts.Status.UpdateRevision,
+		Partition:          int(statefulSetPartition(&sts)),
+		RolloutStatus:      rolloutStatus,
+	}
+}
+
+func AppStatusWorkloadFromDaemonSet(ds appsv1.DaemonSet) AppStatusWorkload {
+	rolloutStatus, _ := CheckDaemonSet(&ds)
+	return AppStatusWorkload{
+		Kind:               WorkloadKindDaemonSet,
+		Name:               ds.Name,
+		AppVersion:         ds.Spec.Template.Labels[StandardVersionLabel],
+		Generation:         ds.Generation,
+		ObservedGeneration: ds.Status.ObservedGeneration,
+		DesiredReplicas:    int(ds.Status.DesiredNumberScheduled),
+		UpdatedReplicas:    int(ds.Status.UpdatedNumberScheduled),
+		ReadyReplicas:      int(ds.Status.NumberReady),
+		AvailableReplicas:  int(ds.Status.NumberAvailable),
+		RolloutStatus:      rolloutStatus,
+	}
+}
+
+func AppStatusWorkloadFromRollout(obj unstructured.Unstructured) AppStatusWorkload {
+	state := RolloutStateFromUnstructured(&obj)
+	rolloutStatus, _ := CheckRollout(state)
+	appVersion, _, _ := unstructured.NestedString(obj.Object, "spec", "template", "metadata", "labels", StandardVersionLabel)
+	return AppStatusWorkload{
+		Kind:               WorkloadKindRollout,
+		Name:               obj.GetName(),
+		AppVersion:         appVersion,
+		Generation:         state.Generation,
+		ObservedGeneration: state.ObservedGeneration,
+		DesiredReplicas:    int(state.Replicas),
+		UpdatedReplicas:    int(state.UpdatedReplicas),
+		ReadyReplicas:      int(state.ReadyReplicas),
+		AvailableReplicas:  int(state.AvailableReplicas),
+		CurrentRevision:    state.StableRS,
+		UpdateRevision:     state.CurrentPodHash,
+		Phase:              state.Phase,
+		Message:            state.Message,
+		RolloutStatus:      rolloutStatus,
+	}
+}
+
+// listPodsByOwner returns the pods that are directly owned by the workload
+// StatefulSets and DaemonSets own their pods without an intermediate ReplicaSet
+func listPodsByOwner(pods []corev1.Pod, kind WorkloadKind, name string, svcs []corev1.Service) []AppStatusPod {
+	result := make([]AppStatusPod, 0)
+	for _, pod := range pods {
+		for _, or := range pod.OwnerReferences {
+			if or.Kind == string(kind) && or.Name == name {
+				result = append(result, AppStatusPodFromK8s(pod, svcs))
+				break
+			}
+		}
+	}
+	return result
+}
@@ -9,10 +9,13 @@ import (
 	"github.com/nullstone-io/deployment-sdk/k8s/failures"
 	"github.com/nullstone-io/deployment-sdk/logging"
 	"k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 )
@@ -30,16 +33,23 @@ type Statuser struct {
 	Cluster      ClusterInfo
 	AppNamespace string
 	AppName      string
+	// WorkloadKind is the kind of object that runs the app; an empty value means a Deployment
+	WorkloadKind WorkloadKind
 	NewConfigFn  NewConfiger
 
 	// Cache populated by initialize. Guarded by initOnce.
-	initOnce    sync.Once
-	initErr     error
-	client      *kubernetes.Clientset
-	replicaSets []v1.ReplicaSet
-	services    []corev1.Service
-	pods        []corev1.Pod
-	jobs        []batchv1.Job
+	initOnce     sync.Once
+	initErr      error
+	client       *kubernetes.Clientset
+	replicaSets  []v1.ReplicaSet
+	services     []corev1.Service
+	pods         []corev1.Pod
+	jobs         []batchv1.Job
+	statefulSets []v1.StatefulSet
+	daemonSets   []v1.DaemonSet
+	rollouts     []unstructured.Unstructured
+	autoscalers  []autoscalingv2.HorizontalPodAutoscaler
+	routes       []AppStatusRoute
 }
 
 // initialize lazily fetches every k8s resource Status and StatusOverview need.
@@ -89,9 +99,76 @@ func (s *Statuser) loadResources(ctx context.Context) error {
 	}
 	s.jobs = jobResp.Items
 
+	// Autoscalers are optional to reporting status; a cluster role without access to them shouldn't fail the status
+	hpaResp, err := client.AutoscalingV2().HorizontalPodAutoscalers(s.AppNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if !apierrors.IsForbidden(err) {
+			return fmt.Errorf("error retrieving horizontal pod autoscalers: %w", err)
+		}
+	} else {
+		s.autoscalers = hpaResp.Items
+	}
+
+	dyn, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("error initializing kubernetes dynamic client: %w", err)
+	}
+	serviceNames := []string{s.AppName}
+	for _, svc := range s.services {
+		serviceNames = append(serviceNames, svc.Name)
+	}
+	if s.routes, err = LoadAppRoutes(ctx, client, dyn, s.AppNamespace, serviceNames); err != nil {
+		return err
+	}
+
+	switch s.WorkloadKind {
+	case WorkloadKindStatefulSet:
+		stsResp, err := client.AppsV1().StatefulSets(s.AppNamespace).List(ctx, listOpts)
+		if err != nil {
+			return fmt.Errorf("error retrieving app stateful sets: %w", err)
+		}
+		s.statefulSets = stsResp.Items
+	case WorkloadKindDaemonSet:
+		dsResp, err := client.AppsV1().DaemonSets(s.AppNamespace).List(ctx, listOpts)
+		if err != nil {
+			return fmt.Errorf("error retrieving app daemon sets: %w", err)
+		}
+		s.daemonSets = dsResp.Items
+	case WorkloadKindRollout:
+		rolloutResp, err := dyn.Resource(RolloutGVR).Namespace(s.AppNamespace).List(ctx, listOpts)
+		if err != nil {
+			return fmt.Errorf("error retrieving app rollouts: %w", err)
+		}
+		s.rollouts = rolloutResp.Items
+	}
+
 	return nil
 }
 
+// appStatusWorkloads reports the app's StatefulSets, DaemonSets, and Argo Rollouts
+// Pods are only attached when withPods is true
+func (s *Statuser) appStatusWorkloads(withPods bool) []AppStatusWorkload {
+	result := make([]AppStatusWorkload, 0)
+	for _, sts := range s.statefulSets {
+		workload := AppStatusWorkloadFromStatefulSet(sts)
+		if withPods {
+			workload.Pods = listPodsByOwner(s.pods, WorkloadKindStatefulSet, sts.Name, s.services)
+		}
+		result = append(result, workload)
+	}
+	for _, ds := range s.daemonSets {
+		workload := AppStatusWorkloadFromDaemonSet(ds)
+		if withPods {
+			workload.Pods = listPodsByOwner(s.pods, WorkloadKindDaemonSet, ds.Name, s.services)
+		}
+		result = append(result, workload)
+	}
+	for _, rollout := range s.rollouts {
+		result = append(result, AppStatusWorkloadFromRollout(rollout))
+	}
+	return result
+}
+
 func (s *Statuser) StatusOverview(ctx context.Context) (app.StatusOverviewResult, error) {
 	so := AppStatusOverview{
 		Cluster:     s.Cluster,
@@ -107,6 +184,7 @@ func (s *Statuser) StatusOverview(ctx context.Context) (app.StatusOverviewResult
 
 	so.DeploymentName = findDeploymentNameFromReplicaSets(s.replicaSets)
 	so.Jobs = AppStatusJobSummaryFromK8s(s.jobs)
+	so.Workloads = s.appStatusWorkloads(false)
 	for _, replicaSet := range ExcludeOldReplicaSets(s.replicaSets) {
 		so.ReplicaSets = append(so.ReplicaSets, AppStatusOverviewReplicaSetFromK8s(replicaSet, s.services))
 	}
@@ -142,11 +220,21 @@ func (s *Statuser) Status(ctx context.Context) (any, error) {
 	for _, job := range s.jobs {
 		st.Jobs = append(st.Jobs, AppStatusJobExecutionFromK8s(job, s.pods))
 	}
+	st.Workloads = s.appStatusWorkloads(true)
+	if hpa := FindAutoscaler(s.autoscalers, s.WorkloadKind, s.AppName); hpa != nil {
+		autoscaler := AppStatusAutoscalerFromK8s(*hpa)
+		st.Autoscaler = &autoscaler
+	}
+	st.Routes = s.routes
+	st.ReadinessGates = AppStatusReadinessGatesFromPods(s.pods)
 
 	// Surface rollout-level failures (ProgressDeadlineExceeded / ReplicaFailure)
 	// from the parent Deployment when one exists with the app name. A missing
 	// Deployment isn't an error — some app types may not have one — so we ignore
 	// NotFound and any transient classification miss.
+	if s.WorkloadKind != "" && s.WorkloadKind != WorkloadKindDeployment {
+		return st, nil
+	}
 	if dep, err := s.client.AppsV1().Deployments(s.AppNamespace).Get(ctx, s.AppName, metav1.GetOptions{}); err == nil && dep != nil {
 		st.Failures = failures.ClassifyDeployment(*dep)
 	} else if err != nil && !apierrors.IsNotFound(err) {
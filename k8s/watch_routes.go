@@ -0,0 +1,110 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/colorstring"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// monitorRoutes waits until the Ingresses and HTTPRoutes that send traffic to the app's Services are ready
+// A route is ready once it has a load balancer address and (for HTTPRoutes) its Gateways accepted it and resolved its backends
+// A rejected route fails the deployment; it will not become ready without a configuration change
+func (w *DeployWatcher) monitorRoutes(ctx context.Context) error {
+	stdout := w.OsWriters.Stdout()
+	reported := map[string]string{}
+	gatesReported := false
+	for {
+		routes, err := LoadAppRoutes(ctx, w.client, w.dynamic, w.AppNamespace, w.appServiceNames(ctx))
+		if err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return w.translateCancellation(ctx)
+			}
+			// Routes are best-effort; a rollout that completed shouldn't fail because routes can't be read
+			fmt.Fprintf(w.OsWriters.Stderr(), "There was an error retrieving routes for app: %s\n", err)
+			return nil
+		}
+		if !gatesReported {
+			w.reportReadinessGates(ctx)
+			gatesReported = true
+		}
+
+		pending := false
+		for _, route := range routes {
+			de := DeployEvent{
+				Timestamp: time.Now(),
+				Type:      EventTypeNormal,
+				Object:    route.ObjectRef(),
+			}
+			switch {
+			case route.Failed:
+				de.Type, de.Reason, de.Message = EventTypeError, "RouteRejected", route.Message
+			case route.Ready:
+				de.Reason, de.Message = "RouteReady", fmt.Sprintf("Serving traffic at %s", strings.Join(route.Addresses, ", "))
+			default:
+				de.Reason, de.Message = "RoutePending", route.Message
+				pending = true
+			}
+			if reported[de.Object] != de.Message {
+				reported[de.Object] = de.Message
+				colorstring.Fprintln(stdout, de.String())
+			}
+			if route.Failed {
+				return rolloutError{err: fmt.Errorf("%s was rejected: %s", route.ObjectRef(), route.Message)}
+			}
+		}
+		if !pending {
+			return nil
+		}
+
+		select {
+		case <-time.After(3 * time.Second):
+		case <-ctx.Done():
+			return w.translateCancellation(ctx)
+		}
+	}
+}
+
+// reportReadinessGates prints how many of the app's pods pass each load balancer readiness gate (e.g. GKE NEG, AWS target health)
+func (w *DeployWatcher) reportReadinessGates(ctx context.Context) {
+	pods, err := w.client.CoreV1().Pods(w.AppNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("nullstone.io/app=%s", w.AppName),
+	})
+	if err != nil {
+		return
+	}
+	for _, gate := range AppStatusReadinessGatesFromPods(pods.Items) {
+		eventType := EventTypeNormal
+		if gate.ReadyPods < gate.TotalPods {
+			eventType = EventTypeWarning
+		}
+		colorstring.Fprintln(w.OsWriters.Stdout(), DeployEvent{
+			Timestamp: time.Now(),
+			Type:      eventType,
+			Reason:    "ReadinessGate",
+			Object:    w.WorkloadKind.ObjectRef(w.AppName),
+			Message:   fmt.Sprintf("%d/%d pods pass readiness gate %s", gate.ReadyPods, gate.TotalPods, gate.ConditionType),
+		}.String())
+	}
+}
+
+// appServiceNames returns the names of the app's Services (labeled for the app or named after it)
+func (w *DeployWatcher) appServiceNames(ctx context.Context) []string {
+	names := []string{w.AppName}
+	svcs, err := w.client.CoreV1().Services(w.AppNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("nullstone.io/app=%s", w.AppName),
+	})
+	if err != nil {
+		return names
+	}
+	for _, svc := range svcs.Items {
+		if !containsString(names, svc.Name) {
+			names = append(names, svc.Name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,205 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nullstone-io/deployment-sdk/app"
+	"github.com/nullstone-io/deployment-sdk/k8s/failures"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// workloadSnapshot is a kind-agnostic view of the app's workload at a point in time
+type workloadSnapshot struct {
+	Generation         int64
+	ObservedGeneration int64
+	// Revision identifies the revision being rolled out
+	// This is the deployment revision, the controller revision (StatefulSet/DaemonSet), or the pod template hash (Rollout)
+	Revision string
+	Status   app.RolloutStatus
+	// Err explains why the rollout failed
+	Err error
+
+	selector   *metav1.LabelSelector
+	deployment *appsv1.Deployment
+	// podLabels are the labels on the workload's pod template; used to find the PodDisruptionBudgets that cover its pods
+	podLabels map[string]string
+}
+
+func (w *DeployWatcher) getWorkload(ctx context.Context) (*workloadSnapshot, error) {
+	switch w.WorkloadKind {
+	case WorkloadKindStatefulSet:
+		sts, err := w.client.AppsV1().StatefulSets(w.AppNamespace).Get(ctx, w.AppName, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		status, err := CheckStatefulSet(sts)
+		return &workloadSnapshot{
+			Generation:         sts.Generation,
+			ObservedGeneration: sts.Status.ObservedGeneration,
+			Revision:           sts.Status.UpdateRevision,
+			Status:             status,
+			Err:                err,
+			selector:           sts.Spec.Selector,
+			podLabels:          sts.Spec.Template.Labels,
+		}, nil
+	case WorkloadKindDaemonSet:
+		ds, err := w.client.AppsV1().DaemonSets(w.AppNamespace).Get(ctx, w.AppName, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		status, err := CheckDaemonSet(ds)
+		return &workloadSnapshot{
+			Generation:         ds.Generation,
+			ObservedGeneration: ds.Status.ObservedGeneration,
+			Revision:           ds.Annotations[DaemonSetRevisionAnnotation],
+			Status:             status,
+			Err:                err,
+			selector:           ds.Spec.Selector,
+			podLabels:          ds.Spec.Template.Labels,
+		}, nil
+	case WorkloadKindRollout:
+		obj, err := w.dynamic.Resource(RolloutGVR).Namespace(w.AppNamespace).Get(ctx, w.AppName, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		state := RolloutStateFromUnstructured(obj)
+		status, err := CheckRollout(state)
+		podLabels, _, _ := unstructured.NestedStringMap(obj.Object, "spec", "template", "metadata", "labels")
+		return &workloadSnapshot{
+			Generation:         state.Generation,
+			ObservedGeneration: state.ObservedGeneration,
+			Revision:           state.CurrentPodHash,
+			Status:             status,
+			Err:                err,
+			podLabels:          podLabels,
+		}, nil
+	default:
+		deployment, err := w.client.AppsV1().Deployments(w.AppNamespace).Get(ctx, w.AppName, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		status, err := CheckAutoscaledDeployment(deployment, w.findAutoscaler(ctx))
+		return &workloadSnapshot{
+			Generation:         deployment.Generation,
+			ObservedGeneration: deployment.Status.ObservedGeneration,
+			Revision:           deployment.Annotations[RevisionAnnotation],
+			Status:             status,
+			Err:                err,
+			deployment:         deployment,
+			podLabels:          deployment.Spec.Template.Labels,
+		}, nil
+	}
+}
+
+// findAutoscaler returns the HorizontalPodAutoscaler that scales the app's workload, or nil if there is none
+// The autoscaler is optional to judging a rollout, so errors (e.g. missing RBAC permissions) are ignored
+func (w *DeployWatcher) findAutoscaler(ctx context.Context) *autoscalingv2.HorizontalPodAutoscaler {
+	hpas, err := w.client.AutoscalingV2().HorizontalPodAutoscalers(w.AppNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil
+	}
+	return FindAutoscaler(hpas.Items, w.WorkloadKind, w.AppName)
+}
+
+// isScaleOnly reports whether a newer workload generation only changed the replica count (e.g. an autoscaler scaled the app)
+// revision is the revision observed for the generation being watched; scaling does not create a new revision
+// A generation that the controller has not observed yet is given the benefit of the doubt until the next poll
+func isScaleOnly(workload *workloadSnapshot, revision string) bool {
+	if revision == "" {
+		return false
+	}
+	return workload.ObservedGeneration < workload.Generation || workload.Revision == revision
+}
+
+// findStartTime finds when the revision being rolled out was created
+// Events that occurred before this time belong to previous deployments
+func (w *DeployWatcher) findStartTime(ctx context.Context, workload *workloadSnapshot, generation int64) *time.Time {
+	switch w.WorkloadKind {
+	case WorkloadKindStatefulSet, WorkloadKindDaemonSet:
+		return FindControllerRevisionStartTime(ctx, w.client, w.AppNamespace, workload.selector)
+	case WorkloadKindRollout:
+		return FindRolloutRevisionStartTime(ctx, w.client, w.AppNamespace, workload.Revision)
+	default:
+		return FindDeploymentStartTime(ctx, w.client, w.AppNamespace, workload.deployment, generation)
+	}
+}
+
+// rolloutError is a failure reported by the workload controller (e.g. ProgressDeadlineExceeded, a degraded Rollout)
+// It keeps the controller's message and still matches app.ErrFailed
+type rolloutError struct {
+	err error
+}
+
+func (e rolloutError) Error() string {
+	return e.err.Error()
+}
+
+func (e rolloutError) Unwrap() []error {
+	return []error{e.err, app.ErrFailed}
+}
+
+// collectPodFailures classifies the current state of the pods created for the new revision
+// Events only capture transitions; this snapshot catches pods that are stuck (e.g. waiting on an image pull)
+// Returns the number of new pods
+func (w *DeployWatcher) collectPodFailures(ctx context.Context, report *failures.Report, start *time.Time) int {
+	pods, err := w.client.CoreV1().Pods(w.AppNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("nullstone.io/app=%s", w.AppName),
+	})
+	if err != nil {
+		fmt.Fprintf(w.OsWriters.Stderr(), "There was an error retrieving pods for app: %s\n", err)
+		return 0
+	}
+	catalog := w.catalog()
+	nodes := map[string]*corev1.Node{}
+	total := 0
+	for _, pod := range pods.Items {
+		if start != nil && pod.CreationTimestamp.Time.Before(*start) {
+			// Pods from previous revisions are not part of this rollout
+			continue
+		}
+		total++
+		node := w.getPodNode(ctx, nodes, pod.Spec.NodeName)
+		report.AddAll(catalog.ClassifyPodOnNode(pod, node))
+		for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+			if f := catalog.ClassifyContainerOnNode(pod, status, node); f != nil {
+				report.Add(*f)
+			}
+		}
+	}
+	return total
+}
+
+// getPodNode retrieves the node a pod is scheduled on so that failures can be classified by node labels (e.g. Fargate, GKE Sandbox)
+// Nodes are cluster-scoped; if the deployer is not allowed to read them, pods are classified without node labels
+func (w *DeployWatcher) getPodNode(ctx context.Context, cache map[string]*corev1.Node, nodeName string) *corev1.Node {
+	if nodeName == "" {
+		return nil
+	}
+	if node, ok := cache[nodeName]; ok {
+		return node
+	}
+	node, err := w.client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		node = nil
+	}
+	cache[nodeName] = node
+	return node
+}
+
+// collectWorkloadFailures classifies the workload's own conditions (only Deployments have a classifier)
+func (w *DeployWatcher) collectWorkloadFailures(ctx context.Context, report *failures.Report) {
+	if w.WorkloadKind != WorkloadKindDeployment {
+		return
+	}
+	deployment, err := w.client.AppsV1().Deployments(w.AppNamespace).Get(ctx, w.AppName, metav1.GetOptions{})
+	if err != nil {
+		return
+	}
+	report.AddAll(w.catalog().ClassifyDeployment(*deployment))
+}
@@ -0,0 +1,52 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// WorkloadKind is the kind of kubernetes object that runs a long-running app
+type WorkloadKind string
+
+const (
+	WorkloadKindDeployment  WorkloadKind = "Deployment"
+	WorkloadKindStatefulSet WorkloadKind = "StatefulSet"
+	WorkloadKindDaemonSet   WorkloadKind = "DaemonSet"
+	// WorkloadKindRollout is an Argo Rollouts Rollout (argoproj.io/v1alpha1)
+	WorkloadKindRollout WorkloadKind = "Rollout"
+)
+
+var (
+	// RolloutGVR identifies Argo Rollouts; there is no typed client for them, so they are accessed through the dynamic client
+	RolloutGVR = schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "rollouts"}
+
+	// RolloutPodTemplateHashLabel is the label Argo Rollouts places on the replica sets and pods of each revision
+	RolloutPodTemplateHashLabel = "rollouts-pod-template-hash"
+)
+
+// ParseWorkloadKind converts an app module's workload_kind output into a WorkloadKind
+// Matching is case-insensitive; an empty value means the app runs as a Deployment
+func ParseWorkloadKind(val string) (WorkloadKind, error) {
+	switch strings.ToLower(val) {
+	case "", "deployment":
+		return WorkloadKindDeployment, nil
+	case "statefulset":
+		return WorkloadKindStatefulSet, nil
+	case "daemonset":
+		return WorkloadKindDaemonSet, nil
+	case "rollout":
+		return WorkloadKindRollout, nil
+	}
+	return "", fmt.Errorf("unsupported workload kind %q (expected Deployment, StatefulSet, DaemonSet, or Rollout)", val)
+}
+
+// ObjectRef formats the workload the way kubectl refers to it (e.g. "statefulset/api")
+func (k WorkloadKind) ObjectRef(name string) string {
+	kind := k
+	if kind == "" {
+		kind = WorkloadKindDeployment
+	}
+	return fmt.Sprintf("%s/%s", strings.ToLower(string(kind)), name)
+}
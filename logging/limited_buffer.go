@@ -0,0 +1,37 @@
+package logging
+
+import "sync"
+
+// LimitedBuffer captures writes up to Limit bytes and discards the rest
+// It is safe for concurrent writes so that a command's stdout and stderr can share one buffer
+type LimitedBuffer struct {
+	Limit     int
+	mu        sync.Mutex
+	buf       []byte
+	truncated bool
+}
+
+func (b *LimitedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n := len(p)
+	if remaining := b.Limit - len(b.buf); n > remaining {
+		b.truncated = true
+		p = p[:max(remaining, 0)]
+	}
+	b.buf = append(b.buf, p...)
+	return n, nil
+}
+
+func (b *LimitedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return string(b.buf)
+}
+
+// Truncated returns true if any write was discarded because the buffer reached its limit
+func (b *LimitedBuffer) Truncated() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.truncated
+}
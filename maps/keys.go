@@ -1,5 +1,10 @@
 package maps
 
+import (
+	"cmp"
+	"slices"
+)
+
 func Keys[TKey comparable, TVal any](m map[TKey]TVal) []TKey {
 	keys := make([]TKey, 0)
 	for k := range m {
@@ -7,3 +12,10 @@ func Keys[TKey comparable, TVal any](m map[TKey]TVal) []TKey {
 	}
 	return keys
 }
+
+// SortedKeys returns the keys of m in ascending order
+func SortedKeys[TKey cmp.Ordered, TVal any](m map[TKey]TVal) []TKey {
+	keys := Keys(m)
+	slices.Sort(keys)
+	return keys
+}
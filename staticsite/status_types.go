@@ -0,0 +1,53 @@
+package staticsite
+
+import "time"
+
+type Status struct {
+	Cdns []CdnStatus `json:"cdns"`
+	// ArtifactVersions lists the versions that have been pushed to the artifacts bucket/container
+	ArtifactVersions []string `json:"artifactVersions"`
+	// ArtifactsError explains why ArtifactVersions is empty when the artifacts could not be listed
+	ArtifactsError string `json:"artifactsError,omitempty"`
+}
+
+// CdnStatus describes a CDN (CloudFront distribution, Cloud CDN url map, Azure CDN endpoint) serving the static site
+type CdnStatus struct {
+	Id string `json:"id"`
+	// Domains are the hostnames that route to this CDN
+	Domains []string `json:"domains"`
+	// State is the provider-reported state of the CDN (e.g. Deployed, InProgress, Succeeded)
+	State string `json:"state,omitempty"`
+	// AppVersion is the version currently served by the CDN; empty if it could not be determined
+	AppVersion           string         `json:"appVersion"`
+	PendingInvalidations []Invalidation `json:"pendingInvalidations"`
+}
+
+// Invalidation is a cache invalidation (or purge) that has not completed
+type Invalidation struct {
+	Id        string     `json:"id"`
+	Status    string     `json:"status"`
+	Paths     []string   `json:"paths,omitempty"`
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+}
+
+type StatusOverview struct {
+	Cdns []CdnStatusOverview `json:"cdns"`
+}
+
+type CdnStatusOverview struct {
+	Id                   string `json:"id"`
+	AppVersion           string `json:"appVersion"`
+	PendingInvalidations int    `json:"pendingInvalidations"`
+}
+
+func (o StatusOverview) GetDeploymentVersions() []string {
+	versions := make([]string, 0)
+	seen := map[string]bool{}
+	for _, cdn := range o.Cdns {
+		if cdn.AppVersion != "" && !seen[cdn.AppVersion] {
+			seen[cdn.AppVersion] = true
+			versions = append(versions, cdn.AppVersion)
+		}
+	}
+	return versions
+}
@@ -0,0 +1,81 @@
+package staticsite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nullstone-io/deployment-sdk/app"
+	"github.com/nullstone-io/deployment-sdk/logging"
+)
+
+const (
+	KeyTemplateAppVersion = "{{app-version}}"
+)
+
+var (
+	_ app.StatusOverviewResult = StatusOverview{}
+	_ app.Statuser             = Statuser{}
+)
+
+type GetCdnStatusesFunc func(ctx context.Context) ([]CdnStatus, error)
+type ListArtifactVersionsFunc func(ctx context.Context) ([]string, error)
+
+// Statuser produces Status and StatusOverview for a static site
+// It is shared by the static site providers (S3, GCS, Azure Blob);
+// each provider supplies how to read its CDNs and how to list its artifact versions.
+type Statuser struct {
+	OsWriters              logging.OsWriters
+	Details                app.Details
+	GetCdnStatusesFn       GetCdnStatusesFunc
+	ListArtifactVersionsFn ListArtifactVersionsFunc
+}
+
+func (s Statuser) StatusOverview(ctx context.Context) (app.StatusOverviewResult, error) {
+	ov := StatusOverview{Cdns: make([]CdnStatusOverview, 0)}
+	cdns, err := s.GetCdnStatusesFn(ctx)
+	if err != nil {
+		return ov, err
+	}
+	for _, cdn := range cdns {
+		ov.Cdns = append(ov.Cdns, CdnStatusOverview{
+			Id:                   cdn.Id,
+			AppVersion:           cdn.AppVersion,
+			PendingInvalidations: len(cdn.PendingInvalidations),
+		})
+	}
+	return ov, nil
+}
+
+func (s Statuser) Status(ctx context.Context) (any, error) {
+	cdns, err := s.GetCdnStatusesFn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	status := Status{Cdns: cdns, ArtifactVersions: make([]string, 0)}
+
+	// Best-effort: a failure to list artifacts is reported, not fatal
+	if versions, err := s.ListArtifactVersionsFn(ctx); err != nil {
+		fmt.Fprintf(s.OsWriters.Stderr(), "static site: unable to list artifact versions: %s\n", err)
+		status.ArtifactsError = err.Error()
+	} else {
+		status.ArtifactVersions = versions
+	}
+	return status, nil
+}
+
+// AppVersionFromPath extracts the app version from a path that was built from an artifacts key template
+// (e.g. template "site/{{app-version}}" and path "/site/v1.2.3/" -> "v1.2.3")
+// Leading and trailing slashes are ignored; an empty string is returned if the path does not match the template.
+func AppVersionFromPath(keyTemplate, path string) string {
+	keyTemplate, path = strings.Trim(keyTemplate, "/"), strings.Trim(path, "/")
+	before, after, found := strings.Cut(keyTemplate, KeyTemplateAppVersion)
+	if !found || !strings.HasPrefix(path, before) || !strings.HasSuffix(path, after) || len(path) <= len(before)+len(after) {
+		return ""
+	}
+	version := strings.TrimSuffix(strings.TrimPrefix(path, before), after)
+	if strings.Contains(version, "/") {
+		return ""
+	}
+	return version
+}
@@ -0,0 +1,83 @@
+package staticsite
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppVersionFromPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		path     string
+		want     string
+	}{
+		{name: "bare version", template: "{{app-version}}", path: "/v1.2.3", want: "v1.2.3"},
+		{name: "prefix", template: "site/{{app-version}}", path: "/site/abc123/", want: "abc123"},
+		{name: "prefix and suffix", template: "/site/{{app-version}}/public", path: "site/v2/public", want: "v2"},
+		{name: "other prefix", template: "site/{{app-version}}", path: "/docs/v2", want: ""},
+		{name: "nested path", template: "{{app-version}}", path: "/site/v2", want: ""},
+		{name: "empty path", template: "{{app-version}}", path: "", want: ""},
+		{name: "no placeholder", template: "site", path: "/site", want: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, AppVersionFromPath(test.template, test.path))
+		})
+	}
+}
+
+func TestStatuser(t *testing.T) {
+	cdns := []CdnStatus{
+		{Id: "a", AppVersion: "v2", PendingInvalidations: []Invalidation{{Id: "I1", Status: "InProgress"}}},
+		{Id: "b", AppVersion: "v2"},
+		{Id: "c"},
+	}
+	stderr := &bytes.Buffer{}
+	s := Statuser{
+		OsWriters: bufferedOsWriters{stdout: &bytes.Buffer{}, stderr: stderr},
+		GetCdnStatusesFn: func(ctx context.Context) ([]CdnStatus, error) {
+			return cdns, nil
+		},
+		ListArtifactVersionsFn: func(ctx context.Context) ([]string, error) {
+			return []string{"v1", "v2"}, nil
+		},
+	}
+
+	ov, err := s.StatusOverview(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, StatusOverview{Cdns: []CdnStatusOverview{
+		{Id: "a", AppVersion: "v2", PendingInvalidations: 1},
+		{Id: "b", AppVersion: "v2"},
+		{Id: "c"},
+	}}, ov)
+	assert.Equal(t, []string{"v2"}, ov.GetDeploymentVersions())
+
+	status, err := s.Status(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Status{Cdns: cdns, ArtifactVersions: []string{"v1", "v2"}}, status)
+
+	t.Run("artifacts unavailable", func(t *testing.T) {
+		s.ListArtifactVersionsFn = func(ctx context.Context) ([]string, error) {
+			return nil, errors.New("access denied")
+		}
+		status, err := s.Status(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, Status{Cdns: cdns, ArtifactVersions: []string{}, ArtifactsError: "access denied"}, status)
+		assert.Contains(t, stderr.String(), "unable to list artifact versions: access denied")
+	})
+}
+
+type bufferedOsWriters struct {
+	stdout, stderr *bytes.Buffer
+}
+
+func (w bufferedOsWriters) Stdout() io.Writer { return w.stdout }
+func (w bufferedOsWriters) Stderr() io.Writer { return w.stderr }
@@ -1,13 +1,19 @@
 package all
 
 import (
+	aws_batch_fargate_provider "github.com/nullstone-io/deployment-sdk/app/container/aws-batch-fargate"
 	aws_ecs_ec2_provider "github.com/nullstone-io/deployment-sdk/app/container/aws-ecs-ec2"
 	aws_ecs_fargate_provider "github.com/nullstone-io/deployment-sdk/app/container/aws-ecs-fargate"
 	aws_eks_provider "github.com/nullstone-io/deployment-sdk/app/container/aws-eks"
+	azure_aks_provider "github.com/nullstone-io/deployment-sdk/app/container/azure-aks"
 	gcp_cloudrun_provider "github.com/nullstone-io/deployment-sdk/app/container/gcp-cloudrun"
 	gcp_gke_service "github.com/nullstone-io/deployment-sdk/app/container/gcp-gke-service"
+	gcp_cloudfunctions_function "github.com/nullstone-io/deployment-sdk/app/serverless/gcp-cloudfunctions-function"
+	"github.com/nullstone-io/deployment-sdk/aws/batch"
 	"github.com/nullstone-io/deployment-sdk/aws/ecs"
 	"github.com/nullstone-io/deployment-sdk/aws/eks"
+	"github.com/nullstone-io/deployment-sdk/azure/aks"
+	"github.com/nullstone-io/deployment-sdk/gcp/cloudfunctions"
 	"github.com/nullstone-io/deployment-sdk/gcp/cloudrun"
 	"github.com/nullstone-io/deployment-sdk/gcp/gke"
 	"github.com/nullstone-io/deployment-sdk/workspace"
@@ -17,10 +23,13 @@ var (
 	// Actioners is a factory for creating a new Actioner from a workspace
 	// If the factory method returns an error, it is wrapped with ActionNotSupportedError
 	Actioners = workspace.Actioners{
-		aws_eks_provider.ModuleContractName:         eks.NewActioner,
-		gcp_gke_service.ModuleContractName:          gke.NewActioner,
-		gcp_cloudrun_provider.ModuleContractName:    cloudrun.NewActioner,
-		aws_ecs_fargate_provider.ModuleContractName: ecs.NewActioner,
-		aws_ecs_ec2_provider.ModuleContractName:     ecs.NewActioner,
+		aws_eks_provider.ModuleContractName:            eks.NewActioner,
+		gcp_gke_service.ModuleContractName:             gke.NewActioner,
+		azure_aks_provider.ModuleContractName:          aks.NewActioner,
+		gcp_cloudrun_provider.ModuleContractName:       cloudrun.NewActioner,
+		aws_ecs_fargate_provider.ModuleContractName:    ecs.NewActioner,
+		aws_ecs_ec2_provider.ModuleContractName:        ecs.NewActioner,
+		aws_batch_fargate_provider.ModuleContractName:  batch.NewActioner,
+		gcp_cloudfunctions_function.ModuleContractName: cloudfunctions.NewActioner,
 	}
 )